@@ -55,9 +55,9 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	sessionCash := session_cash.New(cash, cfg.Redis.Expiration)
+	sessionCash := session_cash.New(cash, cfg.Server.Session.IdleTimeout, cfg.Server.Session.MaxAge)
 
-	authService := auth_service.New(userRepository, sessionCash)
+	authService := auth_service.New(userRepository, sessionCash, nil, nil, nil, auth_service.LoginPolicy{})
 
 	err = authService.RegisterAdmin(context.Background(), login, email, password)
 	if err != nil {
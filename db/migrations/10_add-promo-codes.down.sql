@@ -0,0 +1,7 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS discount;
+
+DROP TABLE IF EXISTS cart_promo_codes;
+DROP TABLE IF EXISTS promo_code_usages;
+DROP TABLE IF EXISTS promo_codes_categories;
+DROP TABLE IF EXISTS promo_codes;
+DROP TYPE IF EXISTS promo_type;
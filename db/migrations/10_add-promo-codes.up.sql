@@ -0,0 +1,45 @@
+CREATE TYPE promo_type AS ENUM(
+    'percent',
+    'fixed',
+    'free_shipping'
+);
+
+CREATE TABLE IF NOT EXISTS promo_codes(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(50) NOT NULL UNIQUE,
+    type promo_type NOT NULL,
+    value NUMERIC NOT NULL DEFAULT 0,
+    min_cart_price NUMERIC NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    usage_limit INTEGER NOT NULL DEFAULT 0,
+    per_user_limit INTEGER NOT NULL DEFAULT 0,
+    used_count INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS promo_codes_categories(
+    promo_code_id UUID REFERENCES promo_codes(id) ON DELETE CASCADE,
+    category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (promo_code_id, category_id)
+);
+
+CREATE TABLE IF NOT EXISTS promo_code_usages(
+    promo_code_id UUID REFERENCES promo_codes(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id),
+    order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (promo_code_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS promo_code_usages_user_idx ON promo_code_usages (promo_code_id, user_id);
+
+CREATE TABLE IF NOT EXISTS cart_promo_codes(
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    promo_code_id UUID REFERENCES promo_codes(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount NUMERIC NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS promotions;
+DROP TYPE IF EXISTS promotion_type;
@@ -0,0 +1,27 @@
+CREATE TYPE promotion_type AS ENUM(
+    'n_for_m',
+    'order_percent',
+    'gift'
+);
+
+CREATE TABLE IF NOT EXISTS promotions(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    type promotion_type NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT true,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+    gift_product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+    buy_quantity INTEGER NOT NULL DEFAULT 0,
+    pay_quantity INTEGER NOT NULL DEFAULT 0,
+    min_cart_price NUMERIC NOT NULL DEFAULT 0,
+    value NUMERIC NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS promotions_priority_idx ON promotions (priority DESC) WHERE is_active;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS price_changes;
+
+ALTER TABLE products DROP COLUMN IF EXISTS compare_at_price;
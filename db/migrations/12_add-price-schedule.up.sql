@@ -0,0 +1,26 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS compare_at_price NUMERIC;
+
+CREATE TABLE IF NOT EXISTS price_changes(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+    price NUMERIC NOT NULL,
+    compare_at_price NUMERIC,
+    starts_at TIMESTAMP NOT NULL,
+    applied_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS price_changes_pending_idx ON price_changes (starts_at) WHERE applied_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS price_history(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+    price NUMERIC NOT NULL,
+    compare_at_price NUMERIC,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS price_history_product_idx ON price_history (product_id, created_at);
+
+INSERT INTO price_history (product_id, price)
+SELECT id, price FROM products;
@@ -0,0 +1,8 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_price;
+
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS shipping_rates;
+DROP TABLE IF EXISTS shipping_methods;
+DROP TYPE IF EXISTS shipping_type;
+
+ALTER TABLE products DROP COLUMN IF EXISTS weight;
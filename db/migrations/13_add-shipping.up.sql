@@ -0,0 +1,37 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS weight INTEGER NOT NULL DEFAULT 0;
+
+CREATE TYPE shipping_type AS ENUM(
+    'courier',
+    'pickup',
+    'post'
+);
+
+CREATE TABLE IF NOT EXISTS shipping_methods(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    type shipping_type NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS shipping_rates(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    method_id UUID REFERENCES shipping_methods(id) ON DELETE CASCADE,
+    region VARCHAR(100) NOT NULL DEFAULT '',
+    max_weight INTEGER NOT NULL DEFAULT 0,
+    price NUMERIC NOT NULL,
+    min_days INTEGER NOT NULL DEFAULT 0,
+    max_days INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS payments(
+    id VARCHAR(100) PRIMARY KEY,
+    user_id UUID REFERENCES users(id),
+    amount NUMERIC NOT NULL,
+    shipping_method_id UUID REFERENCES shipping_methods(id) ON DELETE SET NULL,
+    shipping_price NUMERIC NOT NULL DEFAULT 0,
+    region VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_price NUMERIC NOT NULL DEFAULT 0;
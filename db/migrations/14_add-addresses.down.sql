@@ -0,0 +1,8 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS comment;
+ALTER TABLE payments DROP COLUMN IF EXISTS postal_code;
+ALTER TABLE payments DROP COLUMN IF EXISTS street;
+ALTER TABLE payments DROP COLUMN IF EXISTS phone;
+ALTER TABLE payments DROP COLUMN IF EXISTS recipient;
+ALTER TABLE payments DROP COLUMN IF EXISTS address_id;
+
+DROP TABLE IF EXISTS addresses;
@@ -0,0 +1,6 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS tax;
+
+ALTER TABLE products DROP COLUMN IF EXISTS tax_class;
+ALTER TABLE categories DROP COLUMN IF EXISTS tax_class;
+
+DROP TYPE IF EXISTS tax_class;
@@ -0,0 +1,11 @@
+CREATE TYPE tax_class AS ENUM(
+    'vat20',
+    'vat10',
+    'vat0',
+    'no_vat'
+);
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS tax_class tax_class NOT NULL DEFAULT 'vat20';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tax_class tax_class;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax NUMERIC NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+DELETE FROM tokens WHERE type = 'password-reset';
+
+ALTER TYPE token_type RENAME TO token_type_old;
+CREATE TYPE token_type AS ENUM(
+    'email-verify'
+);
+ALTER TABLE tokens ALTER COLUMN type TYPE token_type USING type::text::token_type;
+DROP TYPE token_type_old;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tokens_expires_at_idx;
+
+ALTER TABLE tokens DROP COLUMN IF EXISTS used_at;
+ALTER TABLE tokens DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE tokens DROP COLUMN IF EXISTS created_at;
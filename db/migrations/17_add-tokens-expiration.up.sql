@@ -0,0 +1,5 @@
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP + INTERVAL '24 hours';
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS used_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS tokens_expires_at_idx ON tokens (expires_at);
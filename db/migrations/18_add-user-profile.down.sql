@@ -0,0 +1,14 @@
+DELETE FROM tokens WHERE type = 'email-change';
+
+ALTER TYPE token_type RENAME TO token_type_old;
+CREATE TYPE token_type AS ENUM(
+    'email-verify',
+    'password-reset'
+);
+ALTER TABLE tokens ALTER COLUMN type TYPE token_type USING type::text::token_type;
+DROP TYPE token_type_old;
+
+ALTER TABLE users DROP COLUMN IF EXISTS pending_email;
+ALTER TABLE users DROP COLUMN IF EXISTS phone;
+ALTER TABLE users DROP COLUMN IF EXISTS last_name;
+ALTER TABLE users DROP COLUMN IF EXISTS first_name;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS users_deletion_requested_at_idx;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_requested_at;
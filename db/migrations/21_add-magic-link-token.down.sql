@@ -0,0 +1,10 @@
+DELETE FROM tokens WHERE type = 'magic-link';
+
+ALTER TYPE token_type RENAME TO token_type_old;
+CREATE TYPE token_type AS ENUM(
+    'email-verify',
+    'password-reset',
+    'email-change'
+);
+ALTER TABLE tokens ALTER COLUMN type TYPE token_type USING type::text::token_type;
+DROP TYPE token_type_old;
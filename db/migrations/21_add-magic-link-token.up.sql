@@ -0,0 +1 @@
+ALTER TYPE token_type ADD VALUE IF NOT EXISTS 'magic-link';
@@ -0,0 +1,3 @@
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS email VARCHAR(50);
+
+DELETE FROM tokens WHERE type = 'email-change' AND used_at IS NULL;
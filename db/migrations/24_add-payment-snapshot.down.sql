@@ -0,0 +1,8 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS total;
+
+DROP TABLE IF EXISTS payment_items;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS promo_code_ids;
+ALTER TABLE payments DROP COLUMN IF EXISTS tax;
+ALTER TABLE payments DROP COLUMN IF EXISTS discount;
+ALTER TABLE payments DROP COLUMN IF EXISTS price;
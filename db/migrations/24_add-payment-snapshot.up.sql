@@ -0,0 +1,17 @@
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS price NUMERIC NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS discount NUMERIC NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS tax NUMERIC NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS promo_code_ids UUID[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS payment_items(
+    payment_id VARCHAR(100) REFERENCES payments(id) ON DELETE CASCADE,
+    product_id UUID REFERENCES products(id),
+    price NUMERIC NOT NULL,
+    total NUMERIC NOT NULL,
+    quantity INTEGER NOT NULL,
+    PRIMARY KEY (payment_id, product_id)
+);
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS total NUMERIC;
+UPDATE order_items SET total = price * quantity WHERE total IS NULL;
+ALTER TABLE order_items ALTER COLUMN total SET NOT NULL;
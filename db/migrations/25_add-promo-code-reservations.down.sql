@@ -0,0 +1,10 @@
+WITH released AS (
+    DELETE FROM promo_code_usages WHERE order_id IS NULL RETURNING promo_code_id
+)
+UPDATE promo_codes pc SET used_count = GREATEST(pc.used_count - r.count, 0)
+FROM (SELECT promo_code_id, COUNT(*) AS count FROM released GROUP BY promo_code_id) r
+WHERE pc.id = r.promo_code_id;
+
+DROP INDEX IF EXISTS promo_code_usages_payment_idx;
+ALTER TABLE promo_code_usages ADD PRIMARY KEY (promo_code_id, order_id);
+ALTER TABLE promo_code_usages DROP COLUMN IF EXISTS payment_id;
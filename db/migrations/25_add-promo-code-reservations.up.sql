@@ -0,0 +1,8 @@
+ALTER TABLE promo_code_usages ADD COLUMN IF NOT EXISTS payment_id VARCHAR(100);
+UPDATE promo_code_usages u SET payment_id = o.payment_id
+FROM orders o
+WHERE o.id = u.order_id AND u.payment_id IS NULL;
+
+ALTER TABLE promo_code_usages DROP CONSTRAINT IF EXISTS promo_code_usages_pkey;
+ALTER TABLE promo_code_usages ALTER COLUMN order_id DROP NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS promo_code_usages_payment_idx ON promo_code_usages (promo_code_id, payment_id);
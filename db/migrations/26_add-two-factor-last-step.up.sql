@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS two_factor_last_step BIGINT;
@@ -0,0 +1 @@
+ALTER TABLE price_changes ALTER COLUMN starts_at TYPE TIMESTAMPTZ USING starts_at AT TIME ZONE 'UTC';
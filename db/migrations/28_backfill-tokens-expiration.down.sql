@@ -0,0 +1 @@
+ALTER TABLE tokens ALTER COLUMN expires_at SET DEFAULT CURRENT_TIMESTAMP + INTERVAL '24 hours';
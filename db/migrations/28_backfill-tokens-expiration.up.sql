@@ -0,0 +1,4 @@
+UPDATE tokens SET expires_at = created_at + INTERVAL '1 hour'
+WHERE type = 'password-reset' AND expires_at = created_at + INTERVAL '24 hours';
+
+ALTER TABLE tokens ALTER COLUMN expires_at DROP DEFAULT;
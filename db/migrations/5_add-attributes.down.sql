@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS products_attributes_value_idx;
+DROP TABLE IF EXISTS products_attributes;
+DROP TABLE IF EXISTS attributes;
+DROP TYPE IF EXISTS attribute_type;
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TYPE attribute_type AS ENUM(
+    'string',
+    'number',
+    'bool'
+);
+
+CREATE TABLE IF NOT EXISTS attributes(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    type attribute_type NOT NULL,
+    unit VARCHAR(20),
+    is_required BOOLEAN DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    UNIQUE (category_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS products_attributes(
+    product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+    attribute_id UUID REFERENCES attributes(id) ON DELETE CASCADE,
+    value TEXT NOT NULL,
+    PRIMARY KEY (product_id, attribute_id)
+);
+
+CREATE INDEX IF NOT EXISTS products_attributes_value_idx ON products_attributes (attribute_id, value);
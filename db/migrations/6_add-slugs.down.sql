@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS slug_redirects;
+DROP TYPE IF EXISTS slug_entity;
+ALTER TABLE products DROP COLUMN IF EXISTS slug;
+ALTER TABLE categories DROP COLUMN IF EXISTS slug;
\ No newline at end of file
@@ -0,0 +1,22 @@
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS slug VARCHAR(120);
+UPDATE categories SET slug = id::text WHERE slug IS NULL;
+ALTER TABLE categories ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE categories ADD CONSTRAINT categories_slug_key UNIQUE (slug);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS slug VARCHAR(120);
+UPDATE products SET slug = id::text WHERE slug IS NULL;
+ALTER TABLE products ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE products ADD CONSTRAINT products_slug_key UNIQUE (slug);
+
+CREATE TYPE slug_entity AS ENUM(
+    'product',
+    'category'
+);
+
+CREATE TABLE IF NOT EXISTS slug_redirects(
+    entity_type slug_entity NOT NULL,
+    old_slug VARCHAR(120) NOT NULL,
+    entity_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entity_type, old_slug)
+);
@@ -0,0 +1,8 @@
+ALTER TABLE products DROP COLUMN IF EXISTS reviews_count;
+ALTER TABLE products DROP COLUMN IF EXISTS rating;
+
+DROP TABLE IF EXISTS reviews;
+DROP TYPE IF EXISTS review_status;
+
+DROP TABLE IF EXISTS order_items;
+DROP TABLE IF EXISTS orders;
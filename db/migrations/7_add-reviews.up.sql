@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS orders(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id),
+    payment_id TEXT NOT NULL UNIQUE,
+    price NUMERIC NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS order_items(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
+    product_id UUID REFERENCES products(id),
+    price NUMERIC NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS orders_user_idx ON orders USING HASH (user_id);
+CREATE INDEX IF NOT EXISTS order_items_product_idx ON order_items (product_id, order_id);
+
+CREATE TYPE review_status AS ENUM(
+    'pending',
+    'approved',
+    'rejected'
+);
+
+CREATE TABLE IF NOT EXISTS reviews(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id),
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text TEXT NOT NULL DEFAULT '',
+    status review_status DEFAULT 'pending',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    UNIQUE(product_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS reviews_status_idx ON reviews (status, created_at);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS rating NUMERIC NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reviews_count INTEGER NOT NULL DEFAULT 0;
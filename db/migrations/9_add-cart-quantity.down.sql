@@ -0,0 +1,4 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS quantity;
+
+ALTER TABLE cart_items DROP CONSTRAINT IF EXISTS cart_items_user_product_key;
+ALTER TABLE cart_items DROP COLUMN IF EXISTS quantity;
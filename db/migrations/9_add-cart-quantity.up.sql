@@ -0,0 +1,20 @@
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0);
+
+UPDATE cart_items c
+SET quantity = g.quantity
+FROM (
+    SELECT user_id, product_id, MIN(id::text) AS keep_id, COUNT(*) AS quantity
+    FROM cart_items
+    GROUP BY user_id, product_id
+) g
+WHERE c.id::text = g.keep_id;
+
+DELETE FROM cart_items c
+USING cart_items d
+WHERE c.user_id = d.user_id
+AND c.product_id = d.product_id
+AND c.id::text > d.id::text;
+
+ALTER TABLE cart_items ADD CONSTRAINT cart_items_user_product_key UNIQUE (user_id, product_id);
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS quantity INTEGER NOT NULL DEFAULT 1;
@@ -2,29 +2,62 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/config"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/oidc"
+	product_local "github.com/AlexMickh/coledzh-shop-backend/internal/repository/local/product"
 	product_s3 "github.com/AlexMickh/coledzh-shop-backend/internal/repository/minio/product"
+	account_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/account"
+	address_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/address"
+	attribute_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/attribute"
 	cart_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/cart"
 	category_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/category"
+	identity_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/identity"
 	product_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/product"
+	promo_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/promo"
+	promotion_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/promotion"
+	review_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/review"
+	shipping_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/shipping"
 	token_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/token"
 	user_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/user"
+	wishlist_repository "github.com/AlexMickh/coledzh-shop-backend/internal/repository/postgres/wishlist"
+	attempts_cash "github.com/AlexMickh/coledzh-shop-backend/internal/repository/redis/attempts"
+	cart_cash "github.com/AlexMickh/coledzh-shop-backend/internal/repository/redis/cart"
 	category_cash "github.com/AlexMickh/coledzh-shop-backend/internal/repository/redis/category"
+	cooldown_cash "github.com/AlexMickh/coledzh-shop-backend/internal/repository/redis/cooldown"
+	login_cash "github.com/AlexMickh/coledzh-shop-backend/internal/repository/redis/login"
+	oidc_cash "github.com/AlexMickh/coledzh-shop-backend/internal/repository/redis/oidc"
+	ratelimit_cash "github.com/AlexMickh/coledzh-shop-backend/internal/repository/redis/ratelimit"
 	session_cash "github.com/AlexMickh/coledzh-shop-backend/internal/repository/redis/session"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/server"
+	account_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/account"
+	address_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/address"
+	attribute_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/attribute"
 	auth_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/auth"
 	cart_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/cart"
 	category_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/category"
+	oidc_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/oidc"
 	product_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/product"
+	promo_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/promo"
+	promotion_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/promotion"
+	review_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/review"
+	shipping_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/shipping"
 	token_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/token"
+	twofactor_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/twofactor"
 	user_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/user"
+	wishlist_service "github.com/AlexMickh/coledzh-shop-backend/internal/services/wishlist"
 	minio_client "github.com/AlexMickh/coledzh-shop-backend/pkg/clients/minio"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/clients/postgresql"
 	redis_client "github.com/AlexMickh/coledzh-shop-backend/pkg/clients/redis"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/job"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/minio/minio-go/v7"
@@ -32,10 +65,12 @@ import (
 )
 
 type App struct {
-	srv *server.Server
-	db  *pgxpool.Pool
-	rdb *redis.Client
-	s3  *minio.Client
+	srv      *server.Server
+	db       *pgxpool.Pool
+	rdb      *redis.Client
+	s3       *minio.Client
+	jobs     []job.Job
+	stopJobs func()
 }
 
 func New(ctx context.Context, cfg *config.Config) *App {
@@ -64,6 +99,15 @@ func New(ctx context.Context, cfg *config.Config) *App {
 	categoryRepository := category_repository.New(db)
 	productRepository := product_repository.New(db)
 	cartRepository := cart_repository.New(db)
+	attributeRepository := attribute_repository.New(db)
+	reviewRepository := review_repository.New(db)
+	promoRepository := promo_repository.New(db)
+	promotionRepository := promotion_repository.New(db)
+	shippingRepository := shipping_repository.New(db)
+	wishlistRepository := wishlist_repository.New(db)
+	addressRepository := address_repository.New(db)
+	accountRepository := account_repository.New(db)
+	identityRepository := identity_repository.New(db)
 
 	log.Info("initing redis")
 	cash, err := redis_client.New(
@@ -77,31 +121,125 @@ func New(ctx context.Context, cfg *config.Config) *App {
 		log.Error("failed to init redis", logger.Err(err))
 		os.Exit(1)
 	}
-	sessionCash := session_cash.New(cash, cfg.Redis.Expiration)
+	sessionCash := session_cash.New(cash, cfg.Server.Session.IdleTimeout, cfg.Server.Session.MaxAge)
+	cooldownCash := cooldown_cash.New(cash, cfg.Tokens.ResendCooldown)
+	attemptsCash := attempts_cash.New(cash, cfg.Login.Window)
+	loginCash := login_cash.New(cash, cfg.TwoFactor.PendingLoginTTL)
+	oidcCash := oidc_cash.New(cash, cfg.OIDC.StateTTL)
+	ratelimitCash := ratelimit_cash.New(cash)
 	categoryCash := category_cash.New(cash, cfg.Redis.Expiration)
+	cartCash := cart_cash.New(cash, cfg.Cart.GuestExpire)
 
-	log.Info("initing minio")
-	s3, err := minio_client.New(
-		ctx,
-		cfg.Minio.Endpoint,
-		cfg.Minio.User,
-		cfg.Minio.Password,
-		cfg.Minio.BucketName,
-		cfg.Minio.IsUseSsl,
+	var (
+		s3           *minio.Client
+		imageStorage product_service.ImageStorage
 	)
-	if err != nil {
-		log.Error("failed to init minio", logger.Err(err))
+	switch cfg.Storage.Type {
+	case consts.StorageTypeMinio:
+		log.Info("initing minio")
+		s3, err = minio_client.New(
+			ctx,
+			cfg.Minio.Endpoint,
+			cfg.Minio.User,
+			cfg.Minio.Password,
+			cfg.Minio.BucketName,
+			cfg.Minio.IsUseSsl,
+		)
+		if err != nil {
+			log.Error("failed to init minio", logger.Err(err))
+			os.Exit(1)
+		}
+		imageStorage = product_s3.New(s3, cfg.Minio.BucketName)
+	case consts.StorageTypeLocal:
+		log.Info("initing local storage", slog.String("path", cfg.Storage.LocalPath))
+		imageStorage, err = product_local.New(cfg.Storage.LocalPath, cfg.Storage.BaseUrl)
+		if err != nil {
+			log.Error("failed to init local storage", logger.Err(err))
+			os.Exit(1)
+		}
+	default:
+		log.Error("failed to init storage", logger.Err(errs.ErrUnknownStorageType), slog.String("type", cfg.Storage.Type))
 		os.Exit(1)
 	}
-	productS3 := product_s3.New(s3, cfg.Minio.BucketName)
 
 	log.Info("initing service layer")
-	authService := auth_service.New(userRepository, sessionCash)
-	tokenService := token_service.New(tokenRepository, authService)
+	promoService := promo_service.New(promoRepository, cartRepository)
+	promotionService := promotion_service.New(promotionRepository)
+	cartService := cart_service.New(
+		cartRepository,
+		cartCash,
+		cfg.Cart.MergeStrategy,
+		cfg.Cart.MaxQuantity,
+		promotionService,
+		promoService,
+	)
+	shippingService := shipping_service.New(shippingRepository, cartService)
+	authService := auth_service.New(userRepository, sessionCash, cartService, attemptsCash, loginCash, auth_service.LoginPolicy{
+		AccountAttempts: cfg.Login.AccountAttempts,
+		IPAttempts:      cfg.Login.IPAttempts,
+		Lockout:         cfg.Login.Lockout,
+		MaxLockout:      cfg.Login.MaxLockout,
+	})
+	twoFactorService := twofactor_service.New(
+		userRepository,
+		sessionCash,
+		attemptsCash,
+		twofactor_service.CodePolicy{
+			Attempts:   cfg.Login.AccountAttempts,
+			Lockout:    cfg.Login.Lockout,
+			MaxLockout: cfg.Login.MaxLockout,
+		},
+		cfg.TwoFactor.Issuer,
+		cfg.TwoFactor.RecoveryCodes,
+	)
+	tokenService := token_service.New(tokenRepository, authService, cooldownCash, map[string]time.Duration{
+		consts.TokenTypeEmailVerify:   cfg.Tokens.EmailVerifyTTL,
+		consts.TokenTypePasswordReset: cfg.Tokens.PasswordResetTTL,
+		consts.TokenTypeEmailChange:   cfg.Tokens.EmailChangeTTL,
+		consts.TokenTypeMagicLink:     cfg.Tokens.MagicLinkTTL,
+	})
 	categoryService := category_service.New(categoryRepository, categoryCash)
-	userService := user_service.New(sessionCash)
-	productService := product_service.New(productRepository, productS3)
-	cartService := cart_service.New(cartRepository)
+	userService := user_service.New(userRepository, sessionCash, cfg.Server.Session.IdleTimeout, cfg.Server.Session.MaxAge)
+	productService := product_service.New(productRepository, imageStorage, attributeRepository)
+	attributeService := attribute_service.New(attributeRepository)
+	reviewService := review_service.New(reviewRepository)
+	wishlistService := wishlist_service.New(wishlistRepository, cfg.Cart.MaxQuantity)
+	addressService := address_service.New(addressRepository)
+	accountService := account_service.New(
+		accountRepository,
+		userRepository,
+		addressService,
+		cartService,
+		sessionCash,
+		cfg.Account.DeletionGracePeriod,
+	)
+	oidcClient := &http.Client{Timeout: cfg.OIDC.Timeout}
+	oidcProviders := make(map[string]oidc_service.Provider, len(cfg.OIDC.Providers))
+	for name, provider := range cfg.OIDC.Providers {
+		oidcProviders[name] = oidc.New(
+			name,
+			oidcClient,
+			provider.Issuer,
+			provider.ClientId,
+			provider.ClientSecret,
+			provider.RedirectURL,
+			provider.Scopes,
+		)
+	}
+	oidcService := oidc_service.New(oidcProviders, oidcCash, identityRepository, authService)
+
+	log.Info("backfilling slugs")
+	if err := categoryService.BackfillSlugs(ctx); err != nil {
+		log.Error("failed to backfill category slugs", logger.Err(err))
+	}
+	if err := productService.BackfillSlugs(ctx); err != nil {
+		log.Error("failed to backfill product slugs", logger.Err(err))
+	}
+
+	if cfg.Cart.CookieSecret == "" {
+		log.Warn("cart cookie secret is not set, using random one, guest carts will be lost on restart")
+		cfg.Cart.CookieSecret = rand.Text()
+	}
 
 	log.Info("initing server")
 	srv, err := server.New(
@@ -115,16 +253,50 @@ func New(ctx context.Context, cfg *config.Config) *App {
 		productService,
 		cartService,
 		cfg.Yookassa,
+		cfg.Storage,
+		attributeService,
+		reviewService,
+		wishlistService,
+		cfg.Cart,
+		promoService,
+		promotionService,
+		shippingService,
+		addressService,
+		accountService,
+		twoFactorService,
+		cfg.OIDC,
+		oidcService,
+		ratelimitCash,
 	)
 	if err != nil {
 		log.Error("failed to init server", logger.Err(err))
 		os.Exit(1)
 	}
 
+	jobs := []job.Job{
+		{
+			Name:     "apply price changes",
+			Interval: cfg.Jobs.PriceChangesInterval,
+			Fn:       productService.ApplyPriceChanges,
+		},
+		{
+			Name:     "cleanup tokens",
+			Interval: cfg.Jobs.TokensCleanupInterval,
+			Fn:       tokenService.CleanupTokens,
+		},
+		{
+			Name:     "anonymise deleted accounts",
+			Interval: cfg.Jobs.AccountsDeleteInterval,
+			Fn:       accountService.AnonymiseAccounts,
+		},
+	}
+
 	return &App{
-		srv: srv,
-		db:  db,
-		rdb: cash,
+		srv:  srv,
+		db:   db,
+		rdb:  cash,
+		s3:   s3,
+		jobs: jobs,
 	}
 }
 
@@ -141,10 +313,17 @@ func (a *App) Run(ctx context.Context) {
 	}()
 
 	log.Info("server started", slog.String("addr", a.srv.Addr()))
+
+	a.stopJobs = job.Run(ctx, a.jobs...)
 }
 
+// GracefulStop stops server and waits for running jobs
+// before connections they use are closed
 func (a *App) GracefulStop(ctx context.Context) {
 	a.srv.GracefulStop(ctx)
+	if a.stopJobs != nil {
+		a.stopJobs()
+	}
 	a.db.Close()
 	a.rdb.Close()
 }
@@ -1,28 +1,73 @@
 package config
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	Env      string         `yaml:"env" env-default:"prod"`
-	Server   ServerConfig   `yaml:"server"`
-	DB       DBConfig       `yaml:"db"`
-	Redis    RedisConfig    `yaml:"redis"`
-	Minio    MinioConfig    `yaml:"minio"`
-	Mail     MailConfig     `yaml:"mail"`
-	Yookassa YookassaConfig `yaml:"yookassa"`
+	Env       string          `yaml:"env" env-default:"prod"`
+	Server    ServerConfig    `yaml:"server"`
+	DB        DBConfig        `yaml:"db"`
+	Redis     RedisConfig     `yaml:"redis"`
+	Minio     MinioConfig     `yaml:"minio"`
+	Storage   StorageConfig   `yaml:"storage"`
+	Mail      MailConfig      `yaml:"mail"`
+	Yookassa  YookassaConfig  `yaml:"yookassa"`
+	Cart      CartConfig      `yaml:"cart"`
+	Jobs      JobsConfig      `yaml:"jobs"`
+	Tokens    TokensConfig    `yaml:"tokens"`
+	Account   AccountConfig   `yaml:"account"`
+	Login     LoginConfig     `yaml:"login"`
+	TwoFactor TwoFactorConfig `yaml:"two_factor"`
+	OIDC      OIDCConfig      `yaml:"oidc"`
 }
 
+// ServerConfig TrustedProxies are CIDRs or addresses of reverse proxies
+// whose X-Real-IP header is used as client address, for example
+// SERVER_TRUSTED_PROXIES=10.0.0.0/8,127.0.0.1. Behind a proxy it must be
+// set, otherwise all clients get proxy address: payment webhooks are
+// rejected and login lockouts and rate limits are shared by all clients
 type ServerConfig struct {
-	Addr        string        `yaml:"addr" env-default:"0.0.0.0:50070"`
-	Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
-	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
+	Addr           string          `yaml:"addr" env-default:"0.0.0.0:50070"`
+	Timeout        time.Duration   `yaml:"timeout" env-default:"4s"`
+	IdleTimeout    time.Duration   `yaml:"idle_timeout" env-default:"60s"`
+	TrustedProxies []string        `env:"SERVER_TRUSTED_PROXIES" yaml:"trusted_proxies" env-separator:","`
+	Session        SessionConfig   `yaml:"session"`
+	RateLimit      RateLimitConfig `yaml:"rate_limit"`
+}
+
+// SessionConfig IdleTimeout is prolonged on every request,
+// session can not live longer than MaxAge since login
+type SessionConfig struct {
+	CookieName  string        `yaml:"cookie_name" env-default:"session_id"`
+	Secure      bool          `yaml:"secure" env-default:"false"`
+	SameSite    string        `yaml:"same_site" env-default:"strict"`
+	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"24h"`
+	MaxAge      time.Duration `yaml:"max_age" env-default:"120h"`
+}
+
+// RateLimitConfig Routes are keyed by "METHOD /pattern" or "/pattern"
+// with chi route pattern like /products/{id}, requests to other routes
+// are limited by Default
+type RateLimitConfig struct {
+	Enabled bool                     `yaml:"enabled" env-default:"true"`
+	Default RateLimitRule            `yaml:"default"`
+	Routes  map[string]RateLimitRule `yaml:"routes"`
+}
+
+// RateLimitRule allows Limit requests per Window for each Key,
+// key is one of ip, user or route
+type RateLimitRule struct {
+	Limit  int           `yaml:"limit" env-default:"100"`
+	Window time.Duration `yaml:"window" env-default:"1m"`
+	Key    string        `yaml:"key" env-default:"ip"`
 }
 
 type DBConfig struct {
@@ -49,21 +94,97 @@ type MinioConfig struct {
 	Endpoint   string `env:"MINIO_ENDPOINT" yaml:"endpoint" env-default:"localhost:9000"`
 	Port       int    `env:"MINIO_PORT" yaml:"port" env-default:"9000"`
 	User       string `env:"MINIO_ROOT_USER" yaml:"user" env-default:"minio"`
-	Password   string `env:"MINIO_ROOT_PASSWORD" yaml:"password" env-required:"true"`
+	Password   string `env:"MINIO_ROOT_PASSWORD" yaml:"password"`
 	BucketName string `env:"MINIO_BUCKET_NAME" yaml:"bucket_name" env-default:"users"`
 	IsUseSsl   bool   `env:"MINIO_USE_SSL" yaml:"is_use_ssl" env-default:"false"`
 }
 
+type StorageConfig struct {
+	Type      string `env:"STORAGE_TYPE" yaml:"type" env-default:"minio"`
+	LocalPath string `env:"STORAGE_LOCAL_PATH" yaml:"local_path" env-default:"./images"`
+	BaseUrl   string `env:"STORAGE_BASE_URL" yaml:"base_url" env-default:"http://localhost:50070/images"`
+}
+
+// MailConfig FrontendURL is base url of frontend pages which emailed
+// links lead to, pages send token from link to api
 type MailConfig struct {
-	Host     string `env:"MAIL_HOST" yaml:"host" env-required:"true"`
-	Port     int    `env:"MAIL_PORT" yaml:"port" env-required:"true"`
-	FromAddr string `env:"MAIL_FROM_ADDR" yaml:"from_addr" env-required:"true"`
-	Password string `env:"MAIL_PASSWORD" yaml:"password" env-required:"true"`
+	Host        string `env:"MAIL_HOST" yaml:"host" env-required:"true"`
+	Port        int    `env:"MAIL_PORT" yaml:"port" env-required:"true"`
+	FromAddr    string `env:"MAIL_FROM_ADDR" yaml:"from_addr" env-required:"true"`
+	Password    string `env:"MAIL_PASSWORD" yaml:"password" env-required:"true"`
+	FrontendURL string `env:"MAIL_FRONTEND_URL" yaml:"frontend_url" env-default:"http://localhost:3000"`
 }
 
 type YookassaConfig struct {
 	ShopId    string `yaml:"shop_id" env-required:"true"`
 	SecretKey string `yaml:"secret_key" env-required:"true"`
+	Receipts  bool   `yaml:"receipts" env-default:"false"`
+}
+
+// CartConfig CookieSecret signs guest cart cookie, when it is not set
+// random secret is generated on start and guest carts are lost on restart
+type CartConfig struct {
+	CookieSecret  string        `env:"CART_COOKIE_SECRET" yaml:"cookie_secret"`
+	GuestExpire   time.Duration `env:"CART_GUEST_EXPIRE" yaml:"guest_expire" env-default:"720h"`
+	MergeStrategy string        `env:"CART_MERGE_STRATEGY" yaml:"merge_strategy" env-default:"max"`
+	MaxQuantity   int           `env:"CART_MAX_QUANTITY" yaml:"max_quantity" env-default:"99"`
+}
+
+type JobsConfig struct {
+	PriceChangesInterval   time.Duration `env:"JOBS_PRICE_CHANGES_INTERVAL" yaml:"price_changes_interval" env-default:"1m"`
+	TokensCleanupInterval  time.Duration `env:"JOBS_TOKENS_CLEANUP_INTERVAL" yaml:"tokens_cleanup_interval" env-default:"1h"`
+	AccountsDeleteInterval time.Duration `env:"JOBS_ACCOUNTS_DELETE_INTERVAL" yaml:"accounts_delete_interval" env-default:"1h"`
+}
+
+// AccountConfig DeletionGracePeriod is time user can cancel account deletion in
+type AccountConfig struct {
+	DeletionGracePeriod time.Duration `env:"ACCOUNT_DELETION_GRACE_PERIOD" yaml:"deletion_grace_period" env-default:"720h"`
+}
+
+// TokensConfig holds lifetime of each token type
+type TokensConfig struct {
+	EmailVerifyTTL   time.Duration `env:"TOKENS_EMAIL_VERIFY_TTL" yaml:"email_verify_ttl" env-default:"24h"`
+	PasswordResetTTL time.Duration `env:"TOKENS_PASSWORD_RESET_TTL" yaml:"password_reset_ttl" env-default:"1h"`
+	EmailChangeTTL   time.Duration `env:"TOKENS_EMAIL_CHANGE_TTL" yaml:"email_change_ttl" env-default:"24h"`
+	MagicLinkTTL     time.Duration `env:"TOKENS_MAGIC_LINK_TTL" yaml:"magic_link_ttl" env-default:"15m"`
+	ResendCooldown   time.Duration `env:"TOKENS_RESEND_COOLDOWN" yaml:"resend_cooldown" env-default:"1m"`
+}
+
+// LoginConfig failed logins are counted per account and per ip, counters are
+// reset after Window without failures. Each failure over attempts limit locks
+// login for Lockout doubled per failure up to MaxLockout
+type LoginConfig struct {
+	AccountAttempts int           `env:"LOGIN_ACCOUNT_ATTEMPTS" yaml:"account_attempts" env-default:"5"`
+	IPAttempts      int           `env:"LOGIN_IP_ATTEMPTS" yaml:"ip_attempts" env-default:"20"`
+	Window          time.Duration `env:"LOGIN_WINDOW" yaml:"window" env-default:"1h"`
+	Lockout         time.Duration `env:"LOGIN_LOCKOUT" yaml:"lockout" env-default:"1m"`
+	MaxLockout      time.Duration `env:"LOGIN_MAX_LOCKOUT" yaml:"max_lockout" env-default:"1h"`
+}
+
+// TwoFactorConfig Issuer is shown in authenticator app, login waits
+// for two factor code for PendingLoginTTL
+type TwoFactorConfig struct {
+	Issuer          string        `env:"TWO_FACTOR_ISSUER" yaml:"issuer" env-default:"Coledzh Shop"`
+	PendingLoginTTL time.Duration `env:"TWO_FACTOR_PENDING_LOGIN_TTL" yaml:"pending_login_ttl" env-default:"5m"`
+	RecoveryCodes   int           `env:"TWO_FACTOR_RECOVERY_CODES" yaml:"recovery_codes" env-default:"10"`
+}
+
+// OIDCConfig Providers are keyed by name used in login url,
+// login must be completed within StateTTL
+type OIDCConfig struct {
+	StateTTL  time.Duration                 `env:"OIDC_STATE_TTL" yaml:"state_ttl" env-default:"10m"`
+	Timeout   time.Duration                 `env:"OIDC_TIMEOUT" yaml:"timeout" env-default:"10s"`
+	Providers map[string]OIDCProviderConfig `yaml:"providers"`
+}
+
+// OIDCProviderConfig RedirectURL is frontend page which sends code
+// and state to callback, openid, email and profile are used if Scopes is empty
+type OIDCProviderConfig struct {
+	Issuer       string   `yaml:"issuer"`
+	ClientId     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectURL  string   `yaml:"redirect_url"`
+	Scopes       []string `yaml:"scopes"`
 }
 
 func MustLoad() *Config {
@@ -86,9 +207,45 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	return cfg, nil
 }
 
+// validate rejects values which are parsed but can not work
+func (c *Config) validate() error {
+	intervals := map[string]time.Duration{
+		"JOBS_PRICE_CHANGES_INTERVAL":   c.Jobs.PriceChangesInterval,
+		"JOBS_TOKENS_CLEANUP_INTERVAL":  c.Jobs.TokensCleanupInterval,
+		"JOBS_ACCOUNTS_DELETE_INTERVAL": c.Jobs.AccountsDeleteInterval,
+	}
+	for name, interval := range intervals {
+		if interval <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", name, interval)
+		}
+	}
+
+	if strings.EqualFold(c.Server.Session.SameSite, "none") && !c.Server.Session.Secure {
+		return errors.New("session cookie with same_site none must be secure, browsers reject it otherwise")
+	}
+
+	if c.Server.RateLimit.Enabled {
+		rules := map[string]RateLimitRule{"default": c.Server.RateLimit.Default}
+		for route, rule := range c.Server.RateLimit.Routes {
+			rules[route] = rule
+		}
+		for name, rule := range rules {
+			if rule.Limit <= 0 || rule.Window <= 0 {
+				return fmt.Errorf("rate limit %q must have positive limit and window, got %d per %s", name, rule.Limit, rule.Window)
+			}
+		}
+	}
+
+	return nil
+}
+
 func fetchPath() string {
 	var path string
 
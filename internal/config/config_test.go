@@ -0,0 +1,91 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_validate(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			Jobs: JobsConfig{
+				PriceChangesInterval:   time.Minute,
+				TokensCleanupInterval:  time.Hour,
+				AccountsDeleteInterval: time.Hour,
+			},
+			Server: ServerConfig{
+				RateLimit: RateLimitConfig{
+					Enabled: true,
+					Default: RateLimitRule{Limit: 100, Window: time.Minute, Key: "ip"},
+					Routes: map[string]RateLimitRule{
+						"POST /auth/login": {Limit: 5, Window: time.Minute, Key: "ip"},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr bool
+	}{
+		{
+			name:    "good case",
+			modify:  func(cfg *Config) {},
+			wantErr: false,
+		},
+		{
+			name:    "zero job interval case",
+			modify:  func(cfg *Config) { cfg.Jobs.TokensCleanupInterval = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "negative job interval case",
+			modify:  func(cfg *Config) { cfg.Jobs.PriceChangesInterval = -time.Minute },
+			wantErr: true,
+		},
+		{
+			name: "same site none without secure case",
+			modify: func(cfg *Config) {
+				cfg.Server.Session = SessionConfig{SameSite: "None", Secure: false}
+			},
+			wantErr: true,
+		},
+		{
+			name: "same site none with secure case",
+			modify: func(cfg *Config) {
+				cfg.Server.Session = SessionConfig{SameSite: "none", Secure: true}
+			},
+			wantErr: false,
+		},
+		{
+			name:    "zero rate limit case",
+			modify:  func(cfg *Config) { cfg.Server.RateLimit.Default.Limit = 0 },
+			wantErr: true,
+		},
+		{
+			name: "zero route window case",
+			modify: func(cfg *Config) {
+				cfg.Server.RateLimit.Routes["POST /auth/login"] = RateLimitRule{Limit: 5, Key: "ip"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "disabled rate limit case",
+			modify: func(cfg *Config) {
+				cfg.Server.RateLimit = RateLimitConfig{Enabled: false}
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			if err := cfg.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,7 +1,40 @@
 package consts
 
 const (
-	TokenTypeEmailVerify = "email-verify"
-	RoleUser             = "user"
-	RoleAdmin            = "admin"
+	TokenTypeEmailVerify   = "email-verify"
+	TokenTypePasswordReset = "password-reset"
+	TokenTypeEmailChange   = "email-change"
+	TokenTypeMagicLink     = "magic-link"
+	RoleUser               = "user"
+	RoleAdmin              = "admin"
+	StorageTypeMinio       = "minio"
+	StorageTypeLocal       = "local"
+	AttributeTypeString    = "string"
+	AttributeTypeNumber    = "number"
+	AttributeTypeBool      = "bool"
+	SlugEntityProduct      = "product"
+	SlugEntityCategory     = "category"
+	ReviewStatusPending    = "pending"
+	ReviewStatusApproved   = "approved"
+	ReviewStatusRejected   = "rejected"
+	CartMergeMax           = "max"
+	CartMergeSum           = "sum"
+	PromoTypePercent       = "percent"
+	PromoTypeFixed         = "fixed"
+	PromoTypeFreeShip      = "free_shipping"
+	AdjustmentPromoCode    = "promo_code"
+	AdjustmentPromotion    = "promotion"
+	PromotionTypeNForM     = "n_for_m"
+	PromotionTypePercent   = "order_percent"
+	PromotionTypeGift      = "gift"
+	ShippingTypeCourier    = "courier"
+	ShippingTypePickup     = "pickup"
+	ShippingTypePost       = "post"
+	TaxClassVat20          = "vat20"
+	TaxClassVat10          = "vat10"
+	TaxClassVat0           = "vat0"
+	TaxClassNoVat          = "no_vat"
+	RateLimitKeyIP         = "ip"
+	RateLimitKeyUser       = "user"
+	RateLimitKeyRoute      = "route"
 )
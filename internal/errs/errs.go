@@ -8,7 +8,69 @@ var (
 	ErrUserNotFound          = errors.New("user not found")
 	ErrEmailNotVerify        = errors.New("email not verify")
 	ErrTokenNotFound         = errors.New("token not found")
+	ErrTokenExpired          = errors.New("token expired")
+	ErrTokenUsed             = errors.New("token already used")
+	ErrEmailAlreadyVerified  = errors.New("email already verified")
+	ErrTooManyRequests       = errors.New("too many requests")
+	ErrWrongPassword         = errors.New("wrong password")
+	ErrEmailChangeNotFound   = errors.New("email change not requested")
+	ErrDeletionNotFound      = errors.New("account deletion not requested")
+	ErrInvalidCredentials    = errors.New("invalid email or password")
+	ErrAccountLocked         = errors.New("account locked")
+	ErrTwoFactorRequired     = errors.New("two factor authentication required")
+	ErrTwoFactorEnabled      = errors.New("two factor authentication already enabled")
+	ErrTwoFactorNotEnabled   = errors.New("two factor authentication not enabled")
+	ErrTwoFactorNotEnrolled  = errors.New("two factor authentication enrolment not started")
+	ErrWrongTwoFactorCode    = errors.New("wrong two factor code")
+	ErrPendingLoginNotFound  = errors.New("login not found or expired")
+	ErrProviderNotFound      = errors.New("identity provider not found")
+	ErrOIDCStateNotFound     = errors.New("identity provider login not found or expired")
+	ErrInvalidIDToken        = errors.New("invalid id token")
+	ErrIdentityNotFound      = errors.New("identity not found")
+	ErrEmailTooLong          = errors.New("email is too long")
 	ErrCategoryAlreadyExists = errors.New("category already axists")
 	ErrNotAdmin              = errors.New("user does not admin")
 	ErrFailedToCash          = errors.New("failed to cashed data")
+	ErrInvalidImageId        = errors.New("invalid image id")
+	ErrUnknownStorageType    = errors.New("unknown storage type")
+	ErrCategoryNotFound      = errors.New("category not found")
+	ErrAttributeNotFound     = errors.New("attribute not found")
+	ErrAttributeExists       = errors.New("attribute already exists")
+	ErrWrongAttributeType    = errors.New("wrong attribute type")
+	ErrUnknownAttribute      = errors.New("attribute does not belong to product categories")
+	ErrInvalidAttribute      = errors.New("invalid attribute value")
+	ErrMissingAttribute      = errors.New("required attribute is missing")
+	ErrProductNotFound       = errors.New("product not found")
+	ErrInvalidSlug           = errors.New("invalid slug")
+	ErrSlugAlreadyExists     = errors.New("slug already exists")
+	ErrReviewAlreadyExists   = errors.New("review already exists")
+	ErrReviewNotFound        = errors.New("review not found")
+	ErrProductNotPurchased   = errors.New("product was not purchased")
+	ErrWrongReviewStatus     = errors.New("wrong review status")
+	ErrCartIsEmpty           = errors.New("cart is empty")
+	ErrPaymentNotFound       = errors.New("payment not found")
+	ErrProductNotInWishlist  = errors.New("product is not in wishlist")
+	ErrWrongQuantity         = errors.New("wrong product quantity")
+	ErrProductNotInCart      = errors.New("product is not in cart")
+	ErrFailedToMergeCart     = errors.New("failed to merge guest cart")
+	ErrUnknownMergeStrategy  = errors.New("unknown cart merge strategy")
+	ErrPromoCodeNotFound     = errors.New("promo code not found")
+	ErrPromoCodeExists       = errors.New("promo code already exists")
+	ErrPromoCodeExpired      = errors.New("promo code is not active")
+	ErrPromoCodeUsedUp       = errors.New("promo code usage limit reached")
+	ErrPromoCodeMinPrice     = errors.New("cart price is less than promo code minimum")
+	ErrPromoCodeNotApplied   = errors.New("promo code is not applicable to cart products")
+	ErrWrongPromoCode        = errors.New("wrong promo code parameters")
+	ErrPromotionNotFound     = errors.New("promotion not found")
+	ErrWrongPromotion        = errors.New("wrong promotion parameters")
+	ErrWrongPrice            = errors.New("wrong price")
+	ErrShippingNotFound      = errors.New("shipping method not found")
+	ErrShippingNotAvailable  = errors.New("shipping method is not available for cart")
+	ErrWrongShippingMethod   = errors.New("wrong shipping method parameters")
+	ErrAddressNotFound       = errors.New("address not found")
+	ErrInvalidPhone          = errors.New("invalid phone number")
+	ErrInvalidPostalCode     = errors.New("invalid postal code")
+	ErrWrongTaxClass         = errors.New("wrong tax class")
+	ErrSessionNotFound       = errors.New("session not found")
+	ErrSessionExpired        = errors.New("session expired")
 )
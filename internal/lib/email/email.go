@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"html/template"
 	"net/smtp"
+	"net/url"
+	"strings"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/config"
 )
@@ -14,6 +16,32 @@ type VerificationEmailVars struct {
 	Token string
 }
 
+type PasswordResetEmailVars struct {
+	Login string
+	Link  string
+}
+
+type EmailChangeVars struct {
+	Login string
+	Token string
+}
+
+type EmailChangedVars struct {
+	Email    string
+	NewEmail string
+	Link     string
+}
+
+type MagicLinkEmailVars struct {
+	Login string
+	Link  string
+}
+
+type LockoutEmailVars struct {
+	Email string
+	Link  string
+}
+
 type Email struct {
 	cfg  config.MailConfig
 	auth smtp.Auth
@@ -27,34 +55,136 @@ func New(cfg config.MailConfig) *Email {
 }
 
 func (e *Email) SendVerification(to string, token, login string) error {
-	const op = "lib.email.Send"
+	const op = "lib.email.SendVerification"
 
-	tmpl, err := template.ParseFiles("./internal/lib/email/templates/verify-email.html")
+	vars := VerificationEmailVars{
+		Login: login,
+		Token: token,
+	}
+	err := e.send(to, "Email", "./internal/lib/email/templates/verify-email.html", vars)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	rendered := new(bytes.Buffer)
-	vars := VerificationEmailVars{
+	return nil
+}
+
+func (e *Email) SendPasswordReset(to string, token, login string) error {
+	const op = "lib.email.SendPasswordReset"
+
+	vars := PasswordResetEmailVars{
+		Login: login,
+		Link:  e.PasswordResetURL(token),
+	}
+	err := e.send(to, "Password reset", "./internal/lib/email/templates/reset-password.html", vars)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PasswordResetURL returns link of frontend page which sends token
+// with new password to /auth/reset-password
+func (e *Email) PasswordResetURL(token string) string {
+	return e.frontendURL("/auth/reset-password", url.Values{"token": {token}})
+}
+
+func (e *Email) SendEmailChange(to string, token, login string) error {
+	const op = "lib.email.SendEmailChange"
+
+	vars := EmailChangeVars{
 		Login: login,
 		Token: token,
 	}
-	if err = tmpl.Execute(rendered, vars); err != nil {
+	err := e.send(to, "Email change", "./internal/lib/email/templates/change-email.html", vars)
+	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	return nil
+}
+
+// SendEmailChanged notifies replaced address that account email was changed
+func (e *Email) SendEmailChanged(to string, newEmail string) error {
+	const op = "lib.email.SendEmailChanged"
+
+	vars := EmailChangedVars{
+		Email:    to,
+		NewEmail: newEmail,
+		Link:     e.frontendURL("/auth/forgot-password", nil),
+	}
+	err := e.send(to, "Email changed", "./internal/lib/email/templates/email-changed.html", vars)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (e *Email) SendMagicLink(to string, token, login string) error {
+	const op = "lib.email.SendMagicLink"
+
+	vars := MagicLinkEmailVars{
+		Login: login,
+		Link:  e.MagicLinkURL(token),
+	}
+	err := e.send(to, "Login link", "./internal/lib/email/templates/magic-link.html", vars)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MagicLinkURL returns link of frontend page which sends token
+// to /auth/magic-link/consume
+func (e *Email) MagicLinkURL(token string) string {
+	return e.frontendURL("/auth/magic-link", url.Values{"token": {token}})
+}
+
+func (e *Email) SendLockout(to string) error {
+	const op = "lib.email.SendLockout"
+
+	vars := LockoutEmailVars{
+		Email: to,
+		Link:  e.frontendURL("/auth/forgot-password", nil),
+	}
+	err := e.send(to, "Login locked", "./internal/lib/email/templates/lockout.html", vars)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (e *Email) frontendURL(path string, query url.Values) string {
+	link := strings.TrimRight(e.cfg.FrontendURL, "/") + path
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+
+	return link
+}
+
+func (e *Email) send(to, subject, templatePath string, vars any) error {
+	tmpl, err := template.ParseFiles(templatePath)
+	if err != nil {
+		return err
+	}
+
+	rendered := new(bytes.Buffer)
+	if err = tmpl.Execute(rendered, vars); err != nil {
+		return err
+	}
+
 	headers := "MIME-version: 1.0;\nContent-Type: text/html; charset=\"UTF-8\";"
 
-	err = smtp.SendMail(
+	return smtp.SendMail(
 		fmt.Sprintf("%s:%d", e.cfg.Host, e.cfg.Port),
 		e.auth,
 		e.cfg.FromAddr,
 		[]string{to},
-		fmt.Appendf(nil, "Subject: Email\n%s\n\n%s", headers, rendered.String()),
+		fmt.Appendf(nil, "Subject: %s\n%s\n\n%s", subject, headers, rendered.String()),
 	)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-
-	return nil
 }
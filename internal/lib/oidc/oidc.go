@@ -0,0 +1,310 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+}
+
+type claims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Audience      audience `json:"aud"`
+	ExpiresAt     int64    `json:"exp"`
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified flag     `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// audience is aud claim which is either string or array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	err := json.Unmarshal(data, &multiple)
+	if err != nil {
+		return err
+	}
+	*a = multiple
+
+	return nil
+}
+
+// flag is boolean claim, some providers send it as string
+type flag bool
+
+func (f *flag) UnmarshalJSON(data []byte) error {
+	*f = flag(strings.Trim(string(data), `"`) == "true")
+
+	return nil
+}
+
+// Provider is OpenID Connect client of one identity provider, it uses
+// authorization code flow with PKCE. Provider endpoints are discovered
+// from issuer on first use
+type Provider struct {
+	name         string
+	client       *http.Client
+	issuer       string
+	clientId     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+
+	mu        sync.Mutex
+	discovery *discovery
+}
+
+func New(
+	name string,
+	client *http.Client,
+	issuer string,
+	clientId string,
+	clientSecret string,
+	redirectURL string,
+	scopes []string,
+) *Provider {
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+
+	return &Provider{
+		name:         name,
+		client:       client,
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+	}
+}
+
+// AuthURL returns url of provider login page, provider redirects
+// back to redirect url with code and state
+func (p *Provider) AuthURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	const op = "lib.oidc.AuthURL"
+
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.clientId)
+	params.Set("redirect_uri", p.redirectURL)
+	params.Set("scope", strings.Join(p.scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", Challenge(verifier))
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return d.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange exchanges code for tokens and returns identity from id token.
+// Id token is received from token endpoint directly over tls, so its claims
+// are checked instead of signature. Email missing in id token is taken
+// from userinfo endpoint
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (models.Identity, error) {
+	const op = "lib.oidc.Exchange"
+
+	d, err := p.discover(ctx)
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.clientId), url.QueryEscape(p.clientSecret))
+
+	var tokens tokenResponse
+	err = p.do(req, &tokens)
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	c, err := parseIDToken(tokens.IDToken)
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%s: %w: %w", op, errs.ErrInvalidIDToken, err)
+	}
+	if c.Issuer != d.Issuer || !slices.Contains(c.Audience, p.clientId) {
+		return models.Identity{}, fmt.Errorf("%s: %w: wrong issuer or audience", op, errs.ErrInvalidIDToken)
+	}
+	if time.Now().Unix() >= c.ExpiresAt {
+		return models.Identity{}, fmt.Errorf("%s: %w: token expired", op, errs.ErrInvalidIDToken)
+	}
+	if c.Nonce != nonce || c.Subject == "" {
+		return models.Identity{}, fmt.Errorf("%s: %w: wrong nonce or subject", op, errs.ErrInvalidIDToken)
+	}
+
+	if c.Email == "" && d.UserinfoEndpoint != "" && tokens.AccessToken != "" {
+		info, err := p.userinfo(ctx, d.UserinfoEndpoint, tokens.AccessToken)
+		if err != nil {
+			return models.Identity{}, fmt.Errorf("%s: %w", op, err)
+		}
+		if info.Subject == c.Subject {
+			c.Email = info.Email
+			c.EmailVerified = info.EmailVerified
+			c.Name = info.Name
+		}
+	}
+
+	return models.Identity{
+		Provider:      p.name,
+		Subject:       c.Subject,
+		Email:         c.Email,
+		EmailVerified: bool(c.EmailVerified),
+		Name:          c.Name,
+	}, nil
+}
+
+// RandomToken returns random url safe string used as state, nonce and verifier
+func RandomToken() (string, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// Challenge returns S256 PKCE code challenge of verifier
+func Challenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// discover loads provider metadata once, failed discovery is retried on next call
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var d discovery
+	err = p.do(req, &d)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("issuer %s does not match %s", d.Issuer, p.issuer)
+	}
+
+	p.discovery = &d
+
+	return p.discovery, nil
+}
+
+func (p *Provider) userinfo(ctx context.Context, endpoint, accessToken string) (claims, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return claims{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	var info claims
+	err = p.do(req, &info)
+	if err != nil {
+		return claims{}, err
+	}
+
+	return info, nil
+}
+
+func (p *Provider) do(req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: status %d: %s", req.Method, req.URL.Path, resp.StatusCode, body)
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+func parseIDToken(token string) (claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims{}, fmt.Errorf("malformed token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims{}, err
+	}
+
+	var c claims
+	err = json.Unmarshal(payload, &c)
+	if err != nil {
+		return claims{}, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,106 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/oidc/oidctest"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_Exchange(t *testing.T) {
+	user := oidctest.User{
+		Subject:       "subject",
+		Email:         "User@Mail.com",
+		EmailVerified: true,
+		Name:          "User",
+	}
+	want := models.Identity{
+		Provider:      "test",
+		Subject:       "subject",
+		Email:         "User@Mail.com",
+		EmailVerified: true,
+		Name:          "User",
+	}
+
+	tests := []struct {
+		name            string
+		emailInUserinfo bool
+		clientId        string
+		nonce           string
+		verifier        string
+		want            models.Identity
+		wantErr         error
+		wantAnyErr      bool
+	}{
+		{
+			name:  "good case",
+			nonce: "nonce",
+			want:  want,
+		},
+		{
+			name:            "email from userinfo case",
+			emailInUserinfo: true,
+			nonce:           "nonce",
+			want:            want,
+		},
+		{
+			name:    "wrong nonce case",
+			nonce:   "other",
+			wantErr: errs.ErrInvalidIDToken,
+		},
+		{
+			name:       "wrong verifier case",
+			nonce:      "nonce",
+			verifier:   "other",
+			wantAnyErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := oidctest.NewProvider("client", "secret", user)
+			defer mock.Close()
+			mock.EmailInUserinfo = tt.emailInUserinfo
+
+			p := New("test", mock.Client(), mock.Issuer(), "client", "secret", "http://localhost/callback", nil)
+
+			verifier, err := RandomToken()
+			require.NoError(t, err)
+
+			authURL, err := p.AuthURL(context.Background(), "state", "nonce", verifier)
+			require.NoError(t, err)
+
+			params, err := url.Parse(authURL)
+			require.NoError(t, err)
+			require.Equal(t, "openid email profile", params.Query().Get("scope"))
+			require.Equal(t, Challenge(verifier), params.Query().Get("code_challenge"))
+
+			code, state, err := mock.Authorize(authURL)
+			require.NoError(t, err)
+			require.Equal(t, "state", state)
+
+			if tt.verifier != "" {
+				verifier = tt.verifier
+			}
+			got, err := p.Exchange(context.Background(), code, verifier, tt.nonce)
+			if tt.wantAnyErr {
+				require.Error(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChallenge(t *testing.T) {
+	// example from RFC 7636 appendix B
+	require.Equal(
+		t,
+		"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"),
+	)
+}
@@ -0,0 +1,22 @@
+package phone
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	phoneRegexp   = regexp.MustCompile(`^(\+7|8)(\d{10})$`)
+	phoneReplacer = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "")
+)
+
+// Normalize validates russian phone number and brings
+// it to +7XXXXXXXXXX form, spaces, dashes and brackets are ignored
+func Normalize(phone string) (string, bool) {
+	matches := phoneRegexp.FindStringSubmatch(phoneReplacer.Replace(phone))
+	if matches == nil {
+		return "", false
+	}
+
+	return "+7" + matches[2], true
+}
@@ -0,0 +1,135 @@
+package tax
+
+import (
+	"math"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+// classes are ordered as they go in receipts and cart breakdown
+var classes = []string{
+	consts.TaxClassVat20,
+	consts.TaxClassVat10,
+	consts.TaxClassVat0,
+	consts.TaxClassNoVat,
+}
+
+var rates = map[string]int{
+	consts.TaxClassVat20: 20,
+	consts.TaxClassVat10: 10,
+	consts.TaxClassVat0:  0,
+	consts.TaxClassNoVat: 0,
+}
+
+// vatCodes are YooKassa receipt vat_code values
+var vatCodes = map[string]int16{
+	consts.TaxClassNoVat: 1,
+	consts.TaxClassVat0:  2,
+	consts.TaxClassVat10: 3,
+	consts.TaxClassVat20: 4,
+}
+
+func IsValidClass(taxClass string) bool {
+	_, ok := rates[taxClass]
+	return ok
+}
+
+// Rate returns VAT rate in percents, unknown class is taxed by the general rate
+func Rate(taxClass string) int {
+	rate, ok := rates[taxClass]
+	if !ok {
+		return rates[consts.TaxClassVat20]
+	}
+	return rate
+}
+
+func VatCode(taxClass string) int16 {
+	code, ok := vatCodes[taxClass]
+	if !ok {
+		return vatCodes[consts.TaxClassVat20]
+	}
+	return code
+}
+
+// Included returns VAT included into amount, prices in shop always include VAT
+func Included(amount float32, taxClass string) float32 {
+	rate := int64(Rate(taxClass))
+	return fromKopecks((toKopecks(amount)*rate*2 + 100 + rate) / (2 * (100 + rate)))
+}
+
+// Calculate spreads cart discount between items and counts VAT included
+// into their prices. Discounts of products go to their items, discount
+// of the whole cart is split in proportion to item prices
+func Calculate(cart models.Cart) models.Cart {
+	totals := make([]int64, len(cart.Items))
+	var sum int64
+	for i, item := range cart.Items {
+		totals[i] = toKopecks(item.Product.Price * float32(item.Quantity))
+		sum += totals[i]
+	}
+
+	discount := min(toKopecks(cart.Discount), sum)
+	for _, adjustment := range cart.Adjustments {
+		if adjustment.ProductId == "" {
+			continue
+		}
+		for i, item := range cart.Items {
+			if item.Product.ID == adjustment.ProductId {
+				amount := min(toKopecks(adjustment.Amount), totals[i], discount)
+				totals[i] -= amount
+				sum -= amount
+				discount -= amount
+				break
+			}
+		}
+	}
+
+	for i := range totals {
+		if sum == 0 {
+			break
+		}
+		share := discount * totals[i] / sum
+		sum -= totals[i]
+		totals[i] -= share
+		discount -= share
+	}
+
+	byClass := make(map[string]int64)
+	var tax int64
+	cart.Items = append([]models.CartItem(nil), cart.Items...)
+	for i := range cart.Items {
+		item := &cart.Items[i]
+		if !IsValidClass(item.TaxClass) {
+			item.TaxClass = consts.TaxClassVat20
+		}
+		item.Total = fromKopecks(totals[i])
+		item.Tax = Included(item.Total, item.TaxClass)
+		byClass[item.TaxClass] += toKopecks(item.Tax)
+		tax += toKopecks(item.Tax)
+	}
+	cart.Tax = fromKopecks(tax)
+
+	cart.Taxes = make([]models.TaxTotal, 0)
+	for _, class := range classes {
+		amount, ok := byClass[class]
+		if !ok {
+			continue
+		}
+		cart.Taxes = append(cart.Taxes, models.TaxTotal{
+			TaxClass: class,
+			Rate:     Rate(class),
+			Amount:   fromKopecks(amount),
+		})
+	}
+
+	return cart
+}
+
+func toKopecks(amount float32) int64 {
+	return int64(math.Round(float64(amount) * 100))
+}
+
+func fromKopecks(amount int64) float32 {
+	return float32(amount) / 100
+}
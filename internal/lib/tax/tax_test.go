@@ -0,0 +1,126 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncluded(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float32
+		taxClass string
+		want     float32
+	}{
+		{
+			name:     "general rate",
+			amount:   1200,
+			taxClass: consts.TaxClassVat20,
+			want:     200,
+		},
+		{
+			name:     "reduced rate",
+			amount:   110,
+			taxClass: consts.TaxClassVat10,
+			want:     10,
+		},
+		{
+			name:     "zero rate",
+			amount:   100,
+			taxClass: consts.TaxClassVat0,
+			want:     0,
+		},
+		{
+			name:     "unknown class",
+			amount:   120,
+			taxClass: "",
+			want:     20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Included(tt.amount, tt.taxClass))
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name       string
+		cart       models.Cart
+		wantTotals []float32
+		wantTax    float32
+		wantTaxes  []models.TaxTotal
+	}{
+		{
+			name: "no discount",
+			cart: models.Cart{
+				Items: []models.CartItem{
+					{Product: models.ProductCard{ID: "1", Price: 600}, Quantity: 2, TaxClass: consts.TaxClassVat20},
+					{Product: models.ProductCard{ID: "2", Price: 220}, Quantity: 1, TaxClass: consts.TaxClassVat10},
+				},
+			},
+			wantTotals: []float32{1200, 220},
+			wantTax:    220,
+			wantTaxes: []models.TaxTotal{
+				{TaxClass: consts.TaxClassVat20, Rate: 20, Amount: 200},
+				{TaxClass: consts.TaxClassVat10, Rate: 10, Amount: 20},
+			},
+		},
+		{
+			name: "product and cart discounts",
+			cart: models.Cart{
+				Discount: 300,
+				Items: []models.CartItem{
+					{Product: models.ProductCard{ID: "1", Price: 600}, Quantity: 2, TaxClass: consts.TaxClassVat20},
+					{Product: models.ProductCard{ID: "2", Price: 300}, Quantity: 1, TaxClass: consts.TaxClassNoVat},
+				},
+				Adjustments: []models.Adjustment{
+					{ProductId: "1", Amount: 150},
+					{Amount: 150},
+				},
+			},
+			wantTotals: []float32{933.34, 266.66},
+			wantTax:    155.56,
+			wantTaxes: []models.TaxTotal{
+				{TaxClass: consts.TaxClassVat20, Rate: 20, Amount: 155.56},
+				{TaxClass: consts.TaxClassNoVat, Rate: 0, Amount: 0},
+			},
+		},
+		{
+			name: "discount is split without losing kopecks",
+			cart: models.Cart{
+				Discount: 100,
+				Items: []models.CartItem{
+					{Product: models.ProductCard{ID: "1", Price: 100}, Quantity: 1, TaxClass: consts.TaxClassVat0},
+					{Product: models.ProductCard{ID: "2", Price: 100}, Quantity: 1, TaxClass: consts.TaxClassVat0},
+					{Product: models.ProductCard{ID: "3", Price: 100}, Quantity: 1, TaxClass: consts.TaxClassVat0},
+				},
+				Adjustments: []models.Adjustment{
+					{Amount: 100},
+				},
+			},
+			wantTotals: []float32{66.67, 66.67, 66.66},
+			wantTax:    0,
+			wantTaxes: []models.TaxTotal{
+				{TaxClass: consts.TaxClassVat0, Rate: 0, Amount: 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart := Calculate(tt.cart)
+
+			totals := make([]float32, 0, len(cart.Items))
+			for _, item := range cart.Items {
+				totals = append(totals, item.Total)
+			}
+			require.Equal(t, tt.wantTotals, totals)
+			require.Equal(t, tt.wantTax, cart.Tax)
+			require.Equal(t, tt.wantTaxes, cart.Taxes)
+		})
+	}
+}
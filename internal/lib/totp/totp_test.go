@@ -0,0 +1,118 @@
+package totp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// secret is "12345678901234567890" from RFC 6238 test vectors
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+		want string
+	}{
+		{
+			name: "first period",
+			time: time.Unix(59, 0),
+			want: "287082",
+		},
+		{
+			name: "leading zero",
+			time: time.Unix(1111111109, 0),
+			want: "081804",
+		},
+		{
+			name: "later period",
+			time: time.Unix(1234567890, 0),
+			want: "005924",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Code(secret, tt.time)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	tests := []struct {
+		name string
+		code string
+		time time.Time
+		want bool
+	}{
+		{
+			name: "current period",
+			code: "081804",
+			time: now,
+			want: true,
+		},
+		{
+			name: "previous period",
+			code: "081804",
+			time: now.Add(period),
+			want: true,
+		},
+		{
+			name: "outdated code",
+			code: "081804",
+			time: now.Add(3 * period),
+			want: false,
+		},
+		{
+			name: "wrong code",
+			code: "123456",
+			time: now,
+			want: false,
+		},
+		{
+			name: "wrong length",
+			code: "81804",
+			time: now,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Validate(secret, tt.code, tt.time))
+		})
+	}
+}
+
+func TestValidateStep(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	step, ok := ValidateStep(secret, "081804", now)
+	require.True(t, ok)
+	require.Equal(t, int64(1111111109/30), step)
+
+	next, ok := ValidateStep(secret, "081804", now.Add(period))
+	require.True(t, ok)
+	require.Equal(t, step, next)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := RecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+	require.Len(t, hashes, 10)
+
+	for i, code := range codes {
+		require.Equal(t, hashes[i], HashRecoveryCode(code))
+	}
+	require.Equal(t, HashRecoveryCode("abcd-efgh"), HashRecoveryCode("ABCDEFGH"))
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Coledzh Shop", "user@mail.com", secret)
+	require.Equal(t, "otpauth://totp/Coledzh%20Shop:user@mail.com?algorithm=SHA1&digits=6&issuer=Coledzh+Shop&period=30&secret="+secret, uri)
+}
@@ -1,38 +1,326 @@
 package models
 
+import "time"
+
 type User struct {
-	ID              string `redis:"id"`
-	Login           string
-	Email           string
-	Password        string
-	Role            string `redis:"role"`
-	IsEmailVerified bool
+	ID               string `redis:"id"`
+	Login            string
+	Email            string
+	Password         string
+	Role             string `redis:"role"`
+	IsEmailVerified  bool
+	FirstName        string
+	LastName         string
+	Phone            string
+	CreatedAt        time.Time
+	TwoFactorSecret  string
+	TwoFactorEnabled bool `redis:"two_factor_enabled"`
+}
+
+// LoginResult TwoFactorToken is returned instead of SessionId
+// when login has to be completed with two factor code,
+// RetryAfter is time left until locked login is allowed again
+type LoginResult struct {
+	SessionId      string
+	TwoFactorToken string
+	RetryAfter     time.Duration
+}
+
+// PendingLogin is login waiting for two factor code,
+// session is created from it after code is checked
+type PendingLogin struct {
+	UserId  string
+	GuestId string
+	Session Session
+}
+
+// ProfileUpdate nil fields are left unchanged
+type ProfileUpdate struct {
+	Login     *string
+	FirstName *string
+	LastName  *string
+	Phone     *string
+}
+
+// Identity is user account of external identity provider,
+// Subject is user id in provider
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// OIDCState is kept between redirect to identity provider and callback
+type OIDCState struct {
+	Provider string
+	Nonce    string
+	Verifier string
+}
+
+// Session ID is id of session in storage, services
+// show public id instead so session id is never exposed
+type Session struct {
+	ID         string
+	UserId     string
+	IP         string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	Current    bool
+}
+
+// RateLimit Reset is time until one more hit is allowed
+type RateLimit struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration
 }
 
 type Category struct {
 	ID   string `redis:"-"`
 	Name string `redis:"name"`
+	Slug string `redis:"slug"`
 }
 
 type Product struct {
-	ID          string
+	ID            string
+	Slug          string
+	Name          string
+	Description   string
+	Price         float32
+	OriginalPrice float32
+	ImageUrl      string
+	Rating        float32
+	ReviewsCount  int
+	Categories    []Category
+	Attributes    []AttributeValue
+}
+
+type Attribute struct {
+	ID         string
+	CategoryId string
+	Name       string
+	Type       string
+	Unit       string
+	IsRequired bool
+}
+
+type AttributeValue struct {
+	AttributeId string
 	Name        string
-	Description string
-	Price       float32
-	ImageUrl    string
-	Categories  []Category
+	Type        string
+	Unit        string
+	Value       string
 }
 
+// ProductCard is a short product info, OriginalPrice is compare-at
+// price, it is zero if product is not on sale
 type ProductCard struct {
-	ID       string
-	Name     string
-	Price    float32
-	ImageUrl string
+	ID            string
+	Slug          string
+	Name          string
+	Price         float32
+	OriginalPrice float32
+	ImageUrl      string
+	Rating        float32
+	ReviewsCount  int
+}
+
+// PriceChange is a product price scheduled to be set at StartsAt
+type PriceChange struct {
+	ID             string
+	ProductId      string
+	Price          float32
+	CompareAtPrice float32
+	StartsAt       time.Time
+	AppliedAt      *time.Time
+}
+
+type PriceRecord struct {
+	Price          float32
+	CompareAtPrice float32
+	CreatedAt      time.Time
+}
+
+// CartItem Total is item price after discounts
+// and Tax is VAT included into Total
+type CartItem struct {
+	Product     ProductCard
+	Quantity    int
+	Weight      int
+	CategoryIds []string
+	TaxClass    string
+	Total       float32
+	Tax         float32
 }
 
 type Cart struct {
+	ID           string
+	UserId       string
+	Subtotal     float32
+	Discount     float32
+	Price        float32
+	FreeShipping bool
+	Tax          float32
+	Items        []CartItem
+	Adjustments  []Adjustment
+	Taxes        []TaxTotal
+}
+
+type TaxTotal struct {
+	TaxClass string
+	Rate     int
+	Amount   float32
+}
+
+// Adjustment is a discount applied to cart, ProductId is empty
+// for adjustments of the whole cart. Exclusive adjustment comes from
+// non stackable promotion, no other discounts are applied after it
+type Adjustment struct {
+	Source       string
+	SourceId     string
+	Name         string
+	ProductId    string
+	Amount       float32
+	FreeShipping bool
+	Exclusive    bool
+}
+
+type Order struct {
+	ID            string
+	UserId        string
+	PaymentId     string
+	Price         float32
+	Discount      float32
+	Tax           float32
+	ShippingPrice float32
+	PromoCodeIds  []string
+	Items         []OrderItem
+	CreatedAt     time.Time
+}
+
+// OrderItem Price is list price of one unit,
+// Total is price of all units after discounts
+type OrderItem struct {
+	ProductId string
+	Name      string
+	Price     float32
+	Total     float32
+	Quantity  int
+}
+
+// AccountExport is personal data of user given out on user request
+type AccountExport struct {
+	User       User
+	Addresses  []Address
+	Cart       Cart
+	Orders     []Order
+	Reviews    []Review
+	ExportedAt time.Time
+}
+
+type ShippingMethod struct {
 	ID       string
-	UserId   string
+	Name     string
+	Type     string
+	IsActive bool
+	Rates    []ShippingRate
+}
+
+// ShippingRate is a shipping price for parcels up to MaxWeight grams,
+// empty Region matches any region, zero MaxWeight matches any weight
+type ShippingRate struct {
+	Region    string
+	MaxWeight int
+	Price     float32
+	MinDays   int
+	MaxDays   int
+}
+
+type ShippingOption struct {
+	MethodId string
+	Name     string
+	Type     string
 	Price    float32
-	Products []ProductCard
+	MinDays  int
+	MaxDays  int
+}
+
+// Payment keeps snapshot of paid cart, order is created from it
+// when payment succeeds
+type Payment struct {
+	ID               string
+	UserId           string
+	Amount           float32
+	Price            float32
+	Discount         float32
+	Tax              float32
+	ShippingMethodId string
+	ShippingPrice    float32
+	Region           string
+	Address          Address
+	PromoCodeIds     []string
+	Items            []OrderItem
+}
+
+type Address struct {
+	ID         string
+	UserId     string
+	Recipient  string
+	Phone      string
+	City       string
+	Street     string
+	PostalCode string
+	Comment    string
+	IsDefault  bool
+}
+
+type PromoCode struct {
+	ID           string
+	Code         string
+	Type         string
+	Value        float32
+	MinCartPrice float32
+	StartsAt     *time.Time
+	EndsAt       *time.Time
+	UsageLimit   int
+	PerUserLimit int
+	UsedCount    int
+	IsActive     bool
+	CategoryIds  []string
+}
+
+// Promotion is a discount rule applied to carts automatically. Rules
+// are evaluated by priority, non stackable rule stops evaluation of
+// rules with lower priority
+type Promotion struct {
+	ID            string
+	Name          string
+	Type          string
+	Priority      int
+	Stackable     bool
+	IsActive      bool
+	CategoryId    string
+	ProductId     string
+	GiftProductId string
+	BuyQuantity   int
+	PayQuantity   int
+	MinCartPrice  float32
+	Value         float32
+	StartsAt      *time.Time
+	EndsAt        *time.Time
+}
+
+type Review struct {
+	ID        string
+	ProductId string
+	UserId    string
+	UserLogin string
+	Rating    int
+	Text      string
+	Status    string
+	CreatedAt time.Time
 }
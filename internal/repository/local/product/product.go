@@ -0,0 +1,91 @@
+package product_local
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+)
+
+type Local struct {
+	path    string
+	baseUrl string
+}
+
+func New(path string, baseUrl string) (*Local, error) {
+	const op = "repository.local.product.New"
+
+	err := os.MkdirAll(path, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Local{
+		path:    path,
+		baseUrl: baseUrl,
+	}, nil
+}
+
+func (l *Local) SaveImage(ctx context.Context, id string, image []byte) (string, error) {
+	const op = "repository.local.product.SaveImage"
+
+	imagePath, err := l.imagePath(id)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = os.WriteFile(imagePath, image, 0644)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	url, err := l.GetImageUrl(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return url, nil
+}
+
+func (l *Local) GetImageUrl(ctx context.Context, imageId string) (string, error) {
+	const op = "repository.local.product.GetImageUrl"
+
+	if _, err := l.imagePath(imageId); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	url, err := url.JoinPath(l.baseUrl, imageId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return url, nil
+}
+
+func (l *Local) DeleteImage(ctx context.Context, imageId string) error {
+	const op = "repository.local.product.DeleteImage"
+
+	imagePath, err := l.imagePath(imageId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = os.Remove(imagePath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// imagePath does not allow ids to escape the storage directory
+func (l *Local) imagePath(imageId string) (string, error) {
+	if imageId == "" || imageId != filepath.Base(imageId) || imageId == "." || imageId == ".." {
+		return "", errs.ErrInvalidImageId
+	}
+
+	return filepath.Join(l.path, imageId), nil
+}
@@ -0,0 +1,101 @@
+package product_local
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/google/uuid"
+)
+
+func TestLocal_SaveImage(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		id    string
+		image []byte
+	}
+
+	dir := t.TempDir()
+	l, err := New(dir, "http://localhost:50070/images")
+	if err != nil {
+		t.Fatalf("failed to init storage: %v", err)
+	}
+
+	id := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		args    args
+		wantUrl string
+		wantErr error
+	}{
+		{
+			name: "good case",
+			args: args{
+				ctx:   context.Background(),
+				id:    id,
+				image: []byte("image"),
+			},
+			wantUrl: "http://localhost:50070/images/" + id,
+			wantErr: nil,
+		},
+		{
+			name: "path traversal case",
+			args: args{
+				ctx:   context.Background(),
+				id:    "../image",
+				image: []byte("image"),
+			},
+			wantUrl: "",
+			wantErr: errs.ErrInvalidImageId,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := l.SaveImage(tt.args.ctx, tt.args.id, tt.args.image)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Local.SaveImage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.wantUrl {
+				t.Errorf("Local.SaveImage() = %v, want %v", got, tt.wantUrl)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, tt.args.id))
+			if err != nil {
+				t.Errorf("Local.SaveImage() image is not saved: %v", err)
+				return
+			}
+			if string(data) != string(tt.args.image) {
+				t.Errorf("Local.SaveImage() saved %q, want %q", data, tt.args.image)
+			}
+		})
+	}
+}
+
+func TestLocal_DeleteImage(t *testing.T) {
+	dir := t.TempDir()
+	l, err := New(dir, "http://localhost:50070/images")
+	if err != nil {
+		t.Fatalf("failed to init storage: %v", err)
+	}
+
+	id := uuid.NewString()
+	if _, err = l.SaveImage(context.Background(), id, []byte("image")); err != nil {
+		t.Fatalf("failed to save image: %v", err)
+	}
+
+	if err = l.DeleteImage(context.Background(), id); err != nil {
+		t.Errorf("Local.DeleteImage() error = %v", err)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, id)); !os.IsNotExist(err) {
+		t.Errorf("Local.DeleteImage() image still exists")
+	}
+}
@@ -0,0 +1,254 @@
+package account_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func New(db *pgxpool.Pool) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
+
+// OrdersByUserId returns all user orders with items, newest first
+func (p *Postgres) OrdersByUserId(ctx context.Context, userId string) ([]models.Order, error) {
+	const op = "repository.postgres.account.OrdersByUserId"
+
+	query := `SELECT id, payment_id, price, discount, tax, shipping_price, created_at
+			  FROM orders
+			  WHERE user_id = $1
+			  ORDER BY created_at DESC`
+	rows, err := p.db.Query(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	orders := make([]models.Order, 0)
+	indexes := make(map[string]int)
+	for rows.Next() {
+		order := models.Order{
+			UserId: userId,
+			Items:  make([]models.OrderItem, 0),
+		}
+		err = rows.Scan(
+			&order.ID,
+			&order.PaymentId,
+			&order.Price,
+			&order.Discount,
+			&order.Tax,
+			&order.ShippingPrice,
+			&order.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		indexes[order.ID] = len(orders)
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `SELECT i.order_id, COALESCE(i.product_id::text, ''), COALESCE(p.name, ''), i.price, i.total, i.quantity
+			 FROM order_items i
+			 JOIN orders o
+			 ON i.order_id = o.id
+			 AND o.user_id = $1
+			 LEFT JOIN products p
+			 ON i.product_id = p.id`
+	rows, err = p.db.Query(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			orderId string
+			item    models.OrderItem
+		)
+		err = rows.Scan(&orderId, &item.ProductId, &item.Name, &item.Price, &item.Total, &item.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if i, ok := indexes[orderId]; ok {
+			orders[i].Items = append(orders[i].Items, item)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orders, nil
+}
+
+// ReviewsByUserId returns all user reviews whatever their status is
+func (p *Postgres) ReviewsByUserId(ctx context.Context, userId string) ([]models.Review, error) {
+	const op = "repository.postgres.account.ReviewsByUserId"
+
+	query := `SELECT id, product_id, rating, text, status, created_at
+			  FROM reviews
+			  WHERE user_id = $1
+			  ORDER BY created_at DESC`
+	rows, err := p.db.Query(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	reviews := make([]models.Review, 0)
+	for rows.Next() {
+		review := models.Review{
+			UserId: userId,
+		}
+		err = rows.Scan(
+			&review.ID,
+			&review.ProductId,
+			&review.Rating,
+			&review.Text,
+			&review.Status,
+			&review.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
+
+// RequestDeletion marks user for deletion and returns time deletion was
+// requested at, repeated request keeps the first time
+func (p *Postgres) RequestDeletion(ctx context.Context, userId string) (time.Time, error) {
+	const op = "repository.postgres.account.RequestDeletion"
+
+	query := `UPDATE users
+			  SET deletion_requested_at = COALESCE(deletion_requested_at, CURRENT_TIMESTAMP)
+			  WHERE id = $1 AND deleted_at IS NULL
+			  RETURNING deletion_requested_at`
+	var requestedAt time.Time
+	err := p.db.QueryRow(ctx, query, userId).Scan(&requestedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, fmt.Errorf("%s: %w", op, errs.ErrUserNotFound)
+		}
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return requestedAt, nil
+}
+
+func (p *Postgres) CancelDeletion(ctx context.Context, userId string) error {
+	const op = "repository.postgres.account.CancelDeletion"
+
+	query := `UPDATE users
+			  SET deletion_requested_at = NULL
+			  WHERE id = $1 AND deletion_requested_at IS NOT NULL AND deleted_at IS NULL`
+	tag, err := p.db.Exec(ctx, query, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrDeletionNotFound)
+	}
+
+	return nil
+}
+
+// AnonymiseUsers removes personal data of users which requested deletion more
+// than gracePeriod ago. Orders and payments are kept for accounting without
+// personal data, returns ids of anonymised users
+func (p *Postgres) AnonymiseUsers(ctx context.Context, gracePeriod time.Duration) (ids []string, err error) {
+	const op = "repository.postgres.account.AnonymiseUsers"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			ids, err = nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}()
+
+	query := `SELECT id
+			  FROM users
+			  WHERE deletion_requested_at <= CURRENT_TIMESTAMP - $1 * INTERVAL '1 second'
+			  AND deleted_at IS NULL
+			  FOR UPDATE SKIP LOCKED`
+	rows, err := tx.Query(ctx, query, int64(gracePeriod.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ids = make([]string, 0)
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	queries := []string{
+		`UPDATE users
+		 SET login = 'deleted', email = NULL, password = NULL, first_name = NULL,
+		 last_name = NULL, phone = NULL, pending_email = NULL,
+		 two_factor_secret = NULL, two_factor_enabled = false,
+		 is_email_verified = false, deleted_at = CURRENT_TIMESTAMP
+		 WHERE id = ANY($1)`,
+		`UPDATE payments
+		 SET recipient = '', phone = '', street = '', postal_code = '', comment = ''
+		 WHERE user_id = ANY($1)`,
+		"DELETE FROM addresses WHERE user_id = ANY($1)",
+		"DELETE FROM cart_items WHERE user_id = ANY($1)",
+		"DELETE FROM cart_promo_codes WHERE user_id = ANY($1)",
+		"DELETE FROM wishlist_items WHERE user_id = ANY($1)",
+		"DELETE FROM tokens WHERE user_id = ANY($1)",
+		"DELETE FROM recovery_codes WHERE user_id = ANY($1)",
+		"DELETE FROM user_identities WHERE user_id = ANY($1)",
+	}
+	for _, query := range queries {
+		_, err = tx.Exec(ctx, query, ids)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return ids, nil
+}
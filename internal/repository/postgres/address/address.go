@@ -0,0 +1,237 @@
+package address_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func New(db *pgxpool.Pool) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
+
+// SaveAddress saves address, the first user address always becomes default
+func (p *Postgres) SaveAddress(ctx context.Context, address models.Address) (string, error) {
+	const op = "repository.postgres.address.SaveAddress"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	if address.IsDefault {
+		err = resetDefault(ctx, tx, address.UserId)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	query := `INSERT INTO addresses
+			  (user_id, recipient, phone, city, street, postal_code, comment, is_default)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7,
+			  $8 OR NOT EXISTS (SELECT 1 FROM addresses WHERE user_id = $1))
+			  RETURNING id`
+	var id string
+	err = tx.QueryRow(
+		ctx,
+		query,
+		address.UserId,
+		address.Recipient,
+		address.Phone,
+		address.City,
+		address.Street,
+		address.PostalCode,
+		address.Comment,
+		address.IsDefault,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// UpdateAddress updates user address, default address can not be unset
+// directly, another address should be made default instead
+func (p *Postgres) UpdateAddress(ctx context.Context, address models.Address) error {
+	const op = "repository.postgres.address.UpdateAddress"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	if address.IsDefault {
+		err = resetDefault(ctx, tx, address.UserId)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	query := `UPDATE addresses
+			  SET recipient = $3, phone = $4, city = $5, street = $6,
+			  postal_code = $7, comment = $8, is_default = is_default OR $9
+			  WHERE id = $1 AND user_id = $2`
+	tag, err := tx.Exec(
+		ctx,
+		query,
+		address.ID,
+		address.UserId,
+		address.Recipient,
+		address.Phone,
+		address.City,
+		address.Street,
+		address.PostalCode,
+		address.Comment,
+		address.IsDefault,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = errs.ErrAddressNotFound
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteAddress deletes user address, if it was default the newest
+// of remaining addresses becomes default
+func (p *Postgres) DeleteAddress(ctx context.Context, userId, id string) error {
+	const op = "repository.postgres.address.DeleteAddress"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	query := `DELETE FROM addresses
+			  WHERE id = $1 AND user_id = $2
+			  RETURNING is_default`
+	var isDefault bool
+	err = tx.QueryRow(ctx, query, id, userId).Scan(&isDefault)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = errs.ErrAddressNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if isDefault {
+		query = `UPDATE addresses SET is_default = true
+				 WHERE id = (
+					SELECT id FROM addresses
+					WHERE user_id = $1
+					ORDER BY created_at DESC
+					LIMIT 1
+				 )`
+		_, err = tx.Exec(ctx, query, userId)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// AddressesByUserId returns user addresses, default address goes first
+func (p *Postgres) AddressesByUserId(ctx context.Context, userId string) ([]models.Address, error) {
+	const op = "repository.postgres.address.AddressesByUserId"
+
+	query := `SELECT id, user_id, recipient, phone, city, street, postal_code, comment, is_default
+			  FROM addresses
+			  WHERE user_id = $1
+			  ORDER BY is_default DESC, created_at DESC`
+	rows, err := p.db.Query(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	addresses := make([]models.Address, 0)
+	for rows.Next() {
+		address, err := scanAddress(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		addresses = append(addresses, address)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return addresses, nil
+}
+
+func (p *Postgres) AddressById(ctx context.Context, userId, id string) (models.Address, error) {
+	const op = "repository.postgres.address.AddressById"
+
+	query := `SELECT id, user_id, recipient, phone, city, street, postal_code, comment, is_default
+			  FROM addresses
+			  WHERE id = $1 AND user_id = $2`
+	address, err := scanAddress(p.db.QueryRow(ctx, query, id, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Address{}, fmt.Errorf("%s: %w", op, errs.ErrAddressNotFound)
+		}
+		return models.Address{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return address, nil
+}
+
+func resetDefault(ctx context.Context, tx pgx.Tx, userId string) error {
+	_, err := tx.Exec(ctx, "UPDATE addresses SET is_default = false WHERE user_id = $1 AND is_default", userId)
+	return err
+}
+
+func scanAddress(row pgx.Row) (models.Address, error) {
+	var address models.Address
+	err := row.Scan(
+		&address.ID,
+		&address.UserId,
+		&address.Recipient,
+		&address.Phone,
+		&address.City,
+		&address.Street,
+		&address.PostalCode,
+		&address.Comment,
+		&address.IsDefault,
+	)
+	return address, err
+}
@@ -0,0 +1,163 @@
+package attribute_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func New(db *pgxpool.Pool) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
+
+func (p *Postgres) SaveAttribute(ctx context.Context, attribute models.Attribute) (string, error) {
+	const op = "repository.postgres.attribute.SaveAttribute"
+
+	query := `INSERT INTO attributes
+			  (category_id, name, type, unit, is_required)
+			  VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+			  RETURNING id`
+	var id string
+	err := p.db.QueryRow(
+		ctx,
+		query,
+		attribute.CategoryId,
+		attribute.Name,
+		attribute.Type,
+		attribute.Unit,
+		attribute.IsRequired,
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return "", fmt.Errorf("%s: %w", op, errs.ErrAttributeExists)
+			case "23503":
+				return "", fmt.Errorf("%s: %w", op, errs.ErrCategoryNotFound)
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (p *Postgres) UpdateAttribute(ctx context.Context, attribute models.Attribute) error {
+	const op = "repository.postgres.attribute.UpdateAttribute"
+
+	query := `UPDATE attributes
+			  SET name = $1, type = $2, unit = NULLIF($3, ''), is_required = $4, updated_at = CURRENT_TIMESTAMP
+			  WHERE id = $5`
+	tag, err := p.db.Exec(
+		ctx,
+		query,
+		attribute.Name,
+		attribute.Type,
+		attribute.Unit,
+		attribute.IsRequired,
+		attribute.ID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return fmt.Errorf("%s: %w", op, errs.ErrAttributeExists)
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrAttributeNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) DeleteAttribute(ctx context.Context, id string) error {
+	const op = "repository.postgres.attribute.DeleteAttribute"
+
+	query := "DELETE FROM attributes WHERE id = $1"
+	tag, err := p.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrAttributeNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) AttributesByCategoryIds(ctx context.Context, categoryIds []string) ([]models.Attribute, error) {
+	const op = "repository.postgres.attribute.AttributesByCategoryIds"
+
+	query := `SELECT id, category_id, name, type, COALESCE(unit, ''), is_required
+			  FROM attributes
+			  WHERE category_id = ANY($1)
+			  ORDER BY name`
+	attributes, err := p.attributes(ctx, query, categoryIds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return attributes, nil
+}
+
+func (p *Postgres) AttributesByIds(ctx context.Context, ids []string) ([]models.Attribute, error) {
+	const op = "repository.postgres.attribute.AttributesByIds"
+
+	query := `SELECT id, category_id, name, type, COALESCE(unit, ''), is_required
+			  FROM attributes
+			  WHERE id = ANY($1)`
+	attributes, err := p.attributes(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return attributes, nil
+}
+
+func (p *Postgres) attributes(ctx context.Context, query string, args ...any) ([]models.Attribute, error) {
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attributes := make([]models.Attribute, 0)
+	for rows.Next() {
+		var attribute models.Attribute
+		err = rows.Scan(
+			&attribute.ID,
+			&attribute.CategoryId,
+			&attribute.Name,
+			&attribute.Type,
+			&attribute.Unit,
+			&attribute.IsRequired,
+		)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
@@ -2,12 +2,28 @@ package cart_repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// taxClass selects effective product tax class: own class of product or
+// class of its categories with the highest rate, classes are ordered by rate
+const taxClass = `COALESCE(
+				p.tax_class,
+				(SELECT MIN(cat.tax_class) FROM categories cat
+				JOIN products_categories pc ON pc.category_id = cat.id
+				WHERE pc.product_id = p.id),
+				'vat20'
+			  )::text`
+
 type Postgres struct {
 	db *pgxpool.Pool
 }
@@ -18,30 +34,65 @@ func New(db *pgxpool.Pool) *Postgres {
 	}
 }
 
-func (p *Postgres) AddProduct(ctx context.Context, userId, productId string) (string, error) {
-	const op = "repository.postgres.cart.CartByUserId"
+// AddProduct adds product to cart or increases its quantity, quantity is capped by maxQuantity
+func (p *Postgres) AddProduct(ctx context.Context, userId, productId string, maxQuantity int) (string, error) {
+	const op = "repository.postgres.cart.AddProduct"
 
+	query := `INSERT INTO cart_items (user_id, product_id)
+			  VALUES ($1, $2)
+			  ON CONFLICT (user_id, product_id)
+			  DO UPDATE SET quantity = LEAST(cart_items.quantity + 1, $3)
+			  RETURNING id`
 	var cartId string
-	query := "INSERT INTO cart_items (user_id, product_id) VALUES ($1, $2) RETURNING id"
-	err := p.db.QueryRow(ctx, query, userId, productId).Scan(&cartId)
+	err := p.db.QueryRow(ctx, query, userId, productId, maxQuantity).Scan(&cartId)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23503" {
+				return "", fmt.Errorf("%s: %w", op, errs.ErrProductNotFound)
+			}
+		}
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
 	return cartId, nil
 }
 
+// UpdateQuantity sets product quantity in cart, zero quantity removes product
+func (p *Postgres) UpdateQuantity(ctx context.Context, userId, productId string, quantity int) error {
+	const op = "repository.postgres.cart.UpdateQuantity"
+
+	query := "UPDATE cart_items SET quantity = $1 WHERE user_id = $2 AND product_id = $3"
+	args := []any{quantity, userId, productId}
+	if quantity == 0 {
+		query = "DELETE FROM cart_items WHERE user_id = $1 AND product_id = $2"
+		args = args[1:]
+	}
+
+	tag, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrProductNotInCart)
+	}
+
+	return nil
+}
+
 func (p *Postgres) CartByUserId(ctx context.Context, userId string) (models.Cart, error) {
 	const op = "repository.postgres.cart.CartByUserId"
 
 	var cart models.Cart
-	cart.Products = make([]models.ProductCard, 0)
-	query := `SELECT c.id, p.id, p.name, p.price, p.image_url
+	cart.Items = make([]models.CartItem, 0)
+	query := `SELECT c.id, p.id, p.slug, p.name, p.price, COALESCE(p.compare_at_price, 0), p.image_url, c.quantity, p.weight,
+			  ARRAY(SELECT pc.category_id::text FROM products_categories pc WHERE pc.product_id = p.id),
+			  ` + taxClass + `
 			  FROM cart_items c
 			  JOIN products p
 			  ON c.product_id = p.id
 			  AND c.user_id = $1
-			  GROUP BY c.id, p.id`
+			  ORDER BY c.created_at`
 	rows, err := p.db.Query(ctx, query, userId)
 	if err != nil {
 		return models.Cart{}, fmt.Errorf("%s: %w", op, err)
@@ -49,18 +100,24 @@ func (p *Postgres) CartByUserId(ctx context.Context, userId string) (models.Cart
 	defer rows.Close()
 
 	for rows.Next() {
-		var product models.ProductCard
+		var item models.CartItem
 		err = rows.Scan(
 			&cart.ID,
-			&product.ID,
-			&product.Name,
-			&product.Price,
-			&product.ImageUrl,
+			&item.Product.ID,
+			&item.Product.Slug,
+			&item.Product.Name,
+			&item.Product.Price,
+			&item.Product.OriginalPrice,
+			&item.Product.ImageUrl,
+			&item.Quantity,
+			&item.Weight,
+			&item.CategoryIds,
+			&item.TaxClass,
 		)
 		if err != nil {
 			return models.Cart{}, fmt.Errorf("%s: %w", op, err)
 		}
-		cart.Products = append(cart.Products, product)
+		cart.Items = append(cart.Items, item)
 	}
 
 	if rows.Err() != nil {
@@ -72,6 +129,85 @@ func (p *Postgres) CartByUserId(ctx context.Context, userId string) (models.Cart
 	return cart, nil
 }
 
+func (p *Postgres) ItemsByProductIds(ctx context.Context, productIds []string) ([]models.CartItem, error) {
+	const op = "repository.postgres.cart.ItemsByProductIds"
+
+	query := `SELECT p.id, p.slug, p.name, p.price, COALESCE(p.compare_at_price, 0), p.image_url, ` + taxClass + `
+			  FROM products p
+			  WHERE p.id::text = ANY($1)`
+	rows, err := p.db.Query(ctx, query, productIds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	items := make([]models.CartItem, 0, len(productIds))
+	for rows.Next() {
+		var item models.CartItem
+		err = rows.Scan(
+			&item.Product.ID,
+			&item.Product.Slug,
+			&item.Product.Name,
+			&item.Product.Price,
+			&item.Product.OriginalPrice,
+			&item.Product.ImageUrl,
+			&item.TaxClass,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return items, nil
+}
+
+// MergeItems puts guest cart items into user cart. Quantities of products
+// which are in both carts are combined by strategy and capped by maxQuantity,
+// deleted products are skipped
+func (p *Postgres) MergeItems(
+	ctx context.Context,
+	userId string,
+	items map[string]int,
+	strategy string,
+	maxQuantity int,
+) error {
+	const op = "repository.postgres.cart.MergeItems"
+
+	var conflict string
+	switch strategy {
+	case consts.CartMergeMax:
+		conflict = "GREATEST(cart_items.quantity, EXCLUDED.quantity)"
+	case consts.CartMergeSum:
+		conflict = "cart_items.quantity + EXCLUDED.quantity"
+	default:
+		return fmt.Errorf("%s: %w", op, errs.ErrUnknownMergeStrategy)
+	}
+
+	query := fmt.Sprintf(`INSERT INTO cart_items (user_id, product_id, quantity)
+			  SELECT $1, id, LEAST($3::integer, $4::integer)
+			  FROM products
+			  WHERE id::text = $2
+			  ON CONFLICT (user_id, product_id)
+			  DO UPDATE SET quantity = LEAST(%s, $4::integer)`, conflict)
+
+	batch := &pgx.Batch{}
+	for productId, quantity := range items {
+		batch.Queue(query, userId, productId, quantity, maxQuantity)
+	}
+
+	err := p.db.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (p *Postgres) DeleteCartByUserId(ctx context.Context, userId string) error {
 	const op = "repository.postgres.cart.DeleteCartByUserId"
 
@@ -83,3 +219,229 @@ func (p *Postgres) DeleteCartByUserId(ctx context.Context, userId string) error
 
 	return nil
 }
+
+// SavePayment saves created payment with its shipping, snapshot of delivery
+// address and snapshot of paid cart, it is used when order is completed.
+// Applied promo codes are reserved for the payment, promo code which reached
+// its usage or per user limit gives ErrPromoCodeUsedUp and nothing is saved
+func (p *Postgres) SavePayment(ctx context.Context, payment models.Payment) (err error) {
+	const op = "repository.postgres.cart.SavePayment"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	query := `INSERT INTO payments
+			  (id, user_id, amount, price, discount, tax, promo_code_ids,
+			  shipping_method_id, shipping_price, region,
+			  address_id, recipient, phone, street, postal_code, comment)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+			  NULLIF($11, '')::uuid, $12, $13, $14, $15, $16)`
+	_, err = tx.Exec(
+		ctx,
+		query,
+		payment.ID,
+		payment.UserId,
+		payment.Amount,
+		payment.Price,
+		payment.Discount,
+		payment.Tax,
+		payment.PromoCodeIds,
+		payment.ShippingMethodId,
+		payment.ShippingPrice,
+		payment.Region,
+		payment.Address.ID,
+		payment.Address.Recipient,
+		payment.Address.Phone,
+		payment.Address.Street,
+		payment.Address.PostalCode,
+		payment.Address.Comment,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, item := range payment.Items {
+		query = `INSERT INTO payment_items (payment_id, product_id, price, total, quantity)
+				 VALUES ($1, $2, $3, $4, $5)`
+		batch.Queue(query, payment.ID, item.ProductId, item.Price, item.Total, item.Quantity)
+	}
+	err = tx.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, promoCodeId := range payment.PromoCodeIds {
+		err = reservePromoCode(ctx, tx, promoCodeId, payment.UserId, payment.ID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// ReleasePromoCodes frees promo codes reserved for payment which did not
+// turn into order, so they can be used again
+func (p *Postgres) ReleasePromoCodes(ctx context.Context, paymentId string) error {
+	const op = "repository.postgres.cart.ReleasePromoCodes"
+
+	query := `WITH released AS (
+				  DELETE FROM promo_code_usages
+				  WHERE payment_id = $1 AND order_id IS NULL
+				  RETURNING promo_code_id
+			  )
+			  UPDATE promo_codes SET used_count = GREATEST(used_count - 1, 0)
+			  WHERE id IN (SELECT promo_code_id FROM released)`
+	_, err := p.db.Exec(ctx, query, paymentId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CreateOrder creates order from cart snapshot saved with payment of user,
+// binds promo codes reserved for payment to order and removes paid quantity
+// of items from user cart. Repeated call with the same paymentId does nothing
+func (p *Postgres) CreateOrder(ctx context.Context, userId, paymentId string) (string, error) {
+	const op = "repository.postgres.cart.CreateOrder"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	query := `SELECT EXISTS(SELECT 1 FROM payments WHERE id = $1 AND user_id = $2)`
+	var exists bool
+	err = tx.QueryRow(ctx, query, paymentId, userId).Scan(&exists)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		err = errs.ErrPaymentNotFound
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `INSERT INTO orders (user_id, payment_id, price, discount, tax, shipping_price)
+			 SELECT user_id, id, price, discount, tax, shipping_price
+			 FROM payments
+			 WHERE id = $1
+			 ON CONFLICT (payment_id) DO NOTHING
+			 RETURNING id`
+	var orderId string
+	err = tx.QueryRow(ctx, query, paymentId).Scan(&orderId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+			return "", nil
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `INSERT INTO order_items (order_id, product_id, price, total, quantity)
+			 SELECT $1, product_id, price, total, quantity
+			 FROM payment_items
+			 WHERE payment_id = $2`
+	tag, err := tx.Exec(ctx, query, orderId, paymentId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = errs.ErrCartIsEmpty
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = "UPDATE promo_code_usages SET order_id = $1 WHERE payment_id = $2"
+	_, err = tx.Exec(ctx, query, orderId, paymentId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `DELETE FROM cart_items c
+			 USING payment_items p
+			 WHERE p.payment_id = $2
+			 AND c.user_id = $1
+			 AND c.product_id = p.product_id
+			 AND c.quantity <= p.quantity`
+	_, err = tx.Exec(ctx, query, userId, paymentId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `UPDATE cart_items c
+			 SET quantity = c.quantity - p.quantity
+			 FROM payment_items p
+			 WHERE p.payment_id = $2
+			 AND c.user_id = $1
+			 AND c.product_id = p.product_id`
+	_, err = tx.Exec(ctx, query, userId, paymentId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM cart_promo_codes WHERE user_id = $1", userId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orderId, nil
+}
+
+// reservePromoCode records usage of promo code by payment, promo code row is
+// locked so concurrent checkouts can not exceed usage and per user limits
+func reservePromoCode(ctx context.Context, tx pgx.Tx, promoCodeId, userId, paymentId string) error {
+	query := "SELECT per_user_limit FROM promo_codes WHERE id = $1 FOR UPDATE"
+	var perUserLimit int
+	err := tx.QueryRow(ctx, query, promoCodeId).Scan(&perUserLimit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrPromoCodeNotFound
+		}
+		return err
+	}
+
+	if perUserLimit > 0 {
+		query = "SELECT COUNT(*) FROM promo_code_usages WHERE promo_code_id = $1 AND user_id = $2"
+		var used int
+		err = tx.QueryRow(ctx, query, promoCodeId, userId).Scan(&used)
+		if err != nil {
+			return err
+		}
+		if used >= perUserLimit {
+			return errs.ErrPromoCodeUsedUp
+		}
+	}
+
+	query = `UPDATE promo_codes SET used_count = used_count + 1
+			 WHERE id = $1 AND (usage_limit = 0 OR used_count < usage_limit)`
+	tag, err := tx.Exec(ctx, query, promoCodeId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrPromoCodeUsedUp
+	}
+
+	query = `INSERT INTO promo_code_usages (promo_code_id, user_id, payment_id)
+			 VALUES ($1, $2, $3)`
+	_, err = tx.Exec(ctx, query, promoCodeId, userId, paymentId)
+
+	return err
+}
@@ -0,0 +1,126 @@
+package cart_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestPostgres_SavePayment_PromoCodeLimit(t *testing.T) {
+	pool := initStorage()
+	defer pool.Close()
+
+	ctx := context.Background()
+	p := New(pool)
+
+	userIds := []string{uuid.NewString(), uuid.NewString()}
+	for i, userId := range userIds {
+		_, _ = pool.Exec(ctx, "INSERT INTO users (id, email) VALUES ($1, $2)", userId, fmt.Sprintf("sas-test23%d@gmail.com", i))
+	}
+
+	var promoCodeId string
+	_ = pool.QueryRow(
+		ctx,
+		"INSERT INTO promo_codes (code, type, value, usage_limit) VALUES ($1, 'fixed', 10, 1) RETURNING id",
+		"LIMIT"+uuid.NewString()[:8],
+	).Scan(&promoCodeId)
+
+	payment := func(userId string) models.Payment {
+		return models.Payment{
+			ID:           uuid.NewString(),
+			UserId:       userId,
+			Amount:       100,
+			PromoCodeIds: []string{promoCodeId},
+		}
+	}
+
+	first := payment(userIds[0])
+	if err := p.SavePayment(ctx, first); err != nil {
+		t.Fatalf("Postgres.SavePayment() error = %v", err)
+	}
+
+	second := payment(userIds[1])
+	if err := p.SavePayment(ctx, second); !errors.Is(err, errs.ErrPromoCodeUsedUp) {
+		t.Errorf("Postgres.SavePayment() error = %v, wantErr %v", err, errs.ErrPromoCodeUsedUp)
+	}
+
+	if err := p.ReleasePromoCodes(ctx, first.ID); err != nil {
+		t.Fatalf("Postgres.ReleasePromoCodes() error = %v", err)
+	}
+	if err := p.SavePayment(ctx, second); err != nil {
+		t.Errorf("Postgres.SavePayment() error = %v, wantErr %v", err, nil)
+	}
+
+	_, _ = pool.Exec(ctx, "DELETE FROM promo_codes WHERE id = $1", promoCodeId)
+	_, _ = pool.Exec(ctx, "DELETE FROM payments WHERE id = ANY($1)", []string{first.ID, second.ID})
+	_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = ANY($1)", userIds)
+}
+
+func TestPostgres_CreateOrder_KeepsUnpaidQuantity(t *testing.T) {
+	pool := initStorage()
+	defer pool.Close()
+
+	ctx := context.Background()
+	p := New(pool)
+
+	userId := uuid.NewString()
+	productId := uuid.NewString()
+	_, _ = pool.Exec(ctx, "INSERT INTO users (id, email) VALUES ($1, $2)", userId, "sas-test234@gmail.com")
+	_, _ = pool.Exec(
+		ctx,
+		"INSERT INTO products (id, name, description, price, slug) VALUES ($1, 'name', 'description', 10, $1)",
+		productId,
+	)
+	_, _ = pool.Exec(ctx, "INSERT INTO cart_items (user_id, product_id, quantity) VALUES ($1, $2, 3)", userId, productId)
+
+	payment := models.Payment{
+		ID:     uuid.NewString(),
+		UserId: userId,
+		Amount: 20,
+		Items:  []models.OrderItem{{ProductId: productId, Price: 10, Total: 20, Quantity: 2}},
+	}
+	if err := p.SavePayment(ctx, payment); err != nil {
+		t.Fatalf("Postgres.SavePayment() error = %v", err)
+	}
+
+	if _, err := p.CreateOrder(ctx, userId, payment.ID); err != nil {
+		t.Fatalf("Postgres.CreateOrder() error = %v", err)
+	}
+
+	var quantity int
+	_ = pool.QueryRow(ctx, "SELECT quantity FROM cart_items WHERE user_id = $1", userId).Scan(&quantity)
+	if quantity != 1 {
+		t.Errorf("Postgres.CreateOrder() cart quantity = %v, want %v", quantity, 1)
+	}
+
+	_, _ = pool.Exec(ctx, "DELETE FROM order_items WHERE product_id = $1", productId)
+	_, _ = pool.Exec(ctx, "DELETE FROM orders WHERE payment_id = $1", payment.ID)
+	_, _ = pool.Exec(ctx, "DELETE FROM payments WHERE id = $1", payment.ID)
+	_, _ = pool.Exec(ctx, "DELETE FROM cart_items WHERE user_id = $1", userId)
+	_, _ = pool.Exec(ctx, "DELETE FROM products WHERE id = $1", productId)
+	_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = $1", userId)
+}
+
+func initStorage() *pgxpool.Pool {
+	connString := fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=disable&pool_max_conns=%s&pool_min_conns=%s",
+		os.Getenv("DB_USER"),
+		os.Getenv("DB_PASSWORD"),
+		os.Getenv("DB_HOST"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_NAME"),
+		os.Getenv("DB_MIN_POOLS"),
+		os.Getenv("DB_MAX_POOLS"),
+	)
+
+	pool, _ := pgxpool.New(context.Background(), connString)
+
+	return pool
+}
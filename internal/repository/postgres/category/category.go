@@ -2,11 +2,14 @@ package category_repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -21,15 +24,18 @@ func New(db *pgxpool.Pool) *Postgres {
 	}
 }
 
-func (p *Postgres) SaveCategory(ctx context.Context, id string, name string) error {
+func (p *Postgres) SaveCategory(ctx context.Context, id string, name string, slug string) error {
 	const op = "repository.postgres.category.SaveCategory"
 
-	query := "INSERT INTO categories (id, name) VALUES ($1, $2)"
-	_, err := p.db.Exec(ctx, query, id, name)
+	query := "INSERT INTO categories (id, name, slug) VALUES ($1, $2, $3)"
+	_, err := p.db.Exec(ctx, query, id, name, slug)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" {
+				if pgErr.ConstraintName == "categories_slug_key" {
+					return fmt.Errorf("%s: %w", op, errs.ErrSlugAlreadyExists)
+				}
 				return fmt.Errorf("%s: %w", op, errs.ErrCategoryAlreadyExists)
 			}
 		}
@@ -42,7 +48,7 @@ func (p *Postgres) SaveCategory(ctx context.Context, id string, name string) err
 func (p *Postgres) AllCategories(ctx context.Context) ([]models.Category, error) {
 	const op = "repository.postgres.category.AllCategories"
 
-	query := "SELECT id, name FROM categories"
+	query := "SELECT id, name, slug FROM categories"
 	rows, err := p.db.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -52,7 +58,7 @@ func (p *Postgres) AllCategories(ctx context.Context) ([]models.Category, error)
 	categories := make([]models.Category, 0)
 	for rows.Next() {
 		var category models.Category
-		err := rows.Scan(&category.ID, &category.Name)
+		err := rows.Scan(&category.ID, &category.Name, &category.Slug)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -65,3 +71,163 @@ func (p *Postgres) AllCategories(ctx context.Context) ([]models.Category, error)
 
 	return categories, nil
 }
+
+// CategoriesWithIdSlug returns categories which slug is still their id
+func (p *Postgres) CategoriesWithIdSlug(ctx context.Context) ([]models.Category, error) {
+	const op = "repository.postgres.category.CategoriesWithIdSlug"
+
+	query := "SELECT id, name, slug FROM categories WHERE slug = id::text"
+	rows, err := p.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	categories := make([]models.Category, 0)
+	for rows.Next() {
+		var category models.Category
+		err := rows.Scan(&category.ID, &category.Name, &category.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return categories, nil
+}
+
+func (p *Postgres) CategoryByIdOrSlug(ctx context.Context, idOrSlug string) (models.Category, error) {
+	const op = "repository.postgres.category.CategoryByIdOrSlug"
+
+	query := "SELECT id, name, slug FROM categories WHERE id::text = $1 OR slug = $1"
+	var category models.Category
+	err := p.db.QueryRow(ctx, query, idOrSlug).Scan(&category.ID, &category.Name, &category.Slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Category{}, fmt.Errorf("%s: %w", op, errs.ErrCategoryNotFound)
+		}
+		return models.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return category, nil
+}
+
+func (p *Postgres) SlugExists(ctx context.Context, slug string) (bool, error) {
+	const op = "repository.postgres.category.SlugExists"
+
+	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE slug = $1)
+			  OR EXISTS(SELECT 1 FROM slug_redirects WHERE entity_type = $2 AND old_slug = $1)`
+	var exists bool
+	err := p.db.QueryRow(ctx, query, slug, consts.SlugEntityCategory).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exists, nil
+}
+
+// SlugByOldSlug returns current category slug for slug the category had before
+func (p *Postgres) SlugByOldSlug(ctx context.Context, oldSlug string) (string, error) {
+	const op = "repository.postgres.category.SlugByOldSlug"
+
+	query := `SELECT c.slug
+			  FROM slug_redirects r
+			  JOIN categories c
+			  ON r.entity_id = c.id
+			  AND r.entity_type = $1
+			  AND r.old_slug = $2`
+	var slug string
+	err := p.db.QueryRow(ctx, query, consts.SlugEntityCategory, oldSlug).Scan(&slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, errs.ErrCategoryNotFound)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return slug, nil
+}
+
+// UpdateSlug changes category slug and records redirect from the old one
+func (p *Postgres) UpdateSlug(ctx context.Context, id string, slug string) (models.Category, error) {
+	const op = "repository.postgres.category.UpdateSlug"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return models.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	var category models.Category
+	err = tx.QueryRow(ctx, "SELECT id, name, slug FROM categories WHERE id = $1 FOR UPDATE", id).
+		Scan(&category.ID, &category.Name, &category.Slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Category{}, fmt.Errorf("%s: %w", op, errs.ErrCategoryNotFound)
+		}
+		return models.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if category.Slug == slug {
+		return category, nil
+	}
+	oldSlug := category.Slug
+
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM slug_redirects WHERE entity_type = $1 AND old_slug = $2 AND entity_id = $3",
+		consts.SlugEntityCategory,
+		slug,
+		id,
+	)
+	if err != nil {
+		return models.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE categories SET slug = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", slug, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return models.Category{}, fmt.Errorf("%s: %w", op, errs.ErrSlugAlreadyExists)
+			}
+		}
+		return models.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `INSERT INTO slug_redirects
+			  (entity_type, old_slug, entity_id)
+			  VALUES ($1, $2, $3)
+			  ON CONFLICT (entity_type, old_slug) DO UPDATE SET entity_id = EXCLUDED.entity_id`
+	_, err = tx.Exec(ctx, query, consts.SlugEntityCategory, oldSlug, id)
+	if err != nil {
+		return models.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	category.Slug = slug
+
+	return category, nil
+}
+
+func (p *Postgres) UpdateTaxClass(ctx context.Context, id string, taxClass string) error {
+	const op = "repository.postgres.category.UpdateTaxClass"
+
+	tag, err := p.db.Exec(ctx, "UPDATE categories SET tax_class = $2 WHERE id = $1", id, taxClass)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrCategoryNotFound)
+	}
+
+	return nil
+}
@@ -23,6 +23,7 @@ func TestPostgres_SaveCategory(t *testing.T) {
 		ctx  context.Context
 		id   string
 		name string
+		slug string
 	}
 
 	pool := initStorage()
@@ -31,7 +32,7 @@ func TestPostgres_SaveCategory(t *testing.T) {
 	id := uuid.NewString()
 	name := "blkrgberkle"
 
-	pool.Exec(context.Background(), "INSERT INTO categories (id, name) VALUES ($1, $2)", id, name)
+	pool.Exec(context.Background(), "INSERT INTO categories (id, name, slug) VALUES ($1, $2, $3)", id, name, name)
 
 	tests := []struct {
 		name    string
@@ -48,6 +49,7 @@ func TestPostgres_SaveCategory(t *testing.T) {
 				ctx:  context.Background(),
 				id:   uuid.NewString(),
 				name: "klvdfsvlk",
+				slug: "klvdfsvlk",
 			},
 			wantErr: nil,
 		},
@@ -60,16 +62,30 @@ func TestPostgres_SaveCategory(t *testing.T) {
 				ctx:  context.Background(),
 				id:   id,
 				name: name,
+				slug: "vdfvdfsbsdf",
 			},
 			wantErr: errs.ErrCategoryAlreadyExists,
 		},
+		{
+			name: "slug already exists case",
+			fields: fields{
+				db: pool,
+			},
+			args: args{
+				ctx:  context.Background(),
+				id:   uuid.NewString(),
+				name: "vfdvsfdvsd",
+				slug: name,
+			},
+			wantErr: errs.ErrSlugAlreadyExists,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &Postgres{
 				db: tt.fields.db,
 			}
-			if err := p.SaveCategory(tt.args.ctx, tt.args.id, tt.args.name); err != nil {
+			if err := p.SaveCategory(tt.args.ctx, tt.args.id, tt.args.name, tt.args.slug); err != nil {
 				if !errors.Is(err, tt.wantErr) {
 					t.Errorf("Postgres.SaveCategory() error = %v, wantErr %v", err, tt.wantErr)
 				}
@@ -100,9 +116,10 @@ func TestPostgres_AllCategories(t *testing.T) {
 		}
 		_, err := pool.Exec(
 			context.Background(),
-			"INSERT INTO categories (id, name) VALUES ($1, $2)",
+			"INSERT INTO categories (id, name, slug) VALUES ($1, $2, $3)",
 			categories[i].ID,
 			categories[i].Name,
+			categories[i].ID,
 		)
 		require.NoError(t, err, "failed to save")
 	}
@@ -0,0 +1,116 @@
+package identity_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func New(db *pgxpool.Pool) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
+
+func (p *Postgres) UserIdByIdentity(ctx context.Context, provider, subject string) (string, error) {
+	const op = "repository.postgres.identity.UserIdByIdentity"
+
+	query := `SELECT user_id
+			  FROM user_identities
+			  WHERE provider = $1 AND subject = $2`
+	var userId string
+	err := p.db.QueryRow(ctx, query, provider, subject).Scan(&userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, errs.ErrIdentityNotFound)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userId, nil
+}
+
+// SaveIdentity links identity to existing user
+func (p *Postgres) SaveIdentity(ctx context.Context, userId string, identity models.Identity) error {
+	const op = "repository.postgres.identity.SaveIdentity"
+
+	err := saveIdentity(ctx, p.db, userId, identity)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SaveUserWithIdentity creates user without password with verified email
+// of identity and links identity to it
+func (p *Postgres) SaveUserWithIdentity(ctx context.Context, login string, identity models.Identity) (id string, err error) {
+	const op = "repository.postgres.identity.SaveUserWithIdentity"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	query := `INSERT INTO users
+			  (login, email, is_email_verified)
+			  VALUES ($1, $2, $3)
+			  RETURNING id`
+	err = tx.QueryRow(ctx, query, login, identity.Email, true).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return "", fmt.Errorf("%s: %w", op, errs.ErrUserAlreadyExists)
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = saveIdentity(ctx, tx, id, identity)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+func saveIdentity(ctx context.Context, db execer, userId string, identity models.Identity) error {
+	query := `INSERT INTO user_identities
+			  (user_id, provider, subject, email)
+			  VALUES ($1, $2, $3, $4)`
+	_, err := db.Exec(ctx, query, userId, identity.Provider, identity.Subject, identity.Email)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return errs.ErrUserAlreadyExists
+			}
+		}
+		return err
+	}
+
+	return nil
+}
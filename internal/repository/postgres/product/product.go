@@ -2,11 +2,16 @@ package product_repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -25,11 +30,14 @@ func New(db *pgxpool.Pool) *Postgres {
 func (p *Postgres) SaveProduct(
 	ctx context.Context,
 	productId string,
+	slug string,
 	name string,
 	description string,
 	price float32,
+	weight int,
 	imageUrl string,
 	categoryIds []string,
+	attributes []models.AttributeValue,
 ) error {
 	const op = "repository.postgres.product.SaveProduct"
 
@@ -46,10 +54,16 @@ func (p *Postgres) SaveProduct(
 	}()
 
 	query := `INSERT INTO products
-			  (id, name, description, price, image_url)
-			  VALUES ($1, $2, $3, $4, $5)`
-	_, err = tx.Exec(ctx, query, productId, name, description, price, imageUrl)
+			  (id, slug, name, description, price, weight, image_url)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err = tx.Exec(ctx, query, productId, slug, name, description, price, weight, imageUrl)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return fmt.Errorf("%s: %w", op, errs.ErrSlugAlreadyExists)
+			}
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -87,23 +101,51 @@ func (p *Postgres) SaveProduct(
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	for _, attribute := range attributes {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO products_attributes (product_id, attribute_id, value) VALUES ($1, $2, $3)",
+			productId,
+			attribute.AttributeId,
+			attribute.Value,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, "INSERT INTO price_history (product_id, price) VALUES ($1, $2)", productId, price)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
 
 // TODO: add tests
-func (p *Postgres) ProductsByCategoryId(ctx context.Context, categoryId string, page int) ([]models.ProductCard, error) {
+func (p *Postgres) ProductsByCategoryId(
+	ctx context.Context,
+	categoryId string,
+	page int,
+	filters []models.AttributeValue,
+) ([]models.ProductCard, error) {
 	const op = "repository.postgres.product.ProductsByCategoryId"
 
-	query := `SELECT p.id, p.name, p.price, p.image_url
+	filtersQuery, args := attributeFilters(filters, 4)
+	query := fmt.Sprintf(`SELECT p.id, p.slug, p.name, p.price, COALESCE(p.compare_at_price, 0), p.image_url, p.rating, p.reviews_count
 			  FROM products p
 			  JOIN products_categories pc
 			  ON p.id = pc.product_id
-			  AND pc.category_id = $1
+			  JOIN categories c
+			  ON pc.category_id = c.id
+			  AND (c.id::text = $1 OR c.slug = $1)
+			  %s
 			  ORDER BY p.price
 			  OFFSET $2
-			  LIMIT $3`
+			  LIMIT $3`, filtersQuery)
 	products := make([]models.ProductCard, 0, pageSize)
-	rows, err := p.db.Query(ctx, query, categoryId, page*pageSize, page*pageSize+pageSize)
+	args = append([]any{categoryId, page * pageSize, page*pageSize + pageSize}, args...)
+	rows, err := p.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -114,9 +156,13 @@ func (p *Postgres) ProductsByCategoryId(ctx context.Context, categoryId string,
 
 		err = rows.Scan(
 			&product.ID,
+			&product.Slug,
 			&product.Name,
 			&product.Price,
+			&product.OriginalPrice,
 			&product.ImageUrl,
+			&product.Rating,
+			&product.ReviewsCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
@@ -132,18 +178,21 @@ func (p *Postgres) ProductsByCategoryId(ctx context.Context, categoryId string,
 	return products, nil
 }
 
-func (p *Postgres) AllProducts(ctx context.Context, page int) ([]models.ProductCard, error) {
+func (p *Postgres) AllProducts(ctx context.Context, page int, filters []models.AttributeValue) ([]models.ProductCard, error) {
 	const op = "repository.postgres.product.AllProducts"
 
-	query := `SELECT p.id, p.name, p.price, p.image_url
+	filtersQuery, args := attributeFilters(filters, 3)
+	query := fmt.Sprintf(`SELECT p.id, p.slug, p.name, p.price, COALESCE(p.compare_at_price, 0), p.image_url, p.rating, p.reviews_count
 			  FROM products p
 			  JOIN products_categories pc
 			  ON p.id = pc.product_id
+			  %s
 			  ORDER BY p.price
 			  OFFSET $1
-			  LIMIT $2`
+			  LIMIT $2`, filtersQuery)
 	products := make([]models.ProductCard, 0, pageSize)
-	rows, err := p.db.Query(ctx, query, page*pageSize, page*pageSize+pageSize)
+	args = append([]any{page * pageSize, page*pageSize + pageSize}, args...)
+	rows, err := p.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -154,9 +203,13 @@ func (p *Postgres) AllProducts(ctx context.Context, page int) ([]models.ProductC
 
 		err = rows.Scan(
 			&product.ID,
+			&product.Slug,
 			&product.Name,
 			&product.Price,
+			&product.OriginalPrice,
 			&product.ImageUrl,
+			&product.Rating,
+			&product.ReviewsCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
@@ -173,44 +226,436 @@ func (p *Postgres) AllProducts(ctx context.Context, page int) ([]models.ProductC
 }
 
 func (p *Postgres) ProductById(ctx context.Context, productId string) (models.Product, error) {
-	const op = "repository.postgres.product.AllProducts"
+	const op = "repository.postgres.product.ProductById"
+
+	product, err := p.product(ctx, "p.id = $1", productId)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return product, nil
+}
+
+func (p *Postgres) ProductBySlug(ctx context.Context, slug string) (models.Product, error) {
+	const op = "repository.postgres.product.ProductBySlug"
+
+	product, err := p.product(ctx, "p.slug = $1", slug)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return product, nil
+}
+
+// ProductsWithIdSlug returns ids and names of products which slug is still their id
+func (p *Postgres) ProductsWithIdSlug(ctx context.Context) ([]models.Product, error) {
+	const op = "repository.postgres.product.ProductsWithIdSlug"
+
+	query := "SELECT id, name, slug FROM products WHERE slug = id::text"
+	rows, err := p.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	products := make([]models.Product, 0)
+	for rows.Next() {
+		var product models.Product
+		err := rows.Scan(&product.ID, &product.Name, &product.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		products = append(products, product)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return products, nil
+}
+
+func (p *Postgres) SlugExists(ctx context.Context, slug string) (bool, error) {
+	const op = "repository.postgres.product.SlugExists"
+
+	query := `SELECT EXISTS(SELECT 1 FROM products WHERE slug = $1)
+			  OR EXISTS(SELECT 1 FROM slug_redirects WHERE entity_type = $2 AND old_slug = $1)`
+	var exists bool
+	err := p.db.QueryRow(ctx, query, slug, consts.SlugEntityProduct).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exists, nil
+}
+
+// SlugByOldSlug returns current product slug for slug the product had before
+func (p *Postgres) SlugByOldSlug(ctx context.Context, oldSlug string) (string, error) {
+	const op = "repository.postgres.product.SlugByOldSlug"
+
+	query := `SELECT p.slug
+			  FROM slug_redirects r
+			  JOIN products p
+			  ON r.entity_id = p.id
+			  AND r.entity_type = $1
+			  AND r.old_slug = $2`
+	var slug string
+	err := p.db.QueryRow(ctx, query, consts.SlugEntityProduct, oldSlug).Scan(&slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, errs.ErrProductNotFound)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return slug, nil
+}
+
+// UpdateSlug changes product slug and records redirect from the old one
+func (p *Postgres) UpdateSlug(ctx context.Context, productId string, slug string) error {
+	const op = "repository.postgres.product.UpdateSlug"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	var oldSlug string
+	err = tx.QueryRow(ctx, "SELECT slug FROM products WHERE id = $1 FOR UPDATE", productId).Scan(&oldSlug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, errs.ErrProductNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if oldSlug == slug {
+		return nil
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM slug_redirects WHERE entity_type = $1 AND old_slug = $2 AND entity_id = $3",
+		consts.SlugEntityProduct,
+		slug,
+		productId,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE products SET slug = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", slug, productId)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return fmt.Errorf("%s: %w", op, errs.ErrSlugAlreadyExists)
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `INSERT INTO slug_redirects
+			  (entity_type, old_slug, entity_id)
+			  VALUES ($1, $2, $3)
+			  ON CONFLICT (entity_type, old_slug) DO UPDATE SET entity_id = EXCLUDED.entity_id`
+	_, err = tx.Exec(ctx, query, consts.SlugEntityProduct, oldSlug, productId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UpdatePrice sets product price and compare-at price, zero compareAtPrice
+// removes it. Change is recorded in price history
+func (p *Postgres) UpdatePrice(ctx context.Context, productId string, price float32, compareAtPrice float32) error {
+	const op = "repository.postgres.product.UpdatePrice"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	err = updatePrice(ctx, tx, productId, price, compareAtPrice)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UpdateTaxClass sets product own tax class, empty taxClass makes
+// product taxed by class of its categories
+func (p *Postgres) UpdateTaxClass(ctx context.Context, productId string, taxClass string) error {
+	const op = "repository.postgres.product.UpdateTaxClass"
+
+	query := "UPDATE products SET tax_class = NULLIF($2, '')::tax_class WHERE id = $1"
+	tag, err := p.db.Exec(ctx, query, productId, taxClass)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrProductNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) SavePriceChange(ctx context.Context, change models.PriceChange) (string, error) {
+	const op = "repository.postgres.product.SavePriceChange"
+
+	query := `INSERT INTO price_changes
+			  (product_id, price, compare_at_price, starts_at)
+			  VALUES ($1, $2, NULLIF($3::numeric, 0), $4)
+			  RETURNING id`
+	var id string
+	err := p.db.QueryRow(ctx, query, change.ProductId, change.Price, change.CompareAtPrice, change.StartsAt).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23503" {
+				return "", fmt.Errorf("%s: %w", op, errs.ErrProductNotFound)
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// ApplyPriceChanges sets prices of price changes which start time has come
+func (p *Postgres) ApplyPriceChanges(ctx context.Context) error {
+	const op = "repository.postgres.product.ApplyPriceChanges"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	query := `SELECT id, product_id, price, COALESCE(compare_at_price, 0)
+			  FROM price_changes
+			  WHERE applied_at IS NULL
+			  AND starts_at <= CURRENT_TIMESTAMP
+			  ORDER BY starts_at
+			  FOR UPDATE SKIP LOCKED`
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes := make([]models.PriceChange, 0)
+	for rows.Next() {
+		var change models.PriceChange
+		err = rows.Scan(&change.ID, &change.ProductId, &change.Price, &change.CompareAtPrice)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		changes = append(changes, change)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, change := range changes {
+		err = updatePrice(ctx, tx, change.ProductId, change.Price, change.CompareAtPrice)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE price_changes SET applied_at = CURRENT_TIMESTAMP WHERE id = $1", change.ID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Postgres) PriceHistory(ctx context.Context, productId string) ([]models.PriceRecord, error) {
+	const op = "repository.postgres.product.PriceHistory"
+
+	query := `SELECT price, COALESCE(compare_at_price, 0), created_at
+			  FROM price_history
+			  WHERE product_id = $1
+			  ORDER BY created_at DESC`
+	rows, err := p.db.Query(ctx, query, productId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	history := make([]models.PriceRecord, 0)
+	for rows.Next() {
+		var record models.PriceRecord
+		err = rows.Scan(&record.Price, &record.CompareAtPrice, &record.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		history = append(history, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return history, nil
+}
+
+func updatePrice(ctx context.Context, tx pgx.Tx, productId string, price float32, compareAtPrice float32) error {
+	query := `UPDATE products
+			  SET price = $1, compare_at_price = NULLIF($2::numeric, 0), updated_at = CURRENT_TIMESTAMP
+			  WHERE id = $3`
+	tag, err := tx.Exec(ctx, query, price, compareAtPrice, productId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrProductNotFound
+	}
+
+	query = `INSERT INTO price_history (product_id, price, compare_at_price)
+			 VALUES ($1, $2, NULLIF($3::numeric, 0))`
+	_, err = tx.Exec(ctx, query, productId, price, compareAtPrice)
+	if err != nil {
+		return err
+	}
 
+	return nil
+}
+
+func (p *Postgres) product(ctx context.Context, condition string, arg string) (models.Product, error) {
 	var product models.Product
 	var categoryIds string
 	var categoryNames string
+	var categorySlugs string
 
-	query := `SELECT p.id, p.name, p.description, p.price, p.image_url, 
-	              string_agg(c.id::text, ' ') AS category_idss, string_agg(c.name, ' ') AS category_names
+	query := fmt.Sprintf(`SELECT p.id, p.slug, p.name, p.description, p.price, COALESCE(p.compare_at_price, 0), p.image_url, p.rating, p.reviews_count,
+	              string_agg(c.id::text, ' ') AS category_idss, string_agg(c.name, ' ') AS category_names,
+	              string_agg(c.slug, ' ') AS category_slugs
 			  FROM products AS p
 			  JOIN products_categories AS pc
 			  ON p.id = pc.product_id
 			  JOIN categories AS c
 			  ON pc.category_id = c.id
-			  AND p.id = $1
-			  GROUP BY p.id`
-	err := p.db.QueryRow(ctx, query, productId).Scan(
+			  AND %s
+			  GROUP BY p.id`, condition)
+	err := p.db.QueryRow(ctx, query, arg).Scan(
 		&product.ID,
+		&product.Slug,
 		&product.Name,
 		&product.Description,
 		&product.Price,
+		&product.OriginalPrice,
 		&product.ImageUrl,
+		&product.Rating,
+		&product.ReviewsCount,
 		&categoryIds,
 		&categoryNames,
+		&categorySlugs,
 	)
 	if err != nil {
-		return models.Product{}, fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Product{}, errs.ErrProductNotFound
+		}
+		return models.Product{}, err
 	}
 
 	categoryNamesArr := strings.Split(categoryNames, " ")
+	categorySlugsArr := strings.Split(categorySlugs, " ")
 	product.Categories = make([]models.Category, 0)
 
 	for i, categoryId := range strings.Split(categoryIds, " ") {
 		category := models.Category{
 			ID:   categoryId,
 			Name: categoryNamesArr[i],
+			Slug: categorySlugsArr[i],
 		}
 		product.Categories = append(product.Categories, category)
 	}
 
+	query = `SELECT a.id, a.name, a.type, COALESCE(a.unit, ''), pa.value
+			 FROM products_attributes pa
+			 JOIN attributes a
+			 ON pa.attribute_id = a.id
+			 AND pa.product_id = $1
+			 ORDER BY a.name`
+	rows, err := p.db.Query(ctx, query, product.ID)
+	if err != nil {
+		return models.Product{}, err
+	}
+	defer rows.Close()
+
+	product.Attributes = make([]models.AttributeValue, 0)
+	for rows.Next() {
+		var attribute models.AttributeValue
+		err = rows.Scan(
+			&attribute.AttributeId,
+			&attribute.Name,
+			&attribute.Type,
+			&attribute.Unit,
+			&attribute.Value,
+		)
+		if err != nil {
+			return models.Product{}, err
+		}
+		product.Attributes = append(product.Attributes, attribute)
+	}
+
+	if err = rows.Err(); err != nil {
+		return models.Product{}, err
+	}
+
 	return product, nil
 }
+
+// attributeFilters builds WHERE conditions for filtering products by attribute values,
+// query args numbering starts from argsStart
+func attributeFilters(filters []models.AttributeValue, argsStart int) (string, []any) {
+	if len(filters) == 0 {
+		return "", nil
+	}
+
+	builder := new(strings.Builder)
+	args := make([]any, 0, len(filters)*2)
+	for i, filter := range filters {
+		if i == 0 {
+			builder.WriteString("WHERE ")
+		} else {
+			builder.WriteString(" AND ")
+		}
+		fmt.Fprintf(
+			builder,
+			`EXISTS (SELECT 1 FROM products_attributes pa
+			  WHERE pa.product_id = p.id AND pa.attribute_id = $%d AND pa.value = $%d)`,
+			argsStart,
+			argsStart+1,
+		)
+		argsStart += 2
+		args = append(args, filter.AttributeId, filter.Value)
+	}
+
+	return builder.String(), args
+}
@@ -20,11 +20,14 @@ func TestPostgres_SaveProduct(t *testing.T) {
 	type args struct {
 		ctx         context.Context
 		productId   string
+		slug        string
 		name        string
 		description string
 		price       float32
+		weight      int
 		imageUrl    string
 		categoryIds []string
+		attributes  []models.AttributeValue
 	}
 
 	pool := initStorage()
@@ -43,7 +46,7 @@ func TestPostgres_SaveProduct(t *testing.T) {
 	categoryIds := make([]string, 0, len(catigories))
 
 	for _, category := range catigories {
-		_, _ = pool.Exec(context.Background(), "INSERT INTO categories (id, name) VALUES ($1, $2)", category.ID, category.Name)
+		_, _ = pool.Exec(context.Background(), "INSERT INTO categories (id, name, slug) VALUES ($1, $2, $3)", category.ID, category.Name, category.ID)
 		categoryIds = append(categoryIds, category.ID)
 	}
 
@@ -61,9 +64,11 @@ func TestPostgres_SaveProduct(t *testing.T) {
 			args: args{
 				ctx:         context.Background(),
 				productId:   uuid.NewString(),
+				slug:        "iphone-" + uuid.NewString(),
 				name:        "iphone",
 				description: "gvdsvs",
 				price:       567.8,
+				weight:      200,
 				imageUrl:    "bfdlknbvldvn",
 				categoryIds: categoryIds,
 			},
@@ -78,11 +83,14 @@ func TestPostgres_SaveProduct(t *testing.T) {
 			if err := p.SaveProduct(
 				tt.args.ctx,
 				tt.args.productId,
+				tt.args.slug,
 				tt.args.name,
 				tt.args.description,
 				tt.args.price,
+				tt.args.weight,
 				tt.args.imageUrl,
 				tt.args.categoryIds,
+				tt.args.attributes,
 			); !errors.Is(err, tt.wantErr) {
 				t.Errorf("Postgres.SaveProduct() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -0,0 +1,235 @@
+package promo_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const pageSize = 20
+
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func New(db *pgxpool.Pool) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
+
+func (p *Postgres) SavePromoCode(ctx context.Context, promo models.PromoCode) (string, error) {
+	const op = "repository.postgres.promo.SavePromoCode"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	query := `INSERT INTO promo_codes
+			  (code, type, value, min_cart_price, starts_at, ends_at, usage_limit, per_user_limit)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING id`
+	var id string
+	err = tx.QueryRow(
+		ctx,
+		query,
+		promo.Code,
+		promo.Type,
+		promo.Value,
+		promo.MinCartPrice,
+		promo.StartsAt,
+		promo.EndsAt,
+		promo.UsageLimit,
+		promo.PerUserLimit,
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return "", fmt.Errorf("%s: %w", op, errs.ErrPromoCodeExists)
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, categoryId := range promo.CategoryIds {
+		query = "INSERT INTO promo_codes_categories (promo_code_id, category_id) VALUES ($1, $2)"
+		_, err = tx.Exec(ctx, query, id, categoryId)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				if pgErr.Code == "23503" {
+					return "", fmt.Errorf("%s: %w", op, errs.ErrCategoryNotFound)
+				}
+			}
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return id, nil
+}
+
+func (p *Postgres) PromoCodes(ctx context.Context, page int) ([]models.PromoCode, error) {
+	const op = "repository.postgres.promo.PromoCodes"
+
+	query := fmt.Sprintf(`%s
+			  ORDER BY pc.created_at DESC
+			  OFFSET $1
+			  LIMIT $2`, selectPromoCode)
+	rows, err := p.db.Query(ctx, query, page*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	promoCodes := make([]models.PromoCode, 0, pageSize)
+	for rows.Next() {
+		promo, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		promoCodes = append(promoCodes, promo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promoCodes, nil
+}
+
+func (p *Postgres) PromoCodeByCode(ctx context.Context, code string) (models.PromoCode, error) {
+	const op = "repository.postgres.promo.PromoCodeByCode"
+
+	query := selectPromoCode + " WHERE pc.code = $1"
+	promo, err := scanPromoCode(p.db.QueryRow(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PromoCode{}, fmt.Errorf("%s: %w", op, errs.ErrPromoCodeNotFound)
+		}
+		return models.PromoCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promo, nil
+}
+
+// DeletePromoCode deactivates promo code, it is not deleted to keep usage history
+func (p *Postgres) DeletePromoCode(ctx context.Context, id string) error {
+	const op = "repository.postgres.promo.DeletePromoCode"
+
+	query := `UPDATE promo_codes
+			  SET is_active = false, updated_at = CURRENT_TIMESTAMP
+			  WHERE id = $1`
+	tag, err := p.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrPromoCodeNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) UsageCountByUser(ctx context.Context, promoCodeId, userId string) (int, error) {
+	const op = "repository.postgres.promo.UsageCountByUser"
+
+	query := "SELECT COUNT(*) FROM promo_code_usages WHERE promo_code_id = $1 AND user_id = $2"
+	var count int
+	err := p.db.QueryRow(ctx, query, promoCodeId, userId).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+func (p *Postgres) SetCartPromoCode(ctx context.Context, userId, promoCodeId string) error {
+	const op = "repository.postgres.promo.SetCartPromoCode"
+
+	query := `INSERT INTO cart_promo_codes (user_id, promo_code_id)
+			  VALUES ($1, $2)
+			  ON CONFLICT (user_id)
+			  DO UPDATE SET promo_code_id = EXCLUDED.promo_code_id, created_at = CURRENT_TIMESTAMP`
+	_, err := p.db.Exec(ctx, query, userId, promoCodeId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) DeleteCartPromoCode(ctx context.Context, userId string) error {
+	const op = "repository.postgres.promo.DeleteCartPromoCode"
+
+	tag, err := p.db.Exec(ctx, "DELETE FROM cart_promo_codes WHERE user_id = $1", userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrPromoCodeNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) CartPromoCode(ctx context.Context, userId string) (models.PromoCode, error) {
+	const op = "repository.postgres.promo.CartPromoCode"
+
+	query := selectPromoCode + `
+			  JOIN cart_promo_codes c
+			  ON c.promo_code_id = pc.id
+			  AND c.user_id = $1`
+	promo, err := scanPromoCode(p.db.QueryRow(ctx, query, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PromoCode{}, fmt.Errorf("%s: %w", op, errs.ErrPromoCodeNotFound)
+		}
+		return models.PromoCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promo, nil
+}
+
+const selectPromoCode = `SELECT pc.id, pc.code, pc.type, pc.value, pc.min_cart_price, pc.starts_at, pc.ends_at,
+			  pc.usage_limit, pc.per_user_limit, pc.used_count, pc.is_active,
+			  ARRAY(SELECT pcc.category_id::text FROM promo_codes_categories pcc WHERE pcc.promo_code_id = pc.id)
+			  FROM promo_codes pc`
+
+func scanPromoCode(row pgx.Row) (models.PromoCode, error) {
+	var promo models.PromoCode
+	err := row.Scan(
+		&promo.ID,
+		&promo.Code,
+		&promo.Type,
+		&promo.Value,
+		&promo.MinCartPrice,
+		&promo.StartsAt,
+		&promo.EndsAt,
+		&promo.UsageLimit,
+		&promo.PerUserLimit,
+		&promo.UsedCount,
+		&promo.IsActive,
+		&promo.CategoryIds,
+	)
+	if err != nil {
+		return models.PromoCode{}, err
+	}
+
+	return promo, nil
+}
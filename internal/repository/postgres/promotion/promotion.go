@@ -0,0 +1,181 @@
+package promotion_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const pageSize = 20
+
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func New(db *pgxpool.Pool) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
+
+func (p *Postgres) SavePromotion(ctx context.Context, promotion models.Promotion) (string, error) {
+	const op = "repository.postgres.promotion.SavePromotion"
+
+	query := `INSERT INTO promotions
+			  (name, type, priority, stackable, is_active, category_id, product_id, gift_product_id,
+			  buy_quantity, pay_quantity, min_cart_price, value, starts_at, ends_at)
+			  VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, NULLIF($7, '')::uuid, NULLIF($8, '')::uuid,
+			  $9, $10, $11, $12, $13, $14)
+			  RETURNING id`
+	var id string
+	err := p.db.QueryRow(ctx, query, promotionArgs(promotion)...).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, mapError(err))
+	}
+
+	return id, nil
+}
+
+func (p *Postgres) UpdatePromotion(ctx context.Context, promotion models.Promotion) error {
+	const op = "repository.postgres.promotion.UpdatePromotion"
+
+	query := `UPDATE promotions
+			  SET name = $1, type = $2, priority = $3, stackable = $4, is_active = $5,
+			  category_id = NULLIF($6, '')::uuid, product_id = NULLIF($7, '')::uuid,
+			  gift_product_id = NULLIF($8, '')::uuid, buy_quantity = $9, pay_quantity = $10,
+			  min_cart_price = $11, value = $12, starts_at = $13, ends_at = $14,
+			  updated_at = CURRENT_TIMESTAMP
+			  WHERE id = $15`
+	tag, err := p.db.Exec(ctx, query, append(promotionArgs(promotion), promotion.ID)...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, mapError(err))
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrPromotionNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) DeletePromotion(ctx context.Context, id string) error {
+	const op = "repository.postgres.promotion.DeletePromotion"
+
+	tag, err := p.db.Exec(ctx, "DELETE FROM promotions WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrPromotionNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) Promotions(ctx context.Context, page int) ([]models.Promotion, error) {
+	const op = "repository.postgres.promotion.Promotions"
+
+	promotions, err := p.promotions(ctx, "ORDER BY priority DESC, created_at OFFSET $1 LIMIT $2", page*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promotions, nil
+}
+
+// ActivePromotions returns promotions which are active now ordered by priority
+func (p *Postgres) ActivePromotions(ctx context.Context) ([]models.Promotion, error) {
+	const op = "repository.postgres.promotion.ActivePromotions"
+
+	promotions, err := p.promotions(ctx, `WHERE is_active
+			  AND (starts_at IS NULL OR starts_at <= CURRENT_TIMESTAMP)
+			  AND (ends_at IS NULL OR ends_at > CURRENT_TIMESTAMP)
+			  ORDER BY priority DESC, created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promotions, nil
+}
+
+func (p *Postgres) promotions(ctx context.Context, condition string, args ...any) ([]models.Promotion, error) {
+	query := fmt.Sprintf(`SELECT id, name, type, priority, stackable, is_active,
+			  COALESCE(category_id::text, ''), COALESCE(product_id::text, ''), COALESCE(gift_product_id::text, ''),
+			  buy_quantity, pay_quantity, min_cart_price, value, starts_at, ends_at
+			  FROM promotions
+			  %s`, condition)
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := make([]models.Promotion, 0)
+	for rows.Next() {
+		var promotion models.Promotion
+		err = rows.Scan(
+			&promotion.ID,
+			&promotion.Name,
+			&promotion.Type,
+			&promotion.Priority,
+			&promotion.Stackable,
+			&promotion.IsActive,
+			&promotion.CategoryId,
+			&promotion.ProductId,
+			&promotion.GiftProductId,
+			&promotion.BuyQuantity,
+			&promotion.PayQuantity,
+			&promotion.MinCartPrice,
+			&promotion.Value,
+			&promotion.StartsAt,
+			&promotion.EndsAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func promotionArgs(promotion models.Promotion) []any {
+	return []any{
+		promotion.Name,
+		promotion.Type,
+		promotion.Priority,
+		promotion.Stackable,
+		promotion.IsActive,
+		promotion.CategoryId,
+		promotion.ProductId,
+		promotion.GiftProductId,
+		promotion.BuyQuantity,
+		promotion.PayQuantity,
+		promotion.MinCartPrice,
+		promotion.Value,
+		promotion.StartsAt,
+		promotion.EndsAt,
+	}
+}
+
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code == "23503" {
+			if pgErr.ConstraintName == "promotions_category_id_fkey" {
+				return errs.ErrCategoryNotFound
+			}
+			return errs.ErrProductNotFound
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,183 @@
+package review_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const pageSize = 10
+
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func New(db *pgxpool.Pool) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
+
+func (p *Postgres) SaveReview(ctx context.Context, review models.Review) (string, error) {
+	const op = "repository.postgres.review.SaveReview"
+
+	query := `INSERT INTO reviews
+			  (product_id, user_id, rating, text)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id`
+	var id string
+	err := p.db.QueryRow(ctx, query, review.ProductId, review.UserId, review.Rating, review.Text).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return "", fmt.Errorf("%s: %w", op, errs.ErrReviewAlreadyExists)
+			case "23503":
+				return "", fmt.Errorf("%s: %w", op, errs.ErrProductNotFound)
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (p *Postgres) IsProductPurchased(ctx context.Context, userId, productId string) (bool, error) {
+	const op = "repository.postgres.review.IsProductPurchased"
+
+	query := `SELECT EXISTS(
+			  	SELECT 1
+			  	FROM order_items oi
+			  	JOIN orders o
+			  	ON oi.order_id = o.id
+			  	AND o.user_id = $1
+			  	AND oi.product_id = $2
+			  )`
+	var purchased bool
+	err := p.db.QueryRow(ctx, query, userId, productId).Scan(&purchased)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purchased, nil
+}
+
+func (p *Postgres) ReviewsByProductId(ctx context.Context, productId string, page int) ([]models.Review, error) {
+	const op = "repository.postgres.review.ReviewsByProductId"
+
+	reviews, err := p.reviews(ctx, "r.product_id = $1 AND r.status = $2", page, productId, consts.ReviewStatusApproved)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
+
+func (p *Postgres) ReviewsByStatus(ctx context.Context, status string, page int) ([]models.Review, error) {
+	const op = "repository.postgres.review.ReviewsByStatus"
+
+	reviews, err := p.reviews(ctx, "r.status = $1", page, status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
+
+// UpdateReviewStatus sets review status and recalculates product rating
+// from approved reviews
+func (p *Postgres) UpdateReviewStatus(ctx context.Context, id string, status string) error {
+	const op = "repository.postgres.review.UpdateReviewStatus"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	query := `UPDATE reviews
+			  SET status = $1, updated_at = CURRENT_TIMESTAMP
+			  WHERE id = $2
+			  RETURNING product_id`
+	var productId string
+	err = tx.QueryRow(ctx, query, status, id).Scan(&productId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, errs.ErrReviewNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `UPDATE products
+			 SET rating = r.rating, reviews_count = r.reviews_count
+			 FROM (
+			 	SELECT COALESCE(ROUND(AVG(rating), 2), 0) AS rating, COUNT(*) AS reviews_count
+			 	FROM reviews
+			 	WHERE product_id = $1
+			 	AND status = $2
+			 ) r
+			 WHERE id = $1`
+	_, err = tx.Exec(ctx, query, productId, consts.ReviewStatusApproved)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) reviews(ctx context.Context, condition string, page int, args ...any) ([]models.Review, error) {
+	query := fmt.Sprintf(`SELECT r.id, r.product_id, r.user_id, COALESCE(u.login, ''), r.rating, r.text, r.status, r.created_at
+			  FROM reviews r
+			  LEFT JOIN users u
+			  ON r.user_id = u.id
+			  WHERE %s
+			  ORDER BY r.created_at DESC
+			  OFFSET $%d
+			  LIMIT $%d`, condition, len(args)+1, len(args)+2)
+	args = append(args, page*pageSize, pageSize)
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]models.Review, 0, pageSize)
+	for rows.Next() {
+		var review models.Review
+		err = rows.Scan(
+			&review.ID,
+			&review.ProductId,
+			&review.UserId,
+			&review.UserLogin,
+			&review.Rating,
+			&review.Text,
+			&review.Status,
+			&review.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
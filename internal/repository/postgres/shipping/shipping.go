@@ -0,0 +1,132 @@
+package shipping_repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func New(db *pgxpool.Pool) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
+
+func (p *Postgres) SaveMethod(ctx context.Context, method models.ShippingMethod) (string, error) {
+	const op = "repository.postgres.shipping.SaveMethod"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	query := `INSERT INTO shipping_methods (name, type, is_active)
+			  VALUES ($1, $2, $3)
+			  RETURNING id`
+	var id string
+	err = tx.QueryRow(ctx, query, method.Name, method.Type, method.IsActive).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, rate := range method.Rates {
+		query = `INSERT INTO shipping_rates
+				 (method_id, region, max_weight, price, min_days, max_days)
+				 VALUES ($1, $2, $3, $4, $5, $6)`
+		_, err = tx.Exec(ctx, query, id, rate.Region, rate.MaxWeight, rate.Price, rate.MinDays, rate.MaxDays)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return id, nil
+}
+
+// Methods returns all shipping methods with their rates
+func (p *Postgres) Methods(ctx context.Context) ([]models.ShippingMethod, error) {
+	const op = "repository.postgres.shipping.Methods"
+
+	query := `SELECT m.id, m.name, m.type, m.is_active,
+			  r.region, r.max_weight, r.price, r.min_days, r.max_days
+			  FROM shipping_methods m
+			  LEFT JOIN shipping_rates r
+			  ON r.method_id = m.id
+			  ORDER BY m.created_at, m.id, r.region, r.max_weight`
+	rows, err := p.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	methods := make([]models.ShippingMethod, 0)
+	for rows.Next() {
+		var method models.ShippingMethod
+		var region *string
+		var maxWeight, minDays, maxDays *int
+		var price *float32
+		err = rows.Scan(
+			&method.ID,
+			&method.Name,
+			&method.Type,
+			&method.IsActive,
+			&region,
+			&maxWeight,
+			&price,
+			&minDays,
+			&maxDays,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if len(methods) == 0 || methods[len(methods)-1].ID != method.ID {
+			method.Rates = make([]models.ShippingRate, 0)
+			methods = append(methods, method)
+		}
+		if price != nil {
+			last := &methods[len(methods)-1]
+			last.Rates = append(last.Rates, models.ShippingRate{
+				Region:    *region,
+				MaxWeight: *maxWeight,
+				Price:     *price,
+				MinDays:   *minDays,
+				MaxDays:   *maxDays,
+			})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return methods, nil
+}
+
+func (p *Postgres) DeleteMethod(ctx context.Context, id string) error {
+	const op = "repository.postgres.shipping.DeleteMethod"
+
+	tag, err := p.db.Exec(ctx, "DELETE FROM shipping_methods WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrShippingNotFound)
+	}
+
+	return nil
+}
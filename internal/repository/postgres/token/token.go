@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,13 +22,23 @@ func New(db *pgxpool.Pool) *Postgres {
 	}
 }
 
-func (p *Postgres) SaveToken(ctx context.Context, userId, token, tokenType string) error {
+// SaveToken saves token which expires after ttl
+func (p *Postgres) SaveToken(ctx context.Context, userId, token, tokenType string, ttl time.Duration) error {
 	const op = "repository.postgres.token.SaveToken"
 
-	query := `INSERT INTO tokens
-			  (user_id, token, type)
-			  VALUES ($1, $2, $3)`
-	_, err := p.db.Exec(ctx, query, userId, token, tokenType)
+	err := p.saveToken(ctx, userId, token, tokenType, nil, ttl)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SaveEmailToken saves token bound to email it is sent to
+func (p *Postgres) SaveEmailToken(ctx context.Context, userId, token, tokenType, email string, ttl time.Duration) error {
+	const op = "repository.postgres.token.SaveEmailToken"
+
+	err := p.saveToken(ctx, userId, token, tokenType, &email, ttl)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -34,18 +46,147 @@ func (p *Postgres) SaveToken(ctx context.Context, userId, token, tokenType strin
 	return nil
 }
 
+func (p *Postgres) saveToken(ctx context.Context, userId, token, tokenType string, email *string, ttl time.Duration) error {
+	query := `INSERT INTO tokens
+			  (user_id, token, type, email, expires_at)
+			  VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP + $5 * INTERVAL '1 second')`
+	_, err := p.db.Exec(ctx, query, userId, token, tokenType, email, int64(ttl.Seconds()))
+
+	return err
+}
+
+// UserIdByToken returns token owner and marks token as used,
+// so token can be used only once
 func (p *Postgres) UserIdByToken(ctx context.Context, token, tokenType string) (string, error) {
 	const op = "repository.postgres.token.UserIdByToken"
 
-	query := "SELECT user_id FROM tokens WHERE token = $1 AND type = $2"
-	var userId string
-	err := p.db.QueryRow(ctx, query, token, tokenType).Scan(&userId)
+	userId, _, err := p.useToken(ctx, token, tokenType)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", fmt.Errorf("%s: %w", op, errs.ErrTokenNotFound)
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userId, nil
+}
+
+// UseEmailToken returns token owner and email token is bound to and marks
+// token as used, email is empty for tokens saved without it
+func (p *Postgres) UseEmailToken(ctx context.Context, token, tokenType string) (string, string, error) {
+	const op = "repository.postgres.token.UseEmailToken"
+
+	userId, email, err := p.useToken(ctx, token, tokenType)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userId, email, nil
+}
+
+func (p *Postgres) useToken(ctx context.Context, token, tokenType string) (userId string, email string, err error) {
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
 		}
+	}()
+
+	return useTokenTx(ctx, tx, token, tokenType)
+}
+
+// ResetPassword marks password reset token as used and sets password of its
+// owner in one transaction, so token stays usable if password is not changed
+func (p *Postgres) ResetPassword(ctx context.Context, token, tokenType, password string) (userId string, err error) {
+	const op = "repository.postgres.token.ResetPassword"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	userId, _, err = useTokenTx(ctx, tx, token, tokenType)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := "UPDATE users SET password = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2"
+	tag, err := tx.Exec(ctx, query, password, userId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrUserNotFound)
+	}
 
 	return userId, nil
 }
+
+func useTokenTx(ctx context.Context, tx pgx.Tx, token, tokenType string) (string, string, error) {
+	query := `SELECT user_id, COALESCE(email, ''), used_at IS NOT NULL, expires_at <= CURRENT_TIMESTAMP
+			  FROM tokens
+			  WHERE token = $1 AND type = $2
+			  FOR UPDATE`
+	var (
+		userId  string
+		email   string
+		used    bool
+		expired bool
+	)
+	err := tx.QueryRow(ctx, query, token, tokenType).Scan(&userId, &email, &used, &expired)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", errs.ErrTokenNotFound
+		}
+		return "", "", err
+	}
+	if used {
+		return "", "", errs.ErrTokenUsed
+	}
+	if expired {
+		return "", "", errs.ErrTokenExpired
+	}
+
+	query = "UPDATE tokens SET used_at = CURRENT_TIMESTAMP WHERE token = $1"
+	_, err = tx.Exec(ctx, query, token)
+	if err != nil {
+		return "", "", err
+	}
+
+	return userId, email, nil
+}
+
+// DeleteStaleTokens deletes used and expired tokens
+func (p *Postgres) DeleteStaleTokens(ctx context.Context) error {
+	const op = "repository.postgres.token.DeleteStaleTokens"
+
+	query := "DELETE FROM tokens WHERE used_at IS NOT NULL OR expires_at <= CURRENT_TIMESTAMP"
+	_, err := p.db.Exec(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteUserTokens deletes unused tokens of user with tokenType
+func (p *Postgres) DeleteUserTokens(ctx context.Context, userId, tokenType string) error {
+	const op = "repository.postgres.token.DeleteUserTokens"
+
+	query := "DELETE FROM tokens WHERE user_id = $1 AND type = $2 AND used_at IS NULL"
+	_, err := p.db.Exec(ctx, query, userId, tokenType)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -54,7 +56,7 @@ func TestPostgres_SaveToken(t *testing.T) {
 			p := &Postgres{
 				db: tt.fields.db,
 			}
-			if err := p.SaveToken(tt.args.ctx, tt.args.userId, tt.args.token, tt.args.tokenType); err != nil {
+			if err := p.SaveToken(tt.args.ctx, tt.args.userId, tt.args.token, tt.args.tokenType, time.Hour); err != nil {
 				if !errors.Is(err, tt.wantErr) {
 					t.Errorf("Postgres.SaveToken() error = %v, wantErr %v", err, tt.wantErr)
 				}
@@ -68,6 +70,95 @@ func TestPostgres_SaveToken(t *testing.T) {
 	_, _ = pool.Exec(context.Background(), "DELETE FROM users WHERE id = $1", userId)
 }
 
+func TestPostgres_UserIdByToken(t *testing.T) {
+	pool := initStorage()
+	defer pool.Close()
+
+	ctx := context.Background()
+	p := New(pool)
+
+	userId := uuid.NewString()
+	_, _ = pool.Exec(ctx, "INSERT INTO users (id, email) VALUES ($1, $2)", userId, "sas-test224@gmail.com")
+
+	token := uuid.NewString()
+	expiredToken := uuid.NewString()
+	_ = p.SaveToken(ctx, userId, token, consts.TokenTypeEmailVerify, time.Hour)
+	_ = p.SaveToken(ctx, userId, expiredToken, consts.TokenTypeEmailVerify, -time.Hour)
+
+	got, err := p.UserIdByToken(ctx, token, consts.TokenTypeEmailVerify)
+	if err != nil || got != userId {
+		t.Errorf("Postgres.UserIdByToken() = %v, %v, want %v", got, err, userId)
+	}
+	if _, err = p.UserIdByToken(ctx, token, consts.TokenTypeEmailVerify); !errors.Is(err, errs.ErrTokenUsed) {
+		t.Errorf("Postgres.UserIdByToken() error = %v, wantErr %v", err, errs.ErrTokenUsed)
+	}
+	if _, err = p.UserIdByToken(ctx, expiredToken, consts.TokenTypeEmailVerify); !errors.Is(err, errs.ErrTokenExpired) {
+		t.Errorf("Postgres.UserIdByToken() error = %v, wantErr %v", err, errs.ErrTokenExpired)
+	}
+
+	if err = p.DeleteStaleTokens(ctx); err != nil {
+		t.Errorf("Postgres.DeleteStaleTokens() error = %v", err)
+	}
+	if _, err = p.UserIdByToken(ctx, expiredToken, consts.TokenTypeEmailVerify); !errors.Is(err, errs.ErrTokenNotFound) {
+		t.Errorf("Postgres.UserIdByToken() error = %v, wantErr %v", err, errs.ErrTokenNotFound)
+	}
+
+	_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = $1", userId)
+}
+
+func TestPostgres_ResetPassword(t *testing.T) {
+	pool := initStorage()
+	defer pool.Close()
+
+	ctx := context.Background()
+	p := New(pool)
+
+	userId := uuid.NewString()
+	_, _ = pool.Exec(ctx, "INSERT INTO users (id, email) VALUES ($1, $2)", userId, "sas-test226@gmail.com")
+
+	token := uuid.NewString()
+	_ = p.SaveToken(ctx, userId, token, consts.TokenTypePasswordReset, time.Hour)
+
+	got, err := p.ResetPassword(ctx, token, consts.TokenTypePasswordReset, "hash")
+	if err != nil || got != userId {
+		t.Errorf("Postgres.ResetPassword() = %v, %v, want %v", got, err, userId)
+	}
+	var password string
+	_ = pool.QueryRow(ctx, "SELECT password FROM users WHERE id = $1", userId).Scan(&password)
+	if password != "hash" {
+		t.Errorf("Postgres.ResetPassword() password = %v, want %v", password, "hash")
+	}
+	if _, err = p.ResetPassword(ctx, token, consts.TokenTypePasswordReset, "other"); !errors.Is(err, errs.ErrTokenUsed) {
+		t.Errorf("Postgres.ResetPassword() error = %v, wantErr %v", err, errs.ErrTokenUsed)
+	}
+
+	_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = $1", userId)
+}
+
+func TestPostgres_UseEmailToken(t *testing.T) {
+	pool := initStorage()
+	defer pool.Close()
+
+	ctx := context.Background()
+	p := New(pool)
+
+	userId := uuid.NewString()
+	_, _ = pool.Exec(ctx, "INSERT INTO users (id, email) VALUES ($1, $2)", userId, "sas-test225@gmail.com")
+
+	token := uuid.NewString()
+	_ = p.SaveEmailToken(ctx, userId, token, consts.TokenTypeEmailChange, "new-test225@gmail.com", time.Hour)
+
+	gotUserId, gotEmail, err := p.UseEmailToken(ctx, token, consts.TokenTypeEmailChange)
+	if err != nil || gotUserId != userId || gotEmail != "new-test225@gmail.com" {
+		t.Errorf("Postgres.UseEmailToken() = %v, %v, %v", gotUserId, gotEmail, err)
+	}
+	if _, _, err = p.UseEmailToken(ctx, token, consts.TokenTypeEmailChange); !errors.Is(err, errs.ErrTokenUsed) {
+		t.Errorf("Postgres.UseEmailToken() error = %v, wantErr %v", err, errs.ErrTokenUsed)
+	}
+
+	_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = $1", userId)
+}
+
 func initStorage() *pgxpool.Pool {
 	connString := fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=disable&pool_max_conns=%s&pool_min_conns=%s",
@@ -9,6 +9,7 @@ import (
 	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -68,7 +69,10 @@ func (p *Postgres) SaveAdmin(ctx context.Context, login, email, password string)
 func (p *Postgres) UserByEmail(ctx context.Context, email string) (models.User, error) {
 	const op = "repository.postgres.user.UserByEmail"
 
-	query := "SELECT id, login, email, password, role, is_email_verified FROM users WHERE email = $1"
+	query := `SELECT id, login, email, COALESCE(password, ''), role, is_email_verified,
+			  COALESCE(two_factor_secret, ''), two_factor_enabled
+			  FROM users
+			  WHERE email = $1`
 	var user models.User
 	err := p.db.QueryRow(ctx, query, email).Scan(
 		&user.ID,
@@ -77,6 +81,8 @@ func (p *Postgres) UserByEmail(ctx context.Context, email string) (models.User,
 		&user.Password,
 		&user.Role,
 		&user.IsEmailVerified,
+		&user.TwoFactorSecret,
+		&user.TwoFactorEnabled,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -99,3 +105,297 @@ func (p *Postgres) VerifyEmail(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (p *Postgres) UpdatePassword(ctx context.Context, id, password string) error {
+	const op = "repository.postgres.user.UpdatePassword"
+
+	query := "UPDATE users SET password = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2"
+	tag, err := p.db.Exec(ctx, query, password, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (p *Postgres) UserById(ctx context.Context, id string) (models.User, error) {
+	const op = "repository.postgres.user.UserById"
+
+	query := `SELECT id, login, email, COALESCE(password, ''), role, is_email_verified,
+			  COALESCE(first_name, ''), COALESCE(last_name, ''), COALESCE(phone, ''), created_at,
+			  COALESCE(two_factor_secret, ''), two_factor_enabled
+			  FROM users
+			  WHERE id = $1`
+	var user models.User
+	err := p.db.QueryRow(ctx, query, id).Scan(
+		&user.ID,
+		&user.Login,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.IsEmailVerified,
+		&user.FirstName,
+		&user.LastName,
+		&user.Phone,
+		&user.CreatedAt,
+		&user.TwoFactorSecret,
+		&user.TwoFactorEnabled,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, errs.ErrUserNotFound)
+		}
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// UpdateProfile updates non nil fields of update, empty phone is stored as NULL
+func (p *Postgres) UpdateProfile(ctx context.Context, id string, update models.ProfileUpdate) error {
+	const op = "repository.postgres.user.UpdateProfile"
+
+	query := `UPDATE users
+			  SET login = COALESCE($1, login),
+			  first_name = COALESCE($2, first_name),
+			  last_name = COALESCE($3, last_name),
+			  phone = CASE WHEN $4::text IS NULL THEN phone ELSE NULLIF($4::text, '') END,
+			  updated_at = CURRENT_TIMESTAMP
+			  WHERE id = $5`
+	tag, err := p.db.Exec(ctx, query, update.Login, update.FirstName, update.LastName, update.Phone, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// SetPendingEmail saves email which replaces user email after confirmation
+func (p *Postgres) SetPendingEmail(ctx context.Context, id, email string) error {
+	const op = "repository.postgres.user.SetPendingEmail"
+
+	query := "UPDATE users SET pending_email = $1 WHERE id = $2"
+	tag, err := p.db.Exec(ctx, query, email, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// ConfirmPendingEmail replaces user email with pending one if pending email
+// is still email, email stays verified. Returns replaced email
+func (p *Postgres) ConfirmPendingEmail(ctx context.Context, id, email string) (string, error) {
+	const op = "repository.postgres.user.ConfirmPendingEmail"
+
+	query := `WITH old AS (SELECT email FROM users WHERE id = $1 FOR UPDATE)
+			  UPDATE users
+			  SET email = pending_email, pending_email = NULL,
+			  is_email_verified = true, updated_at = CURRENT_TIMESTAMP
+			  FROM old
+			  WHERE users.id = $1 AND users.pending_email = $2
+			  RETURNING old.email`
+	var oldEmail string
+	err := p.db.QueryRow(ctx, query, id, email).Scan(&oldEmail)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, errs.ErrEmailChangeNotFound)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return "", fmt.Errorf("%s: %w", op, errs.ErrUserAlreadyExists)
+			}
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return oldEmail, nil
+}
+
+// SetTwoFactorSecret starts two factor enrolment, secret
+// of user with enabled two factor authentication is not changed
+func (p *Postgres) SetTwoFactorSecret(ctx context.Context, id, secret string) error {
+	const op = "repository.postgres.user.SetTwoFactorSecret"
+
+	query := "UPDATE users SET two_factor_secret = $1 WHERE id = $2 AND NOT two_factor_enabled"
+	tag, err := p.db.Exec(ctx, query, secret, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrTwoFactorEnabled)
+	}
+
+	return nil
+}
+
+// EnableTwoFactor enables two factor authentication
+// and replaces user recovery codes with codeHashes
+func (p *Postgres) EnableTwoFactor(ctx context.Context, id string, codeHashes []string) (err error) {
+	const op = "repository.postgres.user.EnableTwoFactor"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	var enrolled, enabled bool
+	query := "SELECT two_factor_secret IS NOT NULL, two_factor_enabled FROM users WHERE id = $1 FOR UPDATE"
+	err = tx.QueryRow(ctx, query, id).Scan(&enrolled, &enabled)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, errs.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if enabled {
+		return fmt.Errorf("%s: %w", op, errs.ErrTwoFactorEnabled)
+	}
+	if !enrolled {
+		return fmt.Errorf("%s: %w", op, errs.ErrTwoFactorNotEnrolled)
+	}
+
+	query = "UPDATE users SET two_factor_enabled = true, updated_at = CURRENT_TIMESTAMP WHERE id = $1"
+	_, err = tx.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = saveRecoveryCodes(ctx, tx, id, codeHashes)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes removes all user recovery codes and saves codeHashes
+func (p *Postgres) ReplaceRecoveryCodes(ctx context.Context, id string, codeHashes []string) (err error) {
+	const op = "repository.postgres.user.ReplaceRecoveryCodes"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	err = saveRecoveryCodes(ctx, tx, id, codeHashes)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks unused recovery code as used,
+// every recovery code can be used once
+func (p *Postgres) UseRecoveryCode(ctx context.Context, id, codeHash string) error {
+	const op = "repository.postgres.user.UseRecoveryCode"
+
+	query := `UPDATE recovery_codes
+			  SET used_at = CURRENT_TIMESTAMP
+			  WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+	tag, err := p.db.Exec(ctx, query, id, codeHash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongTwoFactorCode)
+	}
+
+	return nil
+}
+
+// UseTwoFactorStep saves time step of accepted totp code, code of saved
+// or earlier step gives ErrWrongTwoFactorCode, so every code is used once
+func (p *Postgres) UseTwoFactorStep(ctx context.Context, id string, step int64) error {
+	const op = "repository.postgres.user.UseTwoFactorStep"
+
+	query := `UPDATE users
+			  SET two_factor_last_step = $2
+			  WHERE id = $1 AND (two_factor_last_step IS NULL OR two_factor_last_step < $2)`
+	tag, err := p.db.Exec(ctx, query, id, step)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongTwoFactorCode)
+	}
+
+	return nil
+}
+
+// DisableTwoFactor removes two factor secret and recovery codes of user
+func (p *Postgres) DisableTwoFactor(ctx context.Context, id string) (err error) {
+	const op = "repository.postgres.user.DisableTwoFactor"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	query := `UPDATE users
+			  SET two_factor_secret = NULL, two_factor_enabled = false, two_factor_last_step = NULL,
+			      updated_at = CURRENT_TIMESTAMP
+			  WHERE id = $1 AND two_factor_enabled`
+	tag, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrTwoFactorNotEnabled)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM recovery_codes WHERE user_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func saveRecoveryCodes(ctx context.Context, tx pgx.Tx, id string, codeHashes []string) error {
+	_, err := tx.Exec(ctx, "DELETE FROM recovery_codes WHERE user_id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO recovery_codes (user_id, code_hash)
+			  SELECT $1, UNNEST($2::VARCHAR[])`
+	_, err = tx.Exec(ctx, query, id, codeHashes)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
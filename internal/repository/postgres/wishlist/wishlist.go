@@ -0,0 +1,168 @@
+package wishlist_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func New(db *pgxpool.Pool) *Postgres {
+	return &Postgres{
+		db: db,
+	}
+}
+
+func (p *Postgres) AddProduct(ctx context.Context, userId, productId string) error {
+	const op = "repository.postgres.wishlist.AddProduct"
+
+	query := `INSERT INTO wishlist_items (user_id, product_id)
+			  VALUES ($1, $2)
+			  ON CONFLICT (user_id, product_id) DO NOTHING`
+	_, err := p.db.Exec(ctx, query, userId, productId)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23503" {
+				return fmt.Errorf("%s: %w", op, errs.ErrProductNotFound)
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) DeleteProduct(ctx context.Context, userId, productId string) error {
+	const op = "repository.postgres.wishlist.DeleteProduct"
+
+	query := "DELETE FROM wishlist_items WHERE user_id = $1 AND product_id = $2"
+	tag, err := p.db.Exec(ctx, query, userId, productId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrProductNotInWishlist)
+	}
+
+	return nil
+}
+
+// MoveToCart removes product from user wishlist and adds it to cart in one
+// transaction, quantity of product already in cart grows up to maxQuantity
+func (p *Postgres) MoveToCart(ctx context.Context, userId, productId string, maxQuantity int) (cartId string, err error) {
+	const op = "repository.postgres.wishlist.MoveToCart"
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	query := "DELETE FROM wishlist_items WHERE user_id = $1 AND product_id = $2"
+	tag, err := tx.Exec(ctx, query, userId, productId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrProductNotInWishlist)
+	}
+
+	query = `INSERT INTO cart_items (user_id, product_id)
+			 VALUES ($1, $2)
+			 ON CONFLICT (user_id, product_id)
+			 DO UPDATE SET quantity = LEAST(cart_items.quantity + 1, $3)
+			 RETURNING id`
+	err = tx.QueryRow(ctx, query, userId, productId, maxQuantity).Scan(&cartId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cartId, nil
+}
+
+func (p *Postgres) WishlistByUserId(ctx context.Context, userId string) ([]models.ProductCard, error) {
+	const op = "repository.postgres.wishlist.WishlistByUserId"
+
+	query := `SELECT p.id, p.slug, p.name, p.price, COALESCE(p.compare_at_price, 0), p.image_url, p.rating, p.reviews_count
+			  FROM wishlist_items w
+			  JOIN products p
+			  ON w.product_id = p.id
+			  AND w.user_id = $1
+			  ORDER BY w.created_at DESC`
+	rows, err := p.db.Query(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	products := make([]models.ProductCard, 0)
+	for rows.Next() {
+		var product models.ProductCard
+		err = rows.Scan(
+			&product.ID,
+			&product.Slug,
+			&product.Name,
+			&product.Price,
+			&product.OriginalPrice,
+			&product.ImageUrl,
+			&product.Rating,
+			&product.ReviewsCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return products, nil
+}
+
+// FavoriteProductIds returns which of productIds are in user wishlist
+func (p *Postgres) FavoriteProductIds(ctx context.Context, userId string, productIds []string) ([]string, error) {
+	const op = "repository.postgres.wishlist.FavoriteProductIds"
+
+	query := `SELECT product_id
+			  FROM wishlist_items
+			  WHERE user_id = $1
+			  AND product_id::text = ANY($2)`
+	rows, err := p.db.Query(ctx, query, userId, productIds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0, len(productIds))
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
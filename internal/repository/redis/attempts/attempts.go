@@ -0,0 +1,81 @@
+package attempts_cash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cash counts failed attempts, counter is reset when
+// there were no failures for expire
+type Cash struct {
+	rdb    *redis.Client
+	expire time.Duration
+}
+
+func New(rdb *redis.Client, expire time.Duration) *Cash {
+	return &Cash{
+		rdb:    rdb,
+		expire: expire,
+	}
+}
+
+// AddFailure increments failures counter of key and returns its value
+func (c *Cash) AddFailure(ctx context.Context, key string) (int, error) {
+	const op = "repository.redis.attempts.AddFailure"
+
+	pipe := c.rdb.TxPipeline()
+	incr := pipe.Incr(ctx, genKey(key))
+	pipe.Expire(ctx, genKey(key), c.expire)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(incr.Val()), nil
+}
+
+// ResetFailures removes failures counter and lock of key
+func (c *Cash) ResetFailures(ctx context.Context, key string) error {
+	const op = "repository.redis.attempts.ResetFailures"
+
+	err := c.rdb.Del(ctx, genKey(key), genLockKey(key)).Err()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Cash) Lock(ctx context.Context, key string, expire time.Duration) error {
+	const op = "repository.redis.attempts.Lock"
+
+	err := c.rdb.Set(ctx, genLockKey(key), 1, expire).Err()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// LockedFor returns time left until key is unlocked, zero if key is not locked
+func (c *Cash) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	const op = "repository.redis.attempts.LockedFor"
+
+	ttl, err := c.rdb.PTTL(ctx, genLockKey(key)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return max(ttl, 0), nil
+}
+
+func genKey(key string) string {
+	return "login_failures:" + key
+}
+
+func genLockKey(key string) string {
+	return "login_lock:" + key
+}
@@ -0,0 +1,116 @@
+package cart_cash
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type Cash struct {
+	rdb    *redis.Client
+	expire time.Duration
+}
+
+func New(rdb *redis.Client, expire time.Duration) *Cash {
+	return &Cash{
+		rdb:    rdb,
+		expire: expire,
+	}
+}
+
+// AddProduct increases product quantity in guest cart and returns new quantity,
+// quantity is capped by maxQuantity
+func (c *Cash) AddProduct(ctx context.Context, guestId, productId string, maxQuantity int) (int, error) {
+	const op = "repository.redis.cart.AddProduct"
+
+	key := genKey(guestId)
+	quantity, err := c.rdb.HIncrBy(ctx, key, productId, 1).Result()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if quantity > int64(maxQuantity) {
+		quantity = int64(maxQuantity)
+		err = c.rdb.HSet(ctx, key, productId, quantity).Err()
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = c.rdb.Expire(ctx, key, c.expire).Err()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(quantity), nil
+}
+
+// SetQuantity sets product quantity in guest cart, zero quantity removes product.
+// Returns false if product was not in cart
+func (c *Cash) SetQuantity(ctx context.Context, guestId, productId string, quantity int) (bool, error) {
+	const op = "repository.redis.cart.SetQuantity"
+
+	key := genKey(guestId)
+	exists, err := c.rdb.HExists(ctx, key, productId).Result()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return false, nil
+	}
+
+	if quantity == 0 {
+		err = c.rdb.HDel(ctx, key, productId).Err()
+	} else {
+		err = c.rdb.HSet(ctx, key, productId, quantity).Err()
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = c.rdb.Expire(ctx, key, c.expire).Err()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return true, nil
+}
+
+// CartByGuestId returns product quantities by product id
+func (c *Cash) CartByGuestId(ctx context.Context, guestId string) (map[string]int, error) {
+	const op = "repository.redis.cart.CartByGuestId"
+
+	values, err := c.rdb.HGetAll(ctx, genKey(guestId)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	items := make(map[string]int, len(values))
+	for productId, value := range values {
+		quantity, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		items[productId] = quantity
+	}
+
+	return items, nil
+}
+
+func (c *Cash) DeleteCart(ctx context.Context, guestId string) error {
+	const op = "repository.redis.cart.DeleteCart"
+
+	err := c.rdb.Del(ctx, genKey(guestId)).Err()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func genKey(guestId string) string {
+	return "guest_cart:" + guestId
+}
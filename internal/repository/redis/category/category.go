@@ -67,6 +67,34 @@ func (c *Cash) AllCategories(ctx context.Context) ([]models.Category, error) {
 	return categories, nil
 }
 
+// DeleteCategories removes all cached categories
+func (c *Cash) DeleteCategories(ctx context.Context) error {
+	const op = "repository.redis.category.DeleteCategories"
+
+	var (
+		err    error
+		cursor uint64
+		keys   []string
+	)
+	for {
+		keys, cursor, err = c.rdb.Scan(ctx, cursor, "category:*", 100).Result()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if len(keys) > 0 {
+			err = c.rdb.Del(ctx, keys...).Err()
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
 func genKey(id string) string {
 	return "category:" + id
 }
@@ -0,0 +1,39 @@
+package cooldown_cash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cash keeps cooldown keys, action guarded by key can not be
+// repeated until expire passes
+type Cash struct {
+	rdb    *redis.Client
+	expire time.Duration
+}
+
+func New(rdb *redis.Client, expire time.Duration) *Cash {
+	return &Cash{
+		rdb:    rdb,
+		expire: expire,
+	}
+}
+
+// StartCooldown starts cooldown of key, returns false if key is already in cooldown
+func (c *Cash) StartCooldown(ctx context.Context, key string) (bool, error) {
+	const op = "repository.redis.cooldown.StartCooldown"
+
+	ok, err := c.rdb.SetNX(ctx, genKey(key), 1, c.expire).Result()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ok, nil
+}
+
+func genKey(key string) string {
+	return "cooldown:" + key
+}
@@ -0,0 +1,81 @@
+package login_cash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// Cash keeps logins waiting for two factor code for expire
+type Cash struct {
+	rdb    *redis.Client
+	expire time.Duration
+}
+
+func New(rdb *redis.Client, expire time.Duration) *Cash {
+	return &Cash{
+		rdb:    rdb,
+		expire: expire,
+	}
+}
+
+func (c *Cash) SavePendingLogin(ctx context.Context, token string, login models.PendingLogin) error {
+	const op = "repository.redis.login.SavePendingLogin"
+
+	pipe := c.rdb.TxPipeline()
+	pipe.HSet(
+		ctx,
+		genKey(token),
+		"user_id", login.UserId,
+		"guest_id", login.GuestId,
+		"ip", login.Session.IP,
+		"user_agent", login.Session.UserAgent,
+	)
+	pipe.Expire(ctx, genKey(token), c.expire)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Cash) PendingLogin(ctx context.Context, token string) (models.PendingLogin, error) {
+	const op = "repository.redis.login.PendingLogin"
+
+	fields, err := c.rdb.HGetAll(ctx, genKey(token)).Result()
+	if err != nil {
+		return models.PendingLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if fields["user_id"] == "" {
+		return models.PendingLogin{}, fmt.Errorf("%s: %w", op, errs.ErrPendingLoginNotFound)
+	}
+
+	return models.PendingLogin{
+		UserId:  fields["user_id"],
+		GuestId: fields["guest_id"],
+		Session: models.Session{
+			IP:        fields["ip"],
+			UserAgent: fields["user_agent"],
+		},
+	}, nil
+}
+
+func (c *Cash) DeletePendingLogin(ctx context.Context, token string) error {
+	const op = "repository.redis.login.DeletePendingLogin"
+
+	err := c.rdb.Del(ctx, genKey(token)).Err()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func genKey(token string) string {
+	return "pending_login:" + token
+}
@@ -0,0 +1,73 @@
+package oidc_cash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// Cash keeps state of logins with identity provider for expire
+type Cash struct {
+	rdb    *redis.Client
+	expire time.Duration
+}
+
+func New(rdb *redis.Client, expire time.Duration) *Cash {
+	return &Cash{
+		rdb:    rdb,
+		expire: expire,
+	}
+}
+
+func (c *Cash) SaveState(ctx context.Context, state string, oidcState models.OIDCState) error {
+	const op = "repository.redis.oidc.SaveState"
+
+	pipe := c.rdb.TxPipeline()
+	pipe.HSet(
+		ctx,
+		genKey(state),
+		"provider", oidcState.Provider,
+		"nonce", oidcState.Nonce,
+		"verifier", oidcState.Verifier,
+	)
+	pipe.Expire(ctx, genKey(state), c.expire)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PopState returns state and removes it, so every state is used once
+func (c *Cash) PopState(ctx context.Context, state string) (models.OIDCState, error) {
+	const op = "repository.redis.oidc.PopState"
+
+	pipe := c.rdb.TxPipeline()
+	get := pipe.HGetAll(ctx, genKey(state))
+	pipe.Del(ctx, genKey(state))
+	_, err := pipe.Exec(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return models.OIDCState{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fields := get.Val()
+	if fields["provider"] == "" {
+		return models.OIDCState{}, fmt.Errorf("%s: %w", op, errs.ErrOIDCStateNotFound)
+	}
+
+	return models.OIDCState{
+		Provider: fields["provider"],
+		Nonce:    fields["nonce"],
+		Verifier: fields["verifier"],
+	}, nil
+}
+
+func genKey(state string) string {
+	return "oidc_state:" + state
+}
@@ -0,0 +1,79 @@
+package ratelimit_cash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// hitScript keeps hits of the last window in sorted set scored by hit time,
+// hit is recorded only if there are less than limit hits in window. Time is
+// taken from redis, so app instances with different clocks share windows.
+// Returns whether hit is allowed, hits left and ms until the oldest hit leaves window
+var hitScript = redis.NewScript(`
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+redis.call("PEXPIRE", KEYS[1], window)
+
+local reset = window
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, limit - count, reset}
+`)
+
+// Cash limits hits with sliding window
+type Cash struct {
+	rdb *redis.Client
+}
+
+func New(rdb *redis.Client) *Cash {
+	return &Cash{
+		rdb: rdb,
+	}
+}
+
+// Hit records hit of key if there were less than limit hits in the last window
+func (c *Cash) Hit(ctx context.Context, key string, limit int, window time.Duration) (models.RateLimit, error) {
+	const op = "repository.redis.ratelimit.Hit"
+
+	res, err := hitScript.Run(
+		ctx,
+		c.rdb,
+		[]string{genKey(key)},
+		window.Milliseconds(),
+		limit,
+		uuid.NewString(),
+	).Int64Slice()
+	if err != nil {
+		return models.RateLimit{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return models.RateLimit{
+		Allowed:   res[0] == 1,
+		Limit:     limit,
+		Remaining: int(res[1]),
+		Reset:     time.Duration(res[2]) * time.Millisecond,
+	}, nil
+}
+
+func genKey(key string) string {
+	return "rate_limit:" + key
+}
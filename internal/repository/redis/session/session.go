@@ -2,34 +2,97 @@ package session_cash
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	"github.com/redis/go-redis/v9"
 )
 
+// touchScript updates last seen time and expiration of existing
+// session only, so revoked session is not created again, sessions
+// saved without creation time get it on first touch
+var touchScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	redis.call("HSETNX", KEYS[1], "created_at", ARGV[1])
+	redis.call("HSET", KEYS[1], "last_seen_at", ARGV[1])
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Cash keeps sessions for expire, session index lives for maxAge
+// which is the longest possible session lifetime
 type Cash struct {
 	rdb    *redis.Client
 	expire time.Duration
+	maxAge time.Duration
 }
 
-func New(rdb *redis.Client, expire time.Duration) *Cash {
+func New(rdb *redis.Client, expire time.Duration, maxAge time.Duration) *Cash {
 	return &Cash{
 		rdb:    rdb,
 		expire: expire,
+		maxAge: maxAge,
 	}
 }
 
-func (c *Cash) SaveSession(ctx context.Context, id string, user models.User) error {
+// SaveSession saves session with client metadata and puts its id into
+// user session index, index lives as long as the newest user session can
+func (c *Cash) SaveSession(ctx context.Context, id string, user models.User, session models.Session) error {
 	const op = "repository.redis.session.SaveSession"
 
-	err := c.rdb.HSet(ctx, id, user).Err()
+	pipe := c.rdb.TxPipeline()
+	pipe.HSet(ctx, id, user)
+	pipe.HSet(
+		ctx,
+		id,
+		"ip", session.IP,
+		"user_agent", session.UserAgent,
+		"created_at", session.CreatedAt.Unix(),
+		"last_seen_at", session.CreatedAt.Unix(),
+	)
+	pipe.Expire(ctx, id, c.expire)
+	pipe.SAdd(ctx, genUserKey(user.ID), id)
+	pipe.Expire(ctx, genUserKey(user.ID), max(c.expire, c.maxAge))
+	_, err := pipe.Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = c.rdb.Expire(ctx, id, c.expire).Err()
+	return nil
+}
+
+// SessionById returns session owner and session metadata
+func (c *Cash) SessionById(ctx context.Context, sessionId string) (models.User, models.Session, error) {
+	const op = "repository.redis.session.SessionById"
+
+	cmd := c.rdb.HGetAll(ctx, sessionId)
+	fields, err := cmd.Result()
+	if err != nil {
+		return models.User{}, models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var user models.User
+	err = cmd.Scan(&user)
+	if err != nil {
+		return models.User{}, models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.ID == "" {
+		return models.User{}, models.Session{}, fmt.Errorf("%s: %w", op, errs.ErrSessionNotFound)
+	}
+
+	return user, parseSession(sessionId, fields), nil
+}
+
+// TouchSession sets session last seen time and makes it expire after expire
+func (c *Cash) TouchSession(ctx context.Context, sessionId string, lastSeenAt time.Time, expire time.Duration) error {
+	const op = "repository.redis.session.TouchSession"
+
+	err := touchScript.Run(ctx, c.rdb, []string{sessionId}, lastSeenAt.Unix(), expire.Milliseconds()).Err()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -37,14 +100,98 @@ func (c *Cash) SaveSession(ctx context.Context, id string, user models.User) err
 	return nil
 }
 
-func (c *Cash) SessionById(ctx context.Context, sessionId string) (models.User, error) {
-	const op = "repository.redis.session.SessionById"
+// SessionsByUserId returns active user sessions, ids of expired
+// sessions are removed from session index
+func (c *Cash) SessionsByUserId(ctx context.Context, userId string) ([]models.Session, error) {
+	const op = "repository.redis.session.SessionsByUserId"
 
-	var user models.User
-	err := c.rdb.HGetAll(ctx, sessionId).Scan(&user)
+	sessionIds, err := c.rdb.SMembers(ctx, genUserKey(userId)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessions := make([]models.Session, 0, len(sessionIds))
+	for _, sessionId := range sessionIds {
+		fields, err := c.rdb.HGetAll(ctx, sessionId).Result()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if fields["id"] == "" {
+			err = c.rdb.SRem(ctx, genUserKey(userId), sessionId).Err()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			continue
+		}
+
+		sessions = append(sessions, parseSession(sessionId, fields))
+	}
+
+	return sessions, nil
+}
+
+func (c *Cash) DeleteSession(ctx context.Context, sessionId string) error {
+	const op = "repository.redis.session.DeleteSession"
+
+	userId, err := c.rdb.HGet(ctx, sessionId, "id").Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return fmt.Errorf("%s: %w", op, errs.ErrSessionNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	pipe := c.rdb.TxPipeline()
+	pipe.Del(ctx, sessionId)
+	pipe.SRem(ctx, genUserKey(userId), sessionId)
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteUserSessions deletes all sessions of user found in session index
+func (c *Cash) DeleteUserSessions(ctx context.Context, userId string) error {
+	const op = "repository.redis.session.DeleteUserSessions"
+
+	sessionIds, err := c.rdb.SMembers(ctx, genUserKey(userId)).Result()
 	if err != nil {
-		return models.User{}, fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return user, nil
+	keys := append(sessionIds, genUserKey(userId))
+	err = c.rdb.Del(ctx, keys...).Err()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// parseSession builds session from hash fields, sessions saved before
+// times were tracked are treated as created when last seen or now
+func parseSession(sessionId string, fields map[string]string) models.Session {
+	lastSeenAt := time.Now()
+	if unix, err := strconv.ParseInt(fields["last_seen_at"], 10, 64); err == nil {
+		lastSeenAt = time.Unix(unix, 0)
+	}
+	createdAt := lastSeenAt
+	if unix, err := strconv.ParseInt(fields["created_at"], 10, 64); err == nil {
+		createdAt = time.Unix(unix, 0)
+	}
+
+	return models.Session{
+		ID:         sessionId,
+		UserId:     fields["id"],
+		IP:         fields["ip"],
+		UserAgent:  fields["user_agent"],
+		CreatedAt:  createdAt,
+		LastSeenAt: lastSeenAt,
+	}
+}
+
+func genUserKey(userId string) string {
+	return "user_sessions:" + userId
 }
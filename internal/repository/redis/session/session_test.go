@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
@@ -58,7 +59,7 @@ func TestCash_SaveSession(t *testing.T) {
 				rdb:    tt.fields.rdb,
 				expire: tt.fields.expire,
 			}
-			if err := c.SaveSession(tt.args.ctx, tt.args.id, tt.args.user); err != nil {
+			if err := c.SaveSession(tt.args.ctx, tt.args.id, tt.args.user, models.Session{CreatedAt: time.Now()}); err != nil {
 				if !errors.Is(err, tt.wantErr) {
 					t.Errorf("Cash.SaveSession() error = %v, wantErr %v", err, tt.wantErr)
 				}
@@ -67,6 +68,74 @@ func TestCash_SaveSession(t *testing.T) {
 	}
 }
 
+func TestCash_DeleteUserSessions(t *testing.T) {
+	rdb := initCash(t)
+	defer rdb.Close()
+
+	c := New(rdb, time.Minute, time.Hour)
+	ctx := context.Background()
+	user := models.User{
+		ID:   uuid.NewString(),
+		Role: "user",
+	}
+
+	sessionIds := []string{uuid.NewString(), uuid.NewString()}
+	for _, sessionId := range sessionIds {
+		if err := c.SaveSession(ctx, sessionId, user, models.Session{CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Cash.SaveSession() error = %v", err)
+		}
+	}
+
+	if err := c.DeleteSession(ctx, sessionIds[0]); err != nil {
+		t.Fatalf("Cash.DeleteSession() error = %v", err)
+	}
+	if _, _, err := c.SessionById(ctx, sessionIds[0]); !errors.Is(err, errs.ErrSessionNotFound) {
+		t.Errorf("Cash.SessionById() error = %v, wantErr %v", err, errs.ErrSessionNotFound)
+	}
+
+	if err := c.DeleteUserSessions(ctx, user.ID); err != nil {
+		t.Fatalf("Cash.DeleteUserSessions() error = %v", err)
+	}
+	if _, _, err := c.SessionById(ctx, sessionIds[1]); !errors.Is(err, errs.ErrSessionNotFound) {
+		t.Errorf("Cash.SessionById() error = %v, wantErr %v", err, errs.ErrSessionNotFound)
+	}
+}
+
+func TestParseSession(t *testing.T) {
+	tests := []struct {
+		name           string
+		fields         map[string]string
+		wantCreatedAt  int64
+		wantLastSeenAt int64
+	}{
+		{
+			name:           "good case",
+			fields:         map[string]string{"created_at": "100", "last_seen_at": "200"},
+			wantCreatedAt:  100,
+			wantLastSeenAt: 200,
+		},
+		{
+			name:           "no created at case",
+			fields:         map[string]string{"last_seen_at": "200"},
+			wantCreatedAt:  200,
+			wantLastSeenAt: 200,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseSession("id", tt.fields)
+			if got.CreatedAt.Unix() != tt.wantCreatedAt || got.LastSeenAt.Unix() != tt.wantLastSeenAt {
+				t.Errorf("parseSession() = %v, %v, want %v, %v", got.CreatedAt.Unix(), got.LastSeenAt.Unix(), tt.wantCreatedAt, tt.wantLastSeenAt)
+			}
+		})
+	}
+
+	got := parseSession("id", map[string]string{})
+	if time.Since(got.CreatedAt) > time.Minute {
+		t.Errorf("parseSession() created at = %v, want now", got.CreatedAt)
+	}
+}
+
 func initCash(t *testing.T) *redis.Client {
 	t.Helper()
 
@@ -0,0 +1,57 @@
+package cancel_deletion
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+)
+
+type DeletionCanceler interface {
+	CancelDeletion(ctx context.Context, userId string) error
+}
+
+// New godoc
+//
+//	@Summary		cancel account deletion
+//	@Description	cancel scheduled deletion of current user account
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me/cancel-deletion [post]
+func New(deletionCanceler DeletionCanceler) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.account.cancel-deletion.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		err := deletionCanceler.CancelDeletion(ctx, userId)
+		if err != nil {
+			if errors.Is(err, errs.ErrDeletionNotFound) {
+				log.Error("deletion not requested", logger.Err(err))
+				return api.Error(errs.ErrDeletionNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to cancel deletion", logger.Err(err))
+			return api.Error("failed to cancel deletion", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
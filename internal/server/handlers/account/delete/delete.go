@@ -0,0 +1,59 @@
+package delete_account
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	DeletesAt time.Time `json:"deletes_at"`
+}
+
+type AccountDeleter interface {
+	DeleteAccount(ctx context.Context, userId string) (time.Time, error)
+}
+
+// New godoc
+//
+//	@Summary		delete account
+//	@Description	schedule anonymisation of current user personal data, orders are kept, deletion can be cancelled until deletes_at
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Success		202	{object}	Response
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me [delete]
+func New(accountDeleter AccountDeleter) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.account.delete.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		deletesAt, err := accountDeleter.DeleteAccount(ctx, userId)
+		if err != nil {
+			log.Error("failed to delete account", logger.Err(err))
+			return api.Error("failed to delete account", http.StatusInternalServerError)
+		}
+
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, Response{
+			DeletesAt: deletesAt,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,184 @@
+package export_account
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	Profile    profile   `json:"profile"`
+	Addresses  []address `json:"addresses"`
+	Cart       []item    `json:"cart"`
+	Orders     []order   `json:"orders"`
+	Reviews    []review  `json:"reviews"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+type profile struct {
+	ID              string    `json:"id"`
+	Login           string    `json:"login"`
+	Email           string    `json:"email"`
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	Phone           string    `json:"phone"`
+	IsEmailVerified bool      `json:"is_email_verified"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type address struct {
+	Recipient  string `json:"recipient"`
+	Phone      string `json:"phone"`
+	City       string `json:"city"`
+	Street     string `json:"street"`
+	PostalCode string `json:"postal_code"`
+	Comment    string `json:"comment"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+type item struct {
+	ProductId string  `json:"product_id"`
+	Name      string  `json:"name"`
+	Price     float32 `json:"price"`
+	Total     float32 `json:"total"`
+	Quantity  int     `json:"quantity"`
+}
+
+type order struct {
+	ID            string    `json:"id"`
+	Price         float32   `json:"price"`
+	Discount      float32   `json:"discount"`
+	Tax           float32   `json:"tax"`
+	ShippingPrice float32   `json:"shipping_price"`
+	Items         []item    `json:"items"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type review struct {
+	ProductId string    `json:"product_id"`
+	Rating    int       `json:"rating"`
+	Text      string    `json:"text"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AccountExporter interface {
+	Export(ctx context.Context, userId string) (models.AccountExport, error)
+}
+
+// New godoc
+//
+//	@Summary		export personal data
+//	@Description	returns json archive with profile, addresses, cart, orders and reviews of current user
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	Response
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me/export [get]
+func New(accountExporter AccountExporter) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.account.export.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		export, err := accountExporter.Export(ctx, userId)
+		if err != nil {
+			log.Error("failed to export account", logger.Err(err))
+			return api.Error("failed to export account", http.StatusInternalServerError)
+		}
+
+		addresses := make([]address, 0, len(export.Addresses))
+		for _, a := range export.Addresses {
+			addresses = append(addresses, address{
+				Recipient:  a.Recipient,
+				Phone:      a.Phone,
+				City:       a.City,
+				Street:     a.Street,
+				PostalCode: a.PostalCode,
+				Comment:    a.Comment,
+				IsDefault:  a.IsDefault,
+			})
+		}
+
+		cart := make([]item, 0, len(export.Cart.Items))
+		for _, cartItem := range export.Cart.Items {
+			cart = append(cart, item{
+				ProductId: cartItem.Product.ID,
+				Name:      cartItem.Product.Name,
+				Price:     cartItem.Product.Price,
+				Total:     cartItem.Total,
+				Quantity:  cartItem.Quantity,
+			})
+		}
+
+		orders := make([]order, 0, len(export.Orders))
+		for _, o := range export.Orders {
+			items := make([]item, 0, len(o.Items))
+			for _, orderItem := range o.Items {
+				items = append(items, item{
+					ProductId: orderItem.ProductId,
+					Name:      orderItem.Name,
+					Price:     orderItem.Price,
+					Total:     orderItem.Total,
+					Quantity:  orderItem.Quantity,
+				})
+			}
+			orders = append(orders, order{
+				ID:            o.ID,
+				Price:         o.Price,
+				Discount:      o.Discount,
+				Tax:           o.Tax,
+				ShippingPrice: o.ShippingPrice,
+				Items:         items,
+				CreatedAt:     o.CreatedAt,
+			})
+		}
+
+		reviews := make([]review, 0, len(export.Reviews))
+		for _, r := range export.Reviews {
+			reviews = append(reviews, review{
+				ProductId: r.ProductId,
+				Rating:    r.Rating,
+				Text:      r.Text,
+				Status:    r.Status,
+				CreatedAt: r.CreatedAt,
+			})
+		}
+
+		w.Header().Set("Content-Disposition", `attachment; filename="account-export.json"`)
+		render.JSON(w, r, Response{
+			Profile: profile{
+				ID:              export.User.ID,
+				Login:           export.User.Login,
+				Email:           export.User.Email,
+				FirstName:       export.User.FirstName,
+				LastName:        export.User.LastName,
+				Phone:           export.User.Phone,
+				IsEmailVerified: export.User.IsEmailVerified,
+				CreatedAt:       export.User.CreatedAt,
+			},
+			Addresses:  addresses,
+			Cart:       cart,
+			Orders:     orders,
+			Reviews:    reviews,
+			ExportedAt: export.ExportedAt,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package delete_address
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-playground/validator/v10"
+)
+
+type AddressDeleter interface {
+	DeleteAddress(ctx context.Context, userId, id string) error
+}
+
+// New godoc
+//
+//	@Summary		delete address
+//	@Description	delete users address, if it was default the newest remaining address becomes default
+//	@Tags			address
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"address id"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/addresses/{id} [delete]
+func New(validator *validator.Validate, addressDeleter AddressDeleter) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.address.delete.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate address id", logger.Err(err))
+			return api.Error("invalid address id", http.StatusBadRequest)
+		}
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		err := addressDeleter.DeleteAddress(ctx, userId, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrAddressNotFound) {
+				log.Error("address not found", logger.Err(err))
+				return api.Error(errs.ErrAddressNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to delete address", logger.Err(err))
+			return api.Error("failed to delete address", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package get_addresses
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	Addresses []address `json:"addresses"`
+}
+
+type address struct {
+	ID         string `json:"id"`
+	Recipient  string `json:"recipient"`
+	Phone      string `json:"phone"`
+	City       string `json:"city"`
+	Street     string `json:"street"`
+	PostalCode string `json:"postal_code"`
+	Comment    string `json:"comment"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+type AddressProvider interface {
+	AddressesByUserId(ctx context.Context, userId string) ([]models.Address, error)
+}
+
+// New godoc
+//
+//	@Summary		returns users addresses
+//	@Description	returns users address book, default address goes first
+//	@Tags			address
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	Response
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/addresses [get]
+func New(addressProvider AddressProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.address.get.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		addressesInfo, err := addressProvider.AddressesByUserId(ctx, userId)
+		if err != nil {
+			log.Error("failed to get addresses", logger.Err(err))
+			return api.Error("failed to get addresses", http.StatusInternalServerError)
+		}
+
+		addresses := make([]address, 0, len(addressesInfo))
+		for _, addressInfo := range addressesInfo {
+			addresses = append(addresses, address{
+				ID:         addressInfo.ID,
+				Recipient:  addressInfo.Recipient,
+				Phone:      addressInfo.Phone,
+				City:       addressInfo.City,
+				Street:     addressInfo.Street,
+				PostalCode: addressInfo.PostalCode,
+				Comment:    addressInfo.Comment,
+				IsDefault:  addressInfo.IsDefault,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			Addresses: addresses,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,109 @@
+package update_address
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Recipient  string `json:"recipient" validate:"required,max=150"`
+	Phone      string `json:"phone" validate:"required,max=20"`
+	City       string `json:"city" validate:"required,max=100"`
+	Street     string `json:"street" validate:"required,max=255"`
+	PostalCode string `json:"postal_code" validate:"required"`
+	Comment    string `json:"comment" validate:"max=500"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+type AddressUpdater interface {
+	UpdateAddress(ctx context.Context, address models.Address) error
+}
+
+// New godoc
+//
+//	@Summary		update address
+//	@Description	update users address, is_default makes address default, default address stays default until another one is chosen
+//	@Tags			address
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"address id"
+//	@Param			request	body	Request	true	"address"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/addresses/{id} [put]
+func New(validator *validator.Validate, addressUpdater AddressUpdater) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.address.update.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate address id", logger.Err(err))
+			return api.Error("invalid address id", http.StatusBadRequest)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", logger.Err(err))
+			return api.Error("failed to decode request body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request body", logger.Err(err))
+			return api.Error("failed to validate request body", http.StatusBadRequest)
+		}
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		err := addressUpdater.UpdateAddress(ctx, models.Address{
+			ID:         id,
+			UserId:     userId,
+			Recipient:  req.Recipient,
+			Phone:      req.Phone,
+			City:       req.City,
+			Street:     req.Street,
+			PostalCode: req.PostalCode,
+			Comment:    req.Comment,
+			IsDefault:  req.IsDefault,
+		})
+		if err != nil {
+			if errors.Is(err, errs.ErrInvalidPhone) {
+				log.Error("invalid phone", logger.Err(err))
+				return api.Error(errs.ErrInvalidPhone.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrInvalidPostalCode) {
+				log.Error("invalid postal code", logger.Err(err))
+				return api.Error(errs.ErrInvalidPostalCode.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrAddressNotFound) {
+				log.Error("address not found", logger.Err(err))
+				return api.Error(errs.ErrAddressNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to update address", logger.Err(err))
+			return api.Error("failed to update address", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
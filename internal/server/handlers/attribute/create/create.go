@@ -0,0 +1,99 @@
+package create_attribute
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	CategoryId string `json:"category_id" validate:"required,uuid4"`
+	Name       string `json:"name" validate:"required,min=1,max=100"`
+	Type       string `json:"type" validate:"required,oneof=string number bool"`
+	Unit       string `json:"unit" validate:"max=20"`
+	IsRequired bool   `json:"is_required"`
+}
+
+type Response struct {
+	ID string `json:"id"`
+}
+
+type AttributeCreator interface {
+	CreateAttribute(
+		ctx context.Context,
+		categoryId string,
+		name string,
+		attributeType string,
+		unit string,
+		isRequired bool,
+	) (string, error)
+}
+
+// New godoc
+//
+//	@Summary		create new category attribute
+//	@Description	create new attribute definition for products of category
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		Request	true	"attribute definition"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		404		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/create-attribute [post]
+func New(validator *validator.Validate, attributeCreator AttributeCreator) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.attribute.create.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		id, err := attributeCreator.CreateAttribute(
+			ctx,
+			req.CategoryId,
+			req.Name,
+			req.Type,
+			req.Unit,
+			req.IsRequired,
+		)
+		if err != nil {
+			if errors.Is(err, errs.ErrAttributeExists) {
+				log.Error("attribute already exists", logger.Err(err))
+				return api.Error(errs.ErrAttributeExists.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrCategoryNotFound) {
+				log.Error("category not found", logger.Err(err))
+				return api.Error(errs.ErrCategoryNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to create attribute", logger.Err(err))
+			return api.Error("failed to create attribute", http.StatusInternalServerError)
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			ID: id,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+package delete_attribute
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-playground/validator/v10"
+)
+
+type AttributeDeleter interface {
+	DeleteAttribute(ctx context.Context, id string) error
+}
+
+// New godoc
+//
+//	@Summary		delete category attribute
+//	@Description	delete attribute definition with all its product values
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"attribute id"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/delete-attribute/{id} [delete]
+func New(validator *validator.Validate, attributeDeleter AttributeDeleter) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.attribute.delete.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid attribute id", http.StatusBadRequest)
+		}
+
+		err := attributeDeleter.DeleteAttribute(ctx, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrAttributeNotFound) {
+				log.Error("attribute not found", logger.Err(err))
+				return api.Error(errs.ErrAttributeNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to delete attribute", logger.Err(err))
+			return api.Error("failed to delete attribute", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,78 @@
+package get_attributes
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Response struct {
+	Attributes []attribute `json:"attributes"`
+}
+
+type attribute struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Unit       string `json:"unit,omitempty"`
+	IsRequired bool   `json:"is_required"`
+}
+
+type AttributeProvider interface {
+	AttributesByCategoryId(ctx context.Context, categoryId string) ([]models.Attribute, error)
+}
+
+// New godoc
+//
+//	@Summary		returns category attributes
+//	@Description	returns attribute definitions of category, their ids can be used as product filters
+//	@Tags			category
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"category id"
+//	@Success		200	{object}	Response
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/category/{id}/attributes [get]
+func New(validator *validator.Validate, attributeProvider AttributeProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.attribute.get.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		categoryId := r.PathValue("id")
+		if err := validator.Var(categoryId, "required,uuid4"); err != nil {
+			log.Error("failed to validate category id", logger.Err(err))
+			return api.Error("invalid category id", http.StatusBadRequest)
+		}
+
+		attributesInfo, err := attributeProvider.AttributesByCategoryId(ctx, categoryId)
+		if err != nil {
+			log.Error("failed to get attributes", logger.Err(err))
+			return api.Error("failed to get attributes", http.StatusInternalServerError)
+		}
+
+		attributes := make([]attribute, 0, len(attributesInfo))
+		for _, a := range attributesInfo {
+			attributes = append(attributes, attribute{
+				ID:         a.ID,
+				Name:       a.Name,
+				Type:       a.Type,
+				Unit:       a.Unit,
+				IsRequired: a.IsRequired,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			Attributes: attributes,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package update_attribute
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Name       string `json:"name" validate:"required,min=1,max=100"`
+	Type       string `json:"type" validate:"required,oneof=string number bool"`
+	Unit       string `json:"unit" validate:"max=20"`
+	IsRequired bool   `json:"is_required"`
+}
+
+type AttributeUpdater interface {
+	UpdateAttribute(
+		ctx context.Context,
+		id string,
+		name string,
+		attributeType string,
+		unit string,
+		isRequired bool,
+	) error
+}
+
+// New godoc
+//
+//	@Summary		update category attribute
+//	@Description	update attribute definition
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"attribute id"
+//	@Param			request	body	Request	true	"attribute definition"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/update-attribute/{id} [put]
+func New(validator *validator.Validate, attributeUpdater AttributeUpdater) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.attribute.update.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid attribute id", http.StatusBadRequest)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		err := attributeUpdater.UpdateAttribute(ctx, id, req.Name, req.Type, req.Unit, req.IsRequired)
+		if err != nil {
+			if errors.Is(err, errs.ErrAttributeNotFound) {
+				log.Error("attribute not found", logger.Err(err))
+				return api.Error(errs.ErrAttributeNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrAttributeExists) {
+				log.Error("attribute already exists", logger.Err(err))
+				return api.Error(errs.ErrAttributeExists.Error(), http.StatusBadRequest)
+			}
+			log.Error("failed to update attribute", logger.Err(err))
+			return api.Error("failed to update attribute", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package confirm_email
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+)
+
+type EmailConfirmer interface {
+	ConfirmEmail(ctx context.Context, token string) (string, string, error)
+}
+
+type EmailChangedSender interface {
+	SendEmailChanged(to string, newEmail string) error
+}
+
+// New godoc
+//
+//	@Summary		confirm email change
+//	@Description	switch user email to the address email change token was sent to
+//	@Tags			auth
+//	@Accept			json
+//
+//	@Produce		json
+//
+//	@Param			token	path	string	true	"token"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		410	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/auth/confirm-email/{token} [get]
+func New(emailConfirmer EmailConfirmer, emailChangedSender EmailChangedSender) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.confirm-email.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		token := r.PathValue("token")
+		if token == "" {
+			log.Error("token is empty")
+			return api.Error("token is empty", http.StatusBadRequest)
+		}
+
+		oldEmail, newEmail, err := emailConfirmer.ConfirmEmail(ctx, token)
+		if err != nil {
+			if errors.Is(err, errs.ErrTokenNotFound) {
+				log.Error("token not found", logger.Err(err))
+				return api.Error(errs.ErrTokenNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrTokenExpired) {
+				log.Error("token expired", logger.Err(err))
+				return api.Error(errs.ErrTokenExpired.Error(), http.StatusGone)
+			}
+			if errors.Is(err, errs.ErrTokenUsed) {
+				log.Error("token already used", logger.Err(err))
+				return api.Error(errs.ErrTokenUsed.Error(), http.StatusGone)
+			}
+			if errors.Is(err, errs.ErrUserAlreadyExists) {
+				log.Error("user already exists", logger.Err(err))
+				return api.Error(errs.ErrUserAlreadyExists.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrEmailChangeNotFound) {
+				log.Error("email change not requested", logger.Err(err))
+				return api.Error(errs.ErrEmailChangeNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to confirm email", logger.Err(err))
+			return api.Error("failed to confirm email", http.StatusInternalServerError)
+		}
+
+		go func() {
+			if err := emailChangedSender.SendEmailChanged(oldEmail, newEmail); err != nil {
+				log.Error("failed to send email", logger.Err(err))
+			}
+		}()
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
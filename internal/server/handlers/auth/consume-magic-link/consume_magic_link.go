@@ -0,0 +1,129 @@
+package consume_magic_link
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Token string `json:"token" validate:"required,uuid"`
+}
+
+type Response struct {
+	TwoFactorToken string `json:"two_factor_token"`
+}
+
+type MagicLinkLoginer interface {
+	LoginByMagicLink(ctx context.Context, token, guestId string, session models.Session) (models.LoginResult, error)
+}
+
+type SessionCreator interface {
+	Create(w http.ResponseWriter, sessionId string)
+}
+
+type GuestCookie interface {
+	GuestId(r *http.Request) (string, bool)
+	Delete(w http.ResponseWriter)
+}
+
+// New godoc
+//
+//	@Summary		login with magic link
+//	@Description	login with token from magic link email, session is created the same way as password login does.
+//	@Description	Users with two factor authentication get two_factor_token which is sent to /auth/login/2fa with code
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	body	string	true	"Magic link token"	Format(uuid)
+//	@Success		201
+//	@Success		202	{object}	Response
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		403	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		410	{object}	api.ErrorResponse
+//	@Failure		429	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/auth/magic-link/consume [post]
+func New(
+	validator *validator.Validate,
+	magicLinkLoginer MagicLinkLoginer,
+	sessionCreator SessionCreator,
+	guestCookie GuestCookie,
+) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.consume-magic-link.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		guestId, _ := guestCookie.GuestId(r)
+
+		result, err := magicLinkLoginer.LoginByMagicLink(ctx, req.Token, guestId, models.Session{
+			IP:        api.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+		if err != nil && !errors.Is(err, errs.ErrFailedToMergeCart) {
+			if errors.Is(err, errs.ErrTokenNotFound) {
+				log.Error("token not found", logger.Err(err))
+				return api.Error(errs.ErrTokenNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrTokenExpired) {
+				log.Error("token expired", logger.Err(err))
+				return api.Error(errs.ErrTokenExpired.Error(), http.StatusGone)
+			}
+			if errors.Is(err, errs.ErrTokenUsed) {
+				log.Error("token already used", logger.Err(err))
+				return api.Error(errs.ErrTokenUsed.Error(), http.StatusGone)
+			}
+			if errors.Is(err, errs.ErrTooManyRequests) {
+				log.Error("two factor login is locked", logger.Err(err))
+				return api.Error(errs.ErrTooManyRequests.Error(), http.StatusTooManyRequests)
+			}
+			if errors.Is(err, errs.ErrEmailNotVerify) {
+				log.Error("email not verified", logger.Err(err))
+				return api.Error(errs.ErrEmailNotVerify.Error(), http.StatusForbidden)
+			}
+
+			log.Error("failed to login user", logger.Err(err))
+			return api.Error("failed to login user", http.StatusInternalServerError)
+		}
+
+		if result.TwoFactorToken != "" {
+			render.Status(r, http.StatusAccepted)
+			render.JSON(w, r, Response{
+				TwoFactorToken: result.TwoFactorToken,
+			})
+			return nil
+		}
+
+		if err != nil {
+			log.Error("failed to merge guest cart", logger.Err(err))
+		} else if guestId != "" {
+			guestCookie.Delete(w)
+		}
+
+		sessionCreator.Create(w, result.SessionId)
+
+		return nil
+	}
+}
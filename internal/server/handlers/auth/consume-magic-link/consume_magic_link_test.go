@@ -0,0 +1,56 @@
+package consume_magic_link
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/config"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/email"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	consume_magic_link_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/consume-magic-link/__mocks__"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/session"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/signer"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNew_EmailedLink follows link from magic link email the way
+// frontend page does: token from link is sent to consume endpoint
+func TestNew_EmailedLink(t *testing.T) {
+	token := uuid.NewString()
+	mail := email.New(config.MailConfig{FrontendURL: "https://shop.example/"})
+
+	link, err := url.Parse(mail.MagicLinkURL(token))
+	require.NoError(t, err)
+	require.Equal(t, "shop.example", link.Host)
+	require.Equal(t, "/auth/magic-link", link.Path)
+
+	loginerMock := consume_magic_link_mocks.NewMockMagicLinkLoginer(t)
+	loginerMock.EXPECT().LoginByMagicLink(
+		mock.Anything,
+		token,
+		"",
+		mock.AnythingOfType("models.Session"),
+	).Return(models.LoginResult{SessionId: "session"}, nil).Once()
+
+	handler := api.ErrorWrapper(New(
+		validator.New(),
+		loginerMock,
+		session.New("session_id", true, false, http.SameSiteLaxMode, 600),
+		session.NewGuest("guest_cart_id", false, 600, signer.New("secret")),
+	))
+
+	body := fmt.Sprintf(`{"token": "%s"}`, link.Query().Get("token"))
+	req := httptest.NewRequest(http.MethodPost, "/auth/magic-link/consume", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+}
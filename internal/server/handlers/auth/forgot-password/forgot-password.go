@@ -0,0 +1,86 @@
+package forgot_password
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type TokenCreator interface {
+	CreatePasswordResetToken(ctx context.Context, email string) (models.User, string, error)
+}
+
+type PasswordResetSender interface {
+	SendPasswordReset(to string, token, login string) error
+}
+
+// New godoc
+//
+//	@Summary		forgot password
+//	@Description	send password reset link to user email, old links stop working. Responds the same way if user does not exist.
+//	@Description	Link leads to frontend page MAIL_FRONTEND_URL/auth/reset-password?token=..., which sends token with new password to /auth/reset-password
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			email	body	string	true	"User email"	Format(email)
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		429	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/auth/forgot-password [post]
+func New(validator *validator.Validate, tokenCreator TokenCreator, passwordResetSender PasswordResetSender) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.forgot-password.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		user, token, err := tokenCreator.CreatePasswordResetToken(ctx, req.Email)
+		if err != nil {
+			if errors.Is(err, errs.ErrTooManyRequests) {
+				log.Error("password reset is in cooldown", logger.Err(err))
+				return api.Error(errs.ErrTooManyRequests.Error(), http.StatusTooManyRequests)
+			}
+			if errors.Is(err, errs.ErrUserNotFound) {
+				log.Info("password reset for unknown email requested")
+				w.WriteHeader(http.StatusNoContent)
+				return nil
+			}
+			log.Error("failed to create token", logger.Err(err))
+			return api.Error("failed to create token", http.StatusInternalServerError)
+		}
+
+		err = passwordResetSender.SendPasswordReset(user.Email, token, user.Login)
+		if err != nil {
+			log.Error("failed to send email", logger.Err(err))
+			return api.Error("failed to send email", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package login_2fa
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	TwoFactorToken string `json:"two_factor_token" validate:"required,uuid"`
+	Code           string `json:"code" validate:"required,max=20"`
+}
+
+type TwoFactorLoginer interface {
+	LoginTwoFactor(ctx context.Context, token, code string) (string, error)
+}
+
+type SessionCreator interface {
+	Create(w http.ResponseWriter, sessionId string)
+}
+
+type GuestCookie interface {
+	GuestId(r *http.Request) (string, bool)
+	Delete(w http.ResponseWriter)
+}
+
+// New godoc
+//
+//	@Summary		complete login with two factor code
+//	@Description	complete login started by /auth/login with code from authenticator app or one of recovery codes
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			two_factor_token	body	string	true	"Token from login response"	Format(uuid)
+//	@Param			code				body	string	true	"TOTP or recovery code"
+//	@Success		201
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		429	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/auth/login/2fa [post]
+func New(validator *validator.Validate, loginer TwoFactorLoginer, sessionCreator SessionCreator, guestCookie GuestCookie) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.login-2fa.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		sessionId, err := loginer.LoginTwoFactor(ctx, req.TwoFactorToken, req.Code)
+		if err != nil && !errors.Is(err, errs.ErrFailedToMergeCart) {
+			if errors.Is(err, errs.ErrPendingLoginNotFound) {
+				log.Error("pending login not found", logger.Err(err))
+				return api.Error(errs.ErrPendingLoginNotFound.Error(), http.StatusUnauthorized)
+			}
+			if errors.Is(err, errs.ErrWrongTwoFactorCode) {
+				log.Error("wrong two factor code", logger.Err(err))
+				return api.Error(errs.ErrWrongTwoFactorCode.Error(), http.StatusUnauthorized)
+			}
+			if errors.Is(err, errs.ErrTooManyRequests) {
+				log.Error("too many wrong codes", logger.Err(err))
+				return api.Error(errs.ErrTooManyRequests.Error(), http.StatusTooManyRequests)
+			}
+
+			log.Error("failed to login user", logger.Err(err))
+			return api.Error("failed to login user", http.StatusInternalServerError)
+		}
+
+		if err != nil {
+			log.Error("failed to merge guest cart", logger.Err(err))
+		} else if _, ok := guestCookie.GuestId(r); ok {
+			guestCookie.Delete(w)
+		}
+
+		sessionCreator.Create(w, sessionId)
+
+		return nil
+	}
+}
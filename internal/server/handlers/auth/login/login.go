@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
 	"github.com/go-chi/render"
@@ -18,18 +21,32 @@ type Request struct {
 	Password string `json:"password" validate:"required,min=3"`
 }
 
+type Response struct {
+	TwoFactorToken string `json:"two_factor_token"`
+}
+
 type Loginer interface {
-	Login(ctx context.Context, email, password string) (string, error)
+	Login(ctx context.Context, email, password, guestId string, session models.Session) (models.LoginResult, error)
 }
 
 type SessionCreator interface {
 	Create(w http.ResponseWriter, sessionId string)
 }
 
+type LockoutSender interface {
+	SendLockout(to string) error
+}
+
+type GuestCookie interface {
+	GuestId(r *http.Request) (string, bool)
+	Delete(w http.ResponseWriter)
+}
+
 // New godoc
 //
 //	@Summary		login user
-//	@Description	login user
+//	@Description	login user, guest cart from cookie is merged into user cart, repeated failures temporarily lock login.
+//	@Description	Users with two factor authentication get two_factor_token which is sent to /auth/login/2fa with code
 //	@Tags			auth
 //	@Accept			json
 //
@@ -38,11 +55,20 @@ type SessionCreator interface {
 //	@Param			email		body	string	true	"User email"	Format(email)
 //	@Param			password	body	string	true	"User password"
 //	@Success		201
+//	@Success		202	{object}	Response
 //	@Failure		400	{object}	api.ErrorResponse
-//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		403	{object}	api.ErrorResponse
+//	@Failure		429	{object}	api.ErrorResponse
 //	@Failure		500	{object}	api.ErrorResponse
 //	@Router			/auth/login [post]
-func New(loginer Loginer, validator validator.Validate, sessionCreator SessionCreator) api.HandlerFunc {
+func New(
+	loginer Loginer,
+	validator validator.Validate,
+	sessionCreator SessionCreator,
+	guestCookie GuestCookie,
+	lockoutSender LockoutSender,
+) api.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		const op = "handlers.auth.login.New"
 		ctx := r.Context()
@@ -60,18 +86,55 @@ func New(loginer Loginer, validator validator.Validate, sessionCreator SessionCr
 			return api.Error("failed to validate request", http.StatusBadRequest)
 		}
 
-		sessionId, err := loginer.Login(ctx, req.Email, req.Password)
-		if err != nil {
-			if errors.Is(err, errs.ErrUserNotFound) {
-				log.Error("user not found", logger.Err(err))
-				return api.Error(errs.ErrUserNotFound.Error(), http.StatusNotFound)
+		guestId, _ := guestCookie.GuestId(r)
+
+		result, err := loginer.Login(ctx, req.Email, req.Password, guestId, models.Session{
+			IP:        api.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+		if err != nil && !errors.Is(err, errs.ErrFailedToMergeCart) {
+			if errors.Is(err, errs.ErrInvalidCredentials) {
+				log.Error("invalid credentials", logger.Err(err))
+				if errors.Is(err, errs.ErrAccountLocked) {
+					go func() {
+						if err := lockoutSender.SendLockout(req.Email); err != nil {
+							log.Error("failed to send email", logger.Err(err))
+						}
+					}()
+				}
+				return api.Error(errs.ErrInvalidCredentials.Error(), http.StatusUnauthorized)
+			}
+			if errors.Is(err, errs.ErrTooManyRequests) {
+				log.Error("login is locked", logger.Err(err))
+				if result.RetryAfter > 0 {
+					w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+				}
+				return api.Error(errs.ErrTooManyRequests.Error(), http.StatusTooManyRequests)
+			}
+			if errors.Is(err, errs.ErrEmailNotVerify) {
+				log.Error("email not verified", logger.Err(err))
+				return api.Error(errs.ErrEmailNotVerify.Error(), http.StatusForbidden)
 			}
 
 			log.Error("failed to login user", logger.Err(err))
 			return api.Error("failed to login user", http.StatusInternalServerError)
 		}
 
-		sessionCreator.Create(w, sessionId)
+		if result.TwoFactorToken != "" {
+			render.Status(r, http.StatusAccepted)
+			render.JSON(w, r, Response{
+				TwoFactorToken: result.TwoFactorToken,
+			})
+			return nil
+		}
+
+		if err != nil {
+			log.Error("failed to merge guest cart", logger.Err(err))
+		} else if guestId != "" {
+			guestCookie.Delete(w)
+		}
+
+		sessionCreator.Create(w, result.SessionId)
 
 		return nil
 	}
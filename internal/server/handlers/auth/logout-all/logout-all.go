@@ -0,0 +1,55 @@
+package logout_all
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+)
+
+type Logouter interface {
+	LogoutEverywhere(ctx context.Context, userId string) error
+}
+
+type SessionCookie interface {
+	Delete(w http.ResponseWriter)
+}
+
+// New godoc
+//
+//	@Summary		logout user everywhere
+//	@Description	delete all user sessions on all devices and current session cookie
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/auth/logout-all [post]
+func New(logouter Logouter, sessionCookie SessionCookie) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.logout-all.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		err := logouter.LogoutEverywhere(ctx, userId)
+		if err != nil {
+			log.Error("failed to logout everywhere", logger.Err(err))
+			return api.Error("failed to logout everywhere", http.StatusInternalServerError)
+		}
+
+		sessionCookie.Delete(w)
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package logout
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+)
+
+type Logouter interface {
+	Logout(ctx context.Context, sessionId string) error
+}
+
+type SessionCookie interface {
+	SessionId(r *http.Request) (string, bool)
+	Delete(w http.ResponseWriter)
+}
+
+// New godoc
+//
+//	@Summary		logout user
+//	@Description	delete current session and session cookie
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/auth/logout [post]
+func New(logouter Logouter, sessionCookie SessionCookie) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.logout.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		sessionId, ok := sessionCookie.SessionId(r)
+		if !ok {
+			log.Error("failed to get session id")
+			return api.Error("failed to get session id", http.StatusUnauthorized)
+		}
+
+		err := logouter.Logout(ctx, sessionId)
+		if err != nil {
+			if errors.Is(err, errs.ErrSessionNotFound) {
+				log.Error("session not found", logger.Err(err))
+				sessionCookie.Delete(w)
+				return api.Error(errs.ErrSessionNotFound.Error(), http.StatusUnauthorized)
+			}
+			log.Error("failed to logout", logger.Err(err))
+			return api.Error("failed to logout", http.StatusInternalServerError)
+		}
+
+		sessionCookie.Delete(w)
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package magic_link
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type MagicLinkCreator interface {
+	CreateMagicLink(ctx context.Context, email string) (models.User, string, error)
+}
+
+type MagicLinkSender interface {
+	SendMagicLink(to string, token, login string) error
+}
+
+// New godoc
+//
+//	@Summary		request magic link
+//	@Description	send single use login link to user email, old links stop working. Responds the same way if user does not exist or email is not verified.
+//	@Description	Link leads to frontend page MAIL_FRONTEND_URL/auth/magic-link?token=..., which sends token to /auth/magic-link/consume
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			email	body	string	true	"User email"	Format(email)
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		429	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/auth/magic-link [post]
+func New(validator *validator.Validate, magicLinkCreator MagicLinkCreator, magicLinkSender MagicLinkSender) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.magic-link.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		user, token, err := magicLinkCreator.CreateMagicLink(ctx, req.Email)
+		if err != nil {
+			if errors.Is(err, errs.ErrTooManyRequests) {
+				log.Error("magic link is in cooldown", logger.Err(err))
+				return api.Error(errs.ErrTooManyRequests.Error(), http.StatusTooManyRequests)
+			}
+			if errors.Is(err, errs.ErrUserNotFound) || errors.Is(err, errs.ErrEmailNotVerify) {
+				log.Info("magic link for unknown or unverified email requested", logger.Err(err))
+				w.WriteHeader(http.StatusNoContent)
+				return nil
+			}
+			log.Error("failed to create token", logger.Err(err))
+			return api.Error("failed to create token", http.StatusInternalServerError)
+		}
+
+		err = magicLinkSender.SendMagicLink(user.Email, token, user.Login)
+		if err != nil {
+			log.Error("failed to send email", logger.Err(err))
+			return api.Error("failed to send email", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
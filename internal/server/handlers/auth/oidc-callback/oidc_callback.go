@@ -0,0 +1,158 @@
+package oidc_callback
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+type Response struct {
+	TwoFactorToken string `json:"two_factor_token"`
+}
+
+type OIDCService interface {
+	Callback(
+		ctx context.Context,
+		provider, state, code, guestId string,
+		session models.Session,
+	) (models.LoginResult, error)
+}
+
+type SessionCreator interface {
+	Create(w http.ResponseWriter, sessionId string)
+}
+
+type StateCookie interface {
+	Verify(r *http.Request, state string) bool
+	Delete(w http.ResponseWriter)
+}
+
+type GuestCookie interface {
+	GuestId(r *http.Request) (string, bool)
+	Delete(w http.ResponseWriter)
+}
+
+// New godoc
+//
+//	@Summary		complete login with identity provider
+//	@Description	login with code and state from identity provider, state must match state cookie set when login was started.
+//	@Description	Identity is linked to user with the same verified email,
+//	@Description	new user is created if there is no such user. Session is created the same way as password login does.
+//	@Description	Users with two factor authentication get two_factor_token which is sent to /auth/login/2fa with code
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			provider	path	string	true	"Identity provider"
+//	@Param			request		body	Request	true	"Code and state"
+//	@Success		201
+//	@Success		202	{object}	Response
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		403	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		409	{object}	api.ErrorResponse
+//	@Failure		429	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/auth/oidc/{provider}/callback [post]
+func New(
+	validator *validator.Validate,
+	oidcService OIDCService,
+	sessionCreator SessionCreator,
+	guestCookie GuestCookie,
+	stateCookie StateCookie,
+) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.oidc-callback.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		if !stateCookie.Verify(r, req.State) {
+			log.Error("state does not match state cookie")
+			return api.Error(errs.ErrOIDCStateNotFound.Error(), http.StatusUnauthorized)
+		}
+		stateCookie.Delete(w)
+
+		guestId, _ := guestCookie.GuestId(r)
+
+		result, err := oidcService.Callback(ctx, r.PathValue("provider"), req.State, req.Code, guestId, models.Session{
+			IP:        api.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+		if err != nil && !errors.Is(err, errs.ErrFailedToMergeCart) {
+			if errors.Is(err, errs.ErrProviderNotFound) {
+				log.Error("provider not found", logger.Err(err))
+				return api.Error(errs.ErrProviderNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrOIDCStateNotFound) {
+				log.Error("state not found", logger.Err(err))
+				return api.Error(errs.ErrOIDCStateNotFound.Error(), http.StatusUnauthorized)
+			}
+			if errors.Is(err, errs.ErrInvalidIDToken) {
+				log.Error("invalid id token", logger.Err(err))
+				return api.Error(errs.ErrInvalidIDToken.Error(), http.StatusUnauthorized)
+			}
+			if errors.Is(err, errs.ErrTooManyRequests) {
+				log.Error("two factor login is locked", logger.Err(err))
+				return api.Error(errs.ErrTooManyRequests.Error(), http.StatusTooManyRequests)
+			}
+			if errors.Is(err, errs.ErrEmailTooLong) {
+				log.Error("email is too long", logger.Err(err))
+				return api.Error(errs.ErrEmailTooLong.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrEmailNotVerify) {
+				log.Error("email not verified", logger.Err(err))
+				return api.Error(errs.ErrEmailNotVerify.Error(), http.StatusForbidden)
+			}
+			if errors.Is(err, errs.ErrUserAlreadyExists) {
+				log.Error("user already exists", logger.Err(err))
+				return api.Error(errs.ErrUserAlreadyExists.Error(), http.StatusConflict)
+			}
+
+			log.Error("failed to login user", logger.Err(err))
+			return api.Error("failed to login user", http.StatusInternalServerError)
+		}
+
+		if result.TwoFactorToken != "" {
+			render.Status(r, http.StatusAccepted)
+			render.JSON(w, r, Response{
+				TwoFactorToken: result.TwoFactorToken,
+			})
+			return nil
+		}
+
+		if err != nil {
+			log.Error("failed to merge guest cart", logger.Err(err))
+		} else if guestId != "" {
+			guestCookie.Delete(w)
+		}
+
+		sessionCreator.Create(w, result.SessionId)
+
+		return nil
+	}
+}
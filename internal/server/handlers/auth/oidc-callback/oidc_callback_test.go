@@ -0,0 +1,83 @@
+package oidc_callback
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	oidc_callback_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/oidc-callback/__mocks__"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/session"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/signer"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		name        string
+		cookieState string
+		respStatus  int
+	}{
+		{
+			name:        "good case",
+			cookieState: "state",
+			respStatus:  http.StatusCreated,
+		},
+		{
+			name:        "state of other browser",
+			cookieState: "attacker-state",
+			respStatus:  http.StatusUnauthorized,
+		},
+		{
+			name:       "no state cookie",
+			respStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cookieSigner := signer.New("secret")
+			stateCookie := session.NewState("oidc_state", "/auth/oidc", false, http.SameSiteLaxMode, 600, cookieSigner)
+			guestCookie := session.NewGuest("guest_cart_id", false, 600, cookieSigner)
+			sessionCookie := session.New("session_id", true, false, http.SameSiteLaxMode, 600)
+
+			oidcMock := oidc_callback_mocks.NewMockOIDCService(t)
+			oidcMock.EXPECT().Callback(
+				mock.Anything,
+				"test",
+				"state",
+				"code",
+				"",
+				mock.AnythingOfType("models.Session"),
+			).Return(models.LoginResult{SessionId: "session"}, nil).Maybe()
+
+			mux := http.NewServeMux()
+			mux.Handle(
+				"POST /auth/oidc/{provider}/callback",
+				api.ErrorWrapper(New(validator.New(), oidcMock, sessionCookie, guestCookie, stateCookie)),
+			)
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/auth/oidc/test/callback",
+				strings.NewReader(`{"code": "code", "state": "state"}`),
+			)
+			if tt.cookieState != "" {
+				rec := httptest.NewRecorder()
+				stateCookie.Create(rec, tt.cookieState)
+				for _, cookie := range rec.Result().Cookies() {
+					req.AddCookie(cookie)
+				}
+			}
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package oidc_login
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+)
+
+type OIDCService interface {
+	AuthURL(ctx context.Context, provider string) (string, string, error)
+}
+
+type StateCookie interface {
+	Create(w http.ResponseWriter, state string)
+}
+
+// New godoc
+//
+//	@Summary		start login with identity provider
+//	@Description	redirect to identity provider login page, provider redirects back to configured page
+//	@Description	with code and state which are sent to /auth/oidc/{provider}/callback. State is also kept
+//	@Description	in cookie, so login can be completed only in the same browser
+//	@Tags			auth
+//	@Param			provider	path	string	true	"Identity provider"
+//	@Success		302
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/auth/oidc/{provider} [get]
+func New(oidcService OIDCService, stateCookie StateCookie) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.oidc-login.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		authURL, state, err := oidcService.AuthURL(ctx, r.PathValue("provider"))
+		if err != nil {
+			if errors.Is(err, errs.ErrProviderNotFound) {
+				log.Error("provider not found", logger.Err(err))
+				return api.Error(errs.ErrProviderNotFound.Error(), http.StatusNotFound)
+			}
+
+			log.Error("failed to start login", logger.Err(err))
+			return api.Error("failed to start login", http.StatusInternalServerError)
+		}
+
+		stateCookie.Create(w, state)
+		http.Redirect(w, r, authURL, http.StatusFound)
+
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package resend_verification
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type VerificationResender interface {
+	ResendVerification(ctx context.Context, email string) (models.User, string, error)
+}
+
+type VerificationSender interface {
+	SendVerification(to string, token, login string) error
+}
+
+// New godoc
+//
+//	@Summary		resend verification email
+//	@Description	invalidate old verification links and send new one, responds the same way if user does not exist or is verified
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			email	body	string	true	"User email"	Format(email)
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		429	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/auth/resend-verification [post]
+func New(
+	validator *validator.Validate,
+	verificationResender VerificationResender,
+	verificationSender VerificationSender,
+) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.resend-verification.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		user, token, err := verificationResender.ResendVerification(ctx, req.Email)
+		if err != nil {
+			if errors.Is(err, errs.ErrTooManyRequests) {
+				log.Error("verification resend is in cooldown", logger.Err(err))
+				return api.Error(errs.ErrTooManyRequests.Error(), http.StatusTooManyRequests)
+			}
+			if errors.Is(err, errs.ErrUserNotFound) || errors.Is(err, errs.ErrEmailAlreadyVerified) {
+				log.Info("verification resend is not needed", logger.Err(err))
+				w.WriteHeader(http.StatusNoContent)
+				return nil
+			}
+			log.Error("failed to create token", logger.Err(err))
+			return api.Error("failed to create token", http.StatusInternalServerError)
+		}
+
+		err = verificationSender.SendVerification(user.Email, token, user.Login)
+		if err != nil {
+			log.Error("failed to send email", logger.Err(err))
+			return api.Error("failed to send email", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package reset_password
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=3"`
+}
+
+type PasswordResetter interface {
+	ResetPassword(ctx context.Context, token, password string) error
+}
+
+// New godoc
+//
+//	@Summary		reset password
+//	@Description	set new password by password reset token, all user sessions are revoked
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			token		body	string	true	"Password reset token"
+//	@Param			password	body	string	true	"New password"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		410	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/auth/reset-password [post]
+func New(validator *validator.Validate, passwordResetter PasswordResetter) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.auth.reset-password.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		err := passwordResetter.ResetPassword(ctx, req.Token, req.Password)
+		if err != nil {
+			if errors.Is(err, errs.ErrTokenNotFound) {
+				log.Error("token not found", logger.Err(err))
+				return api.Error(errs.ErrTokenNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrTokenExpired) {
+				log.Error("token expired", logger.Err(err))
+				return api.Error(errs.ErrTokenExpired.Error(), http.StatusGone)
+			}
+			if errors.Is(err, errs.ErrTokenUsed) {
+				log.Error("token already used", logger.Err(err))
+				return api.Error(errs.ErrTokenUsed.Error(), http.StatusGone)
+			}
+			log.Error("failed to reset password", logger.Err(err))
+			return api.Error("failed to reset password", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -28,6 +28,7 @@ type TokenService interface {
 //	@Success		204
 //	@Failure		400	{object}	api.ErrorResponse
 //	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		410	{object}	api.ErrorResponse
 //	@Failure		500	{object}	api.ErrorResponse
 //	@Router			/auth/verify/{token} [get]
 func New(tokenService TokenService) api.HandlerFunc {
@@ -48,6 +49,14 @@ func New(tokenService TokenService) api.HandlerFunc {
 				log.Error("token not found", logger.Err(err))
 				return api.Error(errs.ErrTokenNotFound.Error(), http.StatusNotFound)
 			}
+			if errors.Is(err, errs.ErrTokenExpired) {
+				log.Error("token expired", logger.Err(err))
+				return api.Error(errs.ErrTokenExpired.Error(), http.StatusGone)
+			}
+			if errors.Is(err, errs.ErrTokenUsed) {
+				log.Error("token already used", logger.Err(err))
+				return api.Error(errs.ErrTokenUsed.Error(), http.StatusGone)
+			}
 			log.Error("failed to validate token", logger.Err(err))
 			return api.Error("failed to validate token", http.StatusInternalServerError)
 		}
@@ -2,9 +2,11 @@ package cart_add_product
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
 	"github.com/go-chi/render"
@@ -21,19 +23,20 @@ type Response struct {
 
 type ProductAdder interface {
 	AddProduct(ctx context.Context, userId, productId string) (string, error)
+	AddGuestProduct(ctx context.Context, guestId, productId string) error
 }
 
 // New godoc
 //
 //	@Summary		add product to cart
-//	@Description	add product to cart
+//	@Description	add product to cart or increase its quantity, anonymous visitors get guest cart
 //	@Tags			cart
 //	@Accept			json
 //	@Produce		json
 //	@Param			product_id	body		string	true	"product id"
 //	@Success		201			{object}	Response
 //	@Failure		400			{object}	api.ErrorResponse
-//	@Failure		401			{object}	api.ErrorResponse
+//	@Failure		404			{object}	api.ErrorResponse
 //	@Failure		500			{object}	api.ErrorResponse
 //	@Security		SessionAuth
 //	@Router			/cart/add [post]
@@ -55,14 +58,22 @@ func New(validator *validator.Validate, productAdder ProductAdder) api.HandlerFu
 			return api.Error("failed to validate request body", http.StatusBadRequest)
 		}
 
-		userId, ok := ctx.Value("user_id").(string)
-		if !ok {
-			log.Error("failed to get user id")
-			return api.Error("failed to get user id", http.StatusUnauthorized)
+		var cartId string
+		var err error
+		if userId, ok := ctx.Value("user_id").(string); ok {
+			cartId, err = productAdder.AddProduct(ctx, userId, req.ProductId)
+		} else if guestId, ok := ctx.Value("guest_id").(string); ok {
+			cartId = guestId
+			err = productAdder.AddGuestProduct(ctx, guestId, req.ProductId)
+		} else {
+			log.Error("failed to get cart owner")
+			return api.Error("failed to get cart owner", http.StatusUnauthorized)
 		}
-
-		cartId, err := productAdder.AddProduct(ctx, userId, req.ProductId)
 		if err != nil {
+			if errors.Is(err, errs.ErrProductNotFound) {
+				log.Error("product not found", logger.Err(err))
+				return api.Error(errs.ErrProductNotFound.Error(), http.StatusNotFound)
+			}
 			log.Error("failed to add product", logger.Err(err))
 			return api.Error("failed to add product", http.StatusInternalServerError)
 		}
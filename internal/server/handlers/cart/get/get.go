@@ -12,26 +12,53 @@ import (
 )
 
 type Response struct {
-	ID       string        `json:"id"`
-	Price    float32       `json:"price"`
-	Products []productInfo `json:"products"`
+	ID           string           `json:"id"`
+	Subtotal     float32          `json:"subtotal"`
+	Discount     float32          `json:"discount"`
+	Price        float32          `json:"price"`
+	FreeShipping bool             `json:"free_shipping"`
+	Tax          float32          `json:"tax"`
+	Products     []productInfo    `json:"products"`
+	Adjustments  []adjustmentInfo `json:"adjustments"`
+	Taxes        []taxInfo        `json:"taxes"`
+}
+
+type taxInfo struct {
+	TaxClass string  `json:"tax_class"`
+	Rate     int     `json:"rate"`
+	Amount   float32 `json:"amount"`
+}
+
+type adjustmentInfo struct {
+	Source    string  `json:"source"`
+	SourceId  string  `json:"source_id"`
+	Name      string  `json:"name"`
+	ProductId string  `json:"product_id,omitempty"`
+	Amount    float32 `json:"amount"`
 }
 
 type productInfo struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	Price    float32 `json:"price"`
-	ImageUrl string  `json:"image_url"`
+	ID            string  `json:"id"`
+	Slug          string  `json:"slug"`
+	Name          string  `json:"name"`
+	Price         float32 `json:"price"`
+	OriginalPrice float32 `json:"original_price,omitempty"`
+	ImageUrl      string  `json:"image_url"`
+	Quantity      int     `json:"quantity"`
+	TaxClass      string  `json:"tax_class"`
+	Total         float32 `json:"total"`
+	Tax           float32 `json:"tax"`
 }
 
 type CartProvider interface {
 	CartByUserId(ctx context.Context, userId string) (models.Cart, error)
+	CartByGuestId(ctx context.Context, guestId string) (models.Cart, error)
 }
 
 // New godoc
 //
 //	@Summary		returns users cart
-//	@Description	returns users cart
+//	@Description	returns users cart with discounts and VAT included into prices, anonymous visitors get guest cart from signed cookie
 //	@Tags			cart
 //	@Accept			json
 //	@Produce		json
@@ -46,33 +73,68 @@ func New(cartProvider CartProvider) api.HandlerFunc {
 		ctx := r.Context()
 		log := logger.FromCtx(ctx).With(slog.String("op", op))
 
-		userId, ok := ctx.Value("user_id").(string)
-		if !ok {
-			log.Error("failed to get user id")
-			return api.Error("failed to get user id", http.StatusUnauthorized)
+		var cart models.Cart
+		var err error
+		if userId, ok := ctx.Value("user_id").(string); ok {
+			cart, err = cartProvider.CartByUserId(ctx, userId)
+		} else if guestId, ok := ctx.Value("guest_id").(string); ok {
+			cart, err = cartProvider.CartByGuestId(ctx, guestId)
+		} else {
+			log.Error("failed to get cart owner")
+			return api.Error("failed to get cart owner", http.StatusUnauthorized)
 		}
-
-		cart, err := cartProvider.CartByUserId(ctx, userId)
 		if err != nil {
 			log.Error("failed to get users cart", logger.Err(err))
 			return api.Error("failed to get users cart", http.StatusInternalServerError)
 		}
 
-		productsInfo := make([]productInfo, 0, len(cart.Products))
-		for _, product := range cart.Products {
+		productsInfo := make([]productInfo, 0, len(cart.Items))
+		for _, item := range cart.Items {
 			productInfo := productInfo{
-				ID:       product.ID,
-				Name:     product.Name,
-				Price:    product.Price,
-				ImageUrl: product.ImageUrl,
+				ID:            item.Product.ID,
+				Slug:          item.Product.Slug,
+				Name:          item.Product.Name,
+				Price:         item.Product.Price,
+				OriginalPrice: item.Product.OriginalPrice,
+				ImageUrl:      item.Product.ImageUrl,
+				Quantity:      item.Quantity,
+				TaxClass:      item.TaxClass,
+				Total:         item.Total,
+				Tax:           item.Tax,
 			}
 			productsInfo = append(productsInfo, productInfo)
 		}
 
+		adjustmentsInfo := make([]adjustmentInfo, 0, len(cart.Adjustments))
+		for _, adjustment := range cart.Adjustments {
+			adjustmentsInfo = append(adjustmentsInfo, adjustmentInfo{
+				Source:    adjustment.Source,
+				SourceId:  adjustment.SourceId,
+				Name:      adjustment.Name,
+				ProductId: adjustment.ProductId,
+				Amount:    adjustment.Amount,
+			})
+		}
+
+		taxesInfo := make([]taxInfo, 0, len(cart.Taxes))
+		for _, tax := range cart.Taxes {
+			taxesInfo = append(taxesInfo, taxInfo{
+				TaxClass: tax.TaxClass,
+				Rate:     tax.Rate,
+				Amount:   tax.Amount,
+			})
+		}
+
 		render.JSON(w, r, Response{
-			ID:       cart.ID,
-			Price:    cart.Price,
-			Products: productsInfo,
+			ID:           cart.ID,
+			Subtotal:     cart.Subtotal,
+			Discount:     cart.Discount,
+			Price:        cart.Price,
+			FreeShipping: cart.FreeShipping,
+			Tax:          cart.Tax,
+			Products:     productsInfo,
+			Adjustments:  adjustmentsInfo,
+			Taxes:        taxesInfo,
 		})
 
 		return nil
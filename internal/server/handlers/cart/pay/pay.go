@@ -2,41 +2,73 @@ package pay_cart
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/tax"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
 	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
 	"github.com/rvinnie/yookassa-sdk-go/yookassa"
 	yoocommon "github.com/rvinnie/yookassa-sdk-go/yookassa/common"
 	yoopayment "github.com/rvinnie/yookassa-sdk-go/yookassa/payment"
 )
 
+type Request struct {
+	ShippingMethodId string `json:"shipping_method_id" validate:"required,uuid4"`
+	AddressId        string `json:"address_id" validate:"required,uuid4"`
+}
+
 type Response struct {
 	PaymentId   string `josn:"payment_id"`
 	RedirectURL string `json:"redirect_url"`
 }
 
 type CartProvider interface {
-	CartPriceByUserId(ctx context.Context, userId string) (float32, error)
+	CartByUserId(ctx context.Context, userId string) (models.Cart, error)
+	SavePayment(ctx context.Context, payment models.Payment, cart models.Cart) error
+}
+
+type AddressProvider interface {
+	AddressById(ctx context.Context, userId, id string) (models.Address, error)
+}
+
+type ShippingQuoter interface {
+	ShippingOption(ctx context.Context, userId, methodId, region string) (models.ShippingOption, error)
 }
 
 // Pay godoc
 //
-//	@Summary		returns users cart
-//	@Description	returns users cart
+//	@Summary		pay for users cart
+//	@Description	create payment for users cart delivered to chosen address, cost of chosen shipping method is added to the amount, receipt with VAT codes is attached when enabled, applied promo code is reserved until payment is completed or canceled
 //	@Tags			cart
 //	@Accept			json
 //	@Produce		json
-//	@Success		201	{object}	Response
-//	@Failure		401	{object}	api.ErrorResponse
-//	@Failure		500	{object}	api.ErrorResponse
+//	@Param			request	body		Request	true	"shipping"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		401		{object}	api.ErrorResponse
+//	@Failure		404		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
 //	@Security		SessionAuth
 //	@Router			/cart/pay [post]
-func Pay(paymentHandler *yookassa.PaymentHandler, cartProvider CartProvider) api.HandlerFunc {
+func Pay(
+	validator *validator.Validate,
+	paymentHandler *yookassa.PaymentHandler,
+	receipts bool,
+	cartProvider CartProvider,
+	addressProvider AddressProvider,
+	shippingQuoter ShippingQuoter,
+) api.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		const op = "handlers.cart.pay.Pay"
 		ctx := r.Context()
@@ -48,18 +80,67 @@ func Pay(paymentHandler *yookassa.PaymentHandler, cartProvider CartProvider) api
 			return api.Error("failed to get user id", http.StatusUnauthorized)
 		}
 
-		price, err := cartProvider.CartPriceByUserId(ctx, userId)
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		cart, err := cartProvider.CartByUserId(ctx, userId)
+		if err != nil {
+			log.Error("failed to get cart", logger.Err(err))
+			return api.Error("failed to get cart", http.StatusInternalServerError)
+		}
+
+		address, err := addressProvider.AddressById(ctx, userId, req.AddressId)
 		if err != nil {
-			log.Error("failed to get cart price", logger.Err(err))
-			return api.Error("failed to get cart price", http.StatusInternalServerError)
+			if errors.Is(err, errs.ErrAddressNotFound) {
+				log.Error("address not found", logger.Err(err))
+				return api.Error(errs.ErrAddressNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to get address", logger.Err(err))
+			return api.Error("failed to get address", http.StatusInternalServerError)
+		}
+
+		shipping, err := shippingQuoter.ShippingOption(ctx, userId, req.ShippingMethodId, address.City)
+		if err != nil {
+			if errors.Is(err, errs.ErrShippingNotFound) {
+				log.Error("shipping method not found", logger.Err(err))
+				return api.Error(errs.ErrShippingNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrShippingNotAvailable) {
+				log.Error("shipping method is not available", logger.Err(err))
+				return api.Error(errs.ErrShippingNotAvailable.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrCartIsEmpty) {
+				log.Error("cart is empty", logger.Err(err))
+				return api.Error(errs.ErrCartIsEmpty.Error(), http.StatusBadRequest)
+			}
+			log.Error("failed to get shipping price", logger.Err(err))
+			return api.Error("failed to get shipping price", http.StatusInternalServerError)
+		}
+		price := roundFloat(cart.Price+shipping.Price, 2)
+
+		var receipt *yoopayment.Receipt
+		if receipts {
+			receipt = &yoopayment.Receipt{
+				Customer: &yoocommon.Customer{
+					Phone:    strings.TrimPrefix(address.Phone, "+"),
+					FullName: address.Recipient,
+				},
+				Items: receiptItems(cart, shipping),
+			}
 		}
-		price = roundFloat(price, 2)
 
 		payment, err := paymentHandler.CreatePayment(&yoopayment.Payment{
-			Amount: &yoocommon.Amount{
-				Value:    fmt.Sprint(price),
-				Currency: "RUB",
-			},
+			Amount:        amount(price),
+			Receipt:       receipt,
 			PaymentMethod: yoopayment.PaymentTypeBankCard,
 			Confirmation: yoopayment.Redirect{
 				Type:      "redirect",
@@ -75,6 +156,24 @@ func Pay(paymentHandler *yookassa.PaymentHandler, cartProvider CartProvider) api
 			return api.Error("failed to create payment", http.StatusInternalServerError)
 		}
 
+		err = cartProvider.SavePayment(ctx, models.Payment{
+			ID:               payment.ID,
+			UserId:           userId,
+			Amount:           price,
+			ShippingMethodId: shipping.MethodId,
+			ShippingPrice:    shipping.Price,
+			Region:           address.City,
+			Address:          address,
+		}, cart)
+		if err != nil {
+			if errors.Is(err, errs.ErrPromoCodeUsedUp) || errors.Is(err, errs.ErrPromoCodeNotFound) {
+				log.Error("promo code can not be used", logger.Err(err))
+				return api.Error(errs.ErrPromoCodeUsedUp.Error(), http.StatusBadRequest)
+			}
+			log.Error("failed to save payment", logger.Err(err))
+			return api.Error("failed to create payment", http.StatusInternalServerError)
+		}
+
 		render.Status(r, http.StatusCreated)
 		render.JSON(w, r, Response{
 			PaymentId:   payment.ID,
@@ -91,15 +190,20 @@ type YookassaRequest struct {
 }
 
 type object struct {
+	ID       string         `json:"id"`
 	Paid     bool           `json:"paid"`
 	Metadata map[string]any `json:"metadata"`
 }
 
-type CartDeleter interface {
-	DeleteCartByUserId(ctx context.Context, userId string) error
+type OrderCompleter interface {
+	CompleteOrder(ctx context.Context, userId, paymentId string) (string, error)
 }
 
-func Webhook(cartDeleter CartDeleter) api.HandlerFunc {
+type PaymentCanceler interface {
+	CancelPayment(ctx context.Context, paymentId string) error
+}
+
+func Webhook(orderCompleter OrderCompleter, paymentCanceler PaymentCanceler) api.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		const op = "handlers.cart.pay.Pay"
 		ctx := r.Context()
@@ -118,21 +222,87 @@ func Webhook(cartDeleter CartDeleter) api.HandlerFunc {
 				return api.Error("failed to get user id from metadata", http.StatusBadRequest)
 			}
 
-			err := cartDeleter.DeleteCartByUserId(ctx, userId)
+			_, err := orderCompleter.CompleteOrder(ctx, userId, req.Object.ID)
 			if err != nil {
-				log.Error("failed to delete cart", logger.Err(err))
-				return api.Error("failed to delete cart", http.StatusInternalServerError)
+				if errors.Is(err, errs.ErrCartIsEmpty) {
+					log.Info("payment has no items, treated as processed", logger.Err(err))
+					w.WriteHeader(http.StatusOK)
+					return nil
+				}
+				log.Error("failed to complete order", logger.Err(err))
+				return api.Error("failed to complete order", http.StatusInternalServerError)
 			}
+		}
 
-			render.Status(r, http.StatusOK)
+		if req.Event == "payment.canceled" {
+			err := paymentCanceler.CancelPayment(ctx, req.Object.ID)
+			if err != nil {
+				log.Error("failed to cancel payment", logger.Err(err))
+				return api.Error("failed to cancel payment", http.StatusInternalServerError)
+			}
 		}
 
-		render.Status(r, http.StatusInternalServerError)
+		w.WriteHeader(http.StatusOK)
 
 		return nil
 	}
 }
 
+// receiptItems returns receipt lines with VAT codes, sum of lines is equal
+// to payment amount. Item which total can not be split between units equally
+// takes two lines, the last unit gets the remainder
+func receiptItems(cart models.Cart, shipping models.ShippingOption) []*yoocommon.Item {
+	items := make([]*yoocommon.Item, 0, len(cart.Items)+1)
+	for _, cartItem := range cart.Items {
+		total := int64(math.Round(float64(cartItem.Total) * 100))
+		quantity := int64(cartItem.Quantity)
+		unit := total / quantity
+		remainder := total - unit*quantity
+
+		if remainder != 0 && quantity > 1 {
+			items = append(items, receiptItem(cartItem, quantity-1, unit))
+			quantity, unit = 1, unit+remainder
+		}
+		items = append(items, receiptItem(cartItem, quantity, unit))
+	}
+
+	if shipping.Price > 0 {
+		items = append(items, &yoocommon.Item{
+			Description:    "Доставка",
+			Quantity:       "1",
+			Amount:         amount(shipping.Price),
+			VatCode:        tax.VatCode(consts.TaxClassVat20),
+			PaymentMode:    "full_prepayment",
+			PaymentSubject: "service",
+		})
+	}
+
+	return items
+}
+
+func receiptItem(cartItem models.CartItem, quantity, unit int64) *yoocommon.Item {
+	description := []rune(cartItem.Product.Name)
+	if len(description) > 128 {
+		description = description[:128]
+	}
+
+	return &yoocommon.Item{
+		Description:    string(description),
+		Quantity:       strconv.FormatInt(quantity, 10),
+		Amount:         amount(float32(unit) / 100),
+		VatCode:        tax.VatCode(cartItem.TaxClass),
+		PaymentMode:    "full_prepayment",
+		PaymentSubject: "commodity",
+	}
+}
+
+func amount(value float32) *yoocommon.Amount {
+	return &yoocommon.Amount{
+		Value:    fmt.Sprintf("%.2f", value),
+		Currency: "RUB",
+	}
+}
+
 func roundFloat(val float32, precision uint) float32 {
 	ratio := math.Pow(10, float64(precision))
 	return float32(math.Round(float64(val)*ratio) / ratio)
@@ -0,0 +1,76 @@
+package pay_cart
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	pay_cart_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/cart/pay/__mocks__"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       string
+		completeErr error
+		cancelErr   error
+		wantStatus  int
+	}{
+		{
+			name:       "completed case",
+			event:      "payment.waiting_for_capture",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "retried after order created case",
+			event:       "payment.waiting_for_capture",
+			completeErr: fmt.Errorf("services.cart.CompleteOrder: %w", errs.ErrCartIsEmpty),
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "complete failed case",
+			event:       "payment.waiting_for_capture",
+			completeErr: errs.ErrPaymentNotFound,
+			wantStatus:  http.StatusInternalServerError,
+		},
+		{
+			name:       "canceled case",
+			event:      "payment.canceled",
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mCompleter := pay_cart_mocks.NewMockOrderCompleter(t)
+			mCanceler := pay_cart_mocks.NewMockPaymentCanceler(t)
+
+			if tt.event == "payment.waiting_for_capture" {
+				mCompleter.EXPECT().CompleteOrder(
+					mock.Anything,
+					"user",
+					"payment",
+				).Return("order", tt.completeErr).Once()
+			} else {
+				mCanceler.EXPECT().CancelPayment(mock.Anything, "payment").Return(tt.cancelErr).Once()
+			}
+
+			body := fmt.Sprintf(
+				`{"event":%q,"object":{"id":"payment","paid":true,"metadata":{"user_id":"user"}}}`,
+				tt.event,
+			)
+			req := httptest.NewRequest(http.MethodPost, "/cart/pay/webhook", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			api.ErrorWrapper(Webhook(mCompleter, mCanceler))(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Webhook() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package cart_update_product
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	ProductId string `json:"product_id" validate:"required,uuid4"`
+	Quantity  int    `json:"quantity" validate:"min=0"`
+}
+
+type ProductUpdater interface {
+	UpdateProduct(ctx context.Context, userId, productId string, quantity int) error
+	UpdateGuestProduct(ctx context.Context, guestId, productId string, quantity int) error
+}
+
+// New godoc
+//
+//	@Summary		update product quantity in cart
+//	@Description	set product quantity in cart, zero quantity removes product
+//	@Tags			cart
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	Request	true	"product id and quantity"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/cart/update [put]
+func New(validator *validator.Validate, productUpdater ProductUpdater) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.cart.update-product.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", logger.Err(err))
+			return api.Error("failed to decode request body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request body", logger.Err(err))
+			return api.Error("failed to validate request body", http.StatusBadRequest)
+		}
+
+		var err error
+		if userId, ok := ctx.Value("user_id").(string); ok {
+			err = productUpdater.UpdateProduct(ctx, userId, req.ProductId, req.Quantity)
+		} else if guestId, ok := ctx.Value("guest_id").(string); ok {
+			err = productUpdater.UpdateGuestProduct(ctx, guestId, req.ProductId, req.Quantity)
+		} else {
+			log.Error("failed to get cart owner")
+			return api.Error("failed to get cart owner", http.StatusUnauthorized)
+		}
+		if err != nil {
+			if errors.Is(err, errs.ErrWrongQuantity) {
+				log.Error("wrong quantity", logger.Err(err))
+				return api.Error(errs.ErrWrongQuantity.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrProductNotInCart) {
+				log.Error("product is not in cart", logger.Err(err))
+				return api.Error(errs.ErrProductNotInCart.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to update product", logger.Err(err))
+			return api.Error("failed to update product", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,78 @@
+package get_category_by_id
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	ID   string `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type CategoryProvider interface {
+	CategoryByIdOrSlug(ctx context.Context, idOrSlug string) (models.Category, error)
+	CategoryRedirect(ctx context.Context, oldSlug string) (string, error)
+}
+
+// New godoc
+//
+//	@Summary		get category by id or slug
+//	@Description	get category by id or slug, old slugs are redirected to the current one
+//	@Tags			category
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"category id or slug"
+//	@Success		200	{object}	Response
+//	@Success		301
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Router			/category/{id} [get]
+func New(categoryProvider CategoryProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.category.get_by_id.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		idOrSlug := r.PathValue("id")
+
+		category, err := categoryProvider.CategoryByIdOrSlug(ctx, idOrSlug)
+		if err != nil {
+			if !errors.Is(err, errs.ErrCategoryNotFound) {
+				log.Error("failed to get category", logger.Err(err))
+				return api.Error("failed to get category", http.StatusInternalServerError)
+			}
+
+			slug, err := categoryProvider.CategoryRedirect(ctx, idOrSlug)
+			if err != nil {
+				if errors.Is(err, errs.ErrCategoryNotFound) {
+					return api.Error(errs.ErrCategoryNotFound.Error(), http.StatusNotFound)
+				}
+				log.Error("failed to get category redirect", logger.Err(err))
+				return api.Error("failed to get category", http.StatusInternalServerError)
+			}
+
+			redirectURL := url.URL{Path: "/category/" + slug, RawQuery: r.URL.RawQuery}
+			http.Redirect(w, r, redirectURL.String(), http.StatusMovedPermanently)
+			return nil
+		}
+
+		render.JSON(w, r, Response{
+			ID:   category.ID,
+			Slug: category.Slug,
+			Name: category.Name,
+		})
+
+		return nil
+	}
+}
@@ -17,6 +17,7 @@ type CategoryProvider interface {
 
 type category struct {
 	ID   string `json:"id"`
+	Slug string `json:"slug"`
 	Name string `json:"name"`
 }
 
@@ -50,6 +51,7 @@ func New(categoryProvider CategoryProvider) api.HandlerFunc {
 		for _, c := range categoriesInfo {
 			category := category{
 				ID:   c.ID,
+				Slug: c.Slug,
 				Name: c.Name,
 			}
 			categories = append(categories, category)
@@ -0,0 +1,86 @@
+package update_category_slug
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Slug string `json:"slug" validate:"required,max=100"`
+}
+
+type SlugUpdater interface {
+	ChangeCategorySlug(ctx context.Context, id string, slug string) error
+}
+
+// New godoc
+//
+//	@Summary		update category slug
+//	@Description	update category slug, old slug keeps redirecting to the category
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"category id"
+//	@Param			request	body	Request	true	"new slug"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		409	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/update-category-slug/{id} [put]
+func New(validator *validator.Validate, slugUpdater SlugUpdater) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.category.update_slug.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid category id", http.StatusBadRequest)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		err := slugUpdater.ChangeCategorySlug(ctx, id, req.Slug)
+		if err != nil && !errors.Is(err, errs.ErrFailedToCash) {
+			if errors.Is(err, errs.ErrInvalidSlug) {
+				log.Error("invalid slug", logger.Err(err))
+				return api.Error(errs.ErrInvalidSlug.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrCategoryNotFound) {
+				log.Error("category not found", logger.Err(err))
+				return api.Error(errs.ErrCategoryNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrSlugAlreadyExists) {
+				log.Error("slug already exists", logger.Err(err))
+				return api.Error(errs.ErrSlugAlreadyExists.Error(), http.StatusConflict)
+			}
+			log.Error("failed to update slug", logger.Err(err))
+			return api.Error("failed to update slug", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -3,12 +3,15 @@ package create_product
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
 	"github.com/go-chi/render"
@@ -19,7 +22,9 @@ type Request struct {
 	Name        string   `validate:"required,min=3"`
 	Description string   `validate:"required,min=3"`
 	price       float32  `validate:"required,gt=0,lte=1000000"`
+	Weight      int      `validate:"gte=0"`
 	CategoryIds []string `validate:"required"`
+	Attributes  map[string]string
 }
 
 type Response struct {
@@ -33,7 +38,9 @@ type ProductCreator interface {
 		name string,
 		description string,
 		price float32,
+		weight int,
 		image []byte,
+		attributes map[string]string,
 	) (string, error)
 }
 
@@ -47,8 +54,10 @@ type ProductCreator interface {
 //	@Param			name		formData	string	true	"product name"
 //	@Param			description	formData	string	true	"product description"
 //	@Param			price		formData	number	true	"product price"
+//	@Param			weight		formData	int		false	"product weight in grams"
 //	@Param			category_id	formData	string	true	"product category id"
 //	@Param			image		formData	file	true	"product image"
+//	@Param			attributes	formData	string	false	"json object with attribute values by attribute id"
 //	@Success		201			{object}	Response
 //	@Failure		400			{object}	api.ErrorResponse
 //	@Failure		500			{object}	api.ErrorResponse
@@ -68,6 +77,14 @@ func New(validator *validator.Validate, productCreator ProductCreator) api.Handl
 			log.Error("failed convert price", logger.Err(err))
 			return api.Error("failed to get price", http.StatusBadRequest)
 		}
+		var weight int
+		if weightStr := r.FormValue("weight"); weightStr != "" {
+			weight, err = strconv.Atoi(weightStr)
+			if err != nil {
+				log.Error("failed convert weight", logger.Err(err))
+				return api.Error("failed to get weight", http.StatusBadRequest)
+			}
+		}
 		categoryId := r.FormValue("category_id")
 		image, _, err := r.FormFile("image")
 		if err != nil {
@@ -77,11 +94,21 @@ func New(validator *validator.Validate, productCreator ProductCreator) api.Handl
 
 		categoryIds := strings.Split(categoryId, " ")
 
+		attributes := make(map[string]string)
+		if attributesStr := r.FormValue("attributes"); attributesStr != "" {
+			if err = json.Unmarshal([]byte(attributesStr), &attributes); err != nil {
+				log.Error("failed to decode attributes", logger.Err(err))
+				return api.Error("failed to decode attributes", http.StatusBadRequest)
+			}
+		}
+
 		req := Request{
 			Name:        name,
 			Description: description,
 			price:       float32(price),
+			Weight:      weight,
 			CategoryIds: categoryIds,
+			Attributes:  attributes,
 		}
 		if err = validator.Struct(&req); err != nil {
 			log.Error("failed to validate request", logger.Err(err))
@@ -101,9 +128,17 @@ func New(validator *validator.Validate, productCreator ProductCreator) api.Handl
 			req.Name,
 			req.Description,
 			req.price,
+			req.Weight,
 			buf.Bytes(),
+			req.Attributes,
 		)
 		if err != nil {
+			if errors.Is(err, errs.ErrUnknownAttribute) ||
+				errors.Is(err, errs.ErrInvalidAttribute) ||
+				errors.Is(err, errs.ErrMissingAttribute) {
+				log.Error("invalid attributes", logger.Err(err))
+				return api.Error(err.Error(), http.StatusBadRequest)
+			}
 			log.Error("failed to create product", logger.Err(err))
 			return api.Error("failed to create product", http.StatusInternalServerError)
 		}
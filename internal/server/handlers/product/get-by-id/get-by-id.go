@@ -2,75 +2,151 @@ package get_product_by_id
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"net/url"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 )
 
 type Response struct {
-	ID          string     `json:"id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Price       float32    `json:"price"`
-	ImageUrl    string     `json:"image"`
-	Categories  []category `json:"categories"`
+	ID            string      `json:"id"`
+	Slug          string      `json:"slug"`
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	Price         float32     `json:"price"`
+	OriginalPrice float32     `json:"original_price,omitempty"`
+	ImageUrl      string      `json:"image"`
+	Rating        float32     `json:"rating"`
+	ReviewsCount  int         `json:"reviews_count"`
+	IsFavorite    bool        `json:"is_favorite"`
+	Categories    []category  `json:"categories"`
+	Attributes    []attribute `json:"attributes"`
 }
 
 type category struct {
 	ID   string `json:"id"`
+	Slug string `json:"slug"`
 	Name string `json:"name"`
 }
 
+type attribute struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Unit  string `json:"unit,omitempty"`
+	Value string `json:"value"`
+}
+
 type ProductProvider interface {
 	ProductById(ctx context.Context, productId string) (models.Product, error)
+	ProductBySlug(ctx context.Context, slug string) (models.Product, error)
+	ProductRedirect(ctx context.Context, oldSlug string) (string, error)
+}
+
+type FavoriteProvider interface {
+	FavoriteProducts(ctx context.Context, userId string, productIds []string) (map[string]bool, error)
 }
 
 // New godoc
 //
-//	@Summary		get product by id
-//	@Description	get product by id
+//	@Summary		get product by id or slug
+//	@Description	get product by id or slug, old slugs are redirected to the current one
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
-//	@Param			product_id	path		string	true	"product category id"
-//	@Success		200			{object}	Response
-//	@Failure		400			{object}	api.ErrorResponse
-//	@Failure		500			{object}	api.ErrorResponse
+//	@Param			id	path		string	true	"product id or slug"
+//	@Success		200	{object}	Response
+//	@Success		301
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
 //	@Router			/products/{id} [get]
-func New(productProvider ProductProvider) api.HandlerFunc {
+func New(productProvider ProductProvider, favoriteProvider FavoriteProvider) api.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		const op = "handlers.product.get_by_id.New"
 		ctx := r.Context()
 		log := logger.FromCtx(ctx).With(slog.String("op", op))
 
-		productId := r.PathValue("id")
+		idOrSlug := r.PathValue("id")
 
-		product, err := productProvider.ProductById(ctx, productId)
+		var product models.Product
+		var err error
+		if uuid.Validate(idOrSlug) == nil {
+			product, err = productProvider.ProductById(ctx, idOrSlug)
+		} else {
+			product, err = productProvider.ProductBySlug(ctx, idOrSlug)
+		}
 		if err != nil {
-			log.Error("failed to get product", logger.Err(err))
-			return api.Error("failed to get product", http.StatusInternalServerError)
+			if !errors.Is(err, errs.ErrProductNotFound) {
+				log.Error("failed to get product", logger.Err(err))
+				return api.Error("failed to get product", http.StatusInternalServerError)
+			}
+
+			slug, err := productProvider.ProductRedirect(ctx, idOrSlug)
+			if err != nil {
+				if errors.Is(err, errs.ErrProductNotFound) {
+					return api.Error(errs.ErrProductNotFound.Error(), http.StatusNotFound)
+				}
+				log.Error("failed to get product redirect", logger.Err(err))
+				return api.Error("failed to get product", http.StatusInternalServerError)
+			}
+
+			redirectURL := url.URL{Path: "/products/" + slug, RawQuery: r.URL.RawQuery}
+			http.Redirect(w, r, redirectURL.String(), http.StatusMovedPermanently)
+			return nil
+		}
+
+		var isFavorite bool
+		if userId, ok := ctx.Value("user_id").(string); ok {
+			favorites, err := favoriteProvider.FavoriteProducts(ctx, userId, []string{product.ID})
+			if err != nil {
+				log.Error("failed to get favorite products", logger.Err(err))
+			}
+			isFavorite = favorites[product.ID]
 		}
 
 		categories := make([]category, 0, len(product.Categories))
 		for _, categoryItem := range product.Categories {
 			c := category{
 				ID:   categoryItem.ID,
+				Slug: categoryItem.Slug,
 				Name: categoryItem.Name,
 			}
 			categories = append(categories, c)
 		}
 
+		attributes := make([]attribute, 0, len(product.Attributes))
+		for _, attributeItem := range product.Attributes {
+			a := attribute{
+				ID:    attributeItem.AttributeId,
+				Name:  attributeItem.Name,
+				Type:  attributeItem.Type,
+				Unit:  attributeItem.Unit,
+				Value: attributeItem.Value,
+			}
+			attributes = append(attributes, a)
+		}
+
 		render.JSON(w, r, Response{
-			ID:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			ImageUrl:    product.ImageUrl,
-			Categories:  categories,
+			ID:            product.ID,
+			Slug:          product.Slug,
+			Name:          product.Name,
+			Description:   product.Description,
+			Price:         product.Price,
+			OriginalPrice: product.OriginalPrice,
+			ImageUrl:      product.ImageUrl,
+			Rating:        product.Rating,
+			ReviewsCount:  product.ReviewsCount,
+			IsFavorite:    isFavorite,
+			Categories:    categories,
+			Attributes:    attributes,
 		})
 
 		return nil
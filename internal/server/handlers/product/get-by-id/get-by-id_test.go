@@ -0,0 +1,36 @@
+package get_product_by_id
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	get_product_by_id_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/product/get-by-id/__mocks__"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNew_Redirect(t *testing.T) {
+	mProductProvider := get_product_by_id_mocks.NewMockProductProvider(t)
+
+	mProductProvider.EXPECT().ProductBySlug(mock.Anything, "old-slug").
+		Return(models.Product{}, errs.ErrProductNotFound).Once()
+	mProductProvider.EXPECT().ProductRedirect(mock.Anything, "old-slug").
+		Return("new-slug", nil).Once()
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /products/{id}", api.ErrorWrapper(New(mProductProvider, nil)))
+
+	req := httptest.NewRequest(http.MethodGet, "/products/old-slug?utm_source=mail&ref=1", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("New() status = %v, want %v", w.Code, http.StatusMovedPermanently)
+	}
+	if got, want := w.Header().Get("Location"), "/products/new-slug?utm_source=mail&ref=1"; got != want {
+		t.Errorf("New() location = %v, want %v", got, want)
+	}
+}
@@ -2,14 +2,18 @@ package get_product
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 )
 
 type Response struct {
@@ -17,14 +21,28 @@ type Response struct {
 }
 
 type productInfo struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	Price    float32 `json:"price"`
-	ImageUrl string  `json:"image_url"`
+	ID            string  `json:"id"`
+	Slug          string  `json:"slug"`
+	Name          string  `json:"name"`
+	Price         float32 `json:"price"`
+	OriginalPrice float32 `json:"original_price,omitempty"`
+	ImageUrl      string  `json:"image_url"`
+	Rating        float32 `json:"rating"`
+	ReviewsCount  int     `json:"reviews_count"`
+	IsFavorite    bool    `json:"is_favorite"`
 }
 
 type ProductProvider interface {
-	ProductsCard(ctx context.Context, categoryId string, page int) ([]models.ProductCard, error)
+	ProductsCard(
+		ctx context.Context,
+		categoryId string,
+		page int,
+		filters map[string]string,
+	) ([]models.ProductCard, error)
+}
+
+type FavoriteProvider interface {
+	FavoriteProducts(ctx context.Context, userId string, productIds []string) (map[string]bool, error)
 }
 
 // New godoc
@@ -34,13 +52,14 @@ type ProductProvider interface {
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
-//	@Param			category_id	query		string	false	"product category id"
-//	@Param			page		query		int		true	"page for pagination"
+//	@Param			category_id	query		string	false	"product category id or slug"
+//	@Param			page		query		int			true	"page for pagination"
+//	@Param			attribute	query		[]string	false	"attribute filter in form attribute_id:value"	collectionFormat(multi)
 //	@Success		200			{object}	Response
 //	@Failure		400			{object}	api.ErrorResponse
 //	@Failure		500			{object}	api.ErrorResponse
 //	@Router			/products [get]
-func New(productProvider ProductProvider) api.HandlerFunc {
+func New(productProvider ProductProvider, favoriteProvider FavoriteProvider) api.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		const op = "handlers.product.get.New"
 		ctx := r.Context()
@@ -57,20 +76,57 @@ func New(productProvider ProductProvider) api.HandlerFunc {
 			return api.Error("page must be non negative", http.StatusBadRequest)
 		}
 
+		filters := make(map[string]string)
+		for _, filter := range r.URL.Query()["attribute"] {
+			attributeId, value, ok := strings.Cut(filter, ":")
+			if !ok {
+				log.Error("wrong attribute filter format", slog.String("filter", filter))
+				return api.Error("attribute filter must be in form attribute_id:value", http.StatusBadRequest)
+			}
+			if _, err := uuid.Parse(attributeId); err != nil {
+				log.Error("wrong attribute id", slog.String("filter", filter), logger.Err(err))
+				return api.Error("attribute id must be uuid", http.StatusBadRequest)
+			}
+			filters[attributeId] = value
+		}
+
 		categoryId := r.URL.Query().Get("category_id")
-		products, err := productProvider.ProductsCard(ctx, categoryId, page)
+		products, err := productProvider.ProductsCard(ctx, categoryId, page, filters)
 		if err != nil {
+			if errors.Is(err, errs.ErrAttributeNotFound) || errors.Is(err, errs.ErrInvalidAttribute) {
+				log.Error("invalid attribute filter", logger.Err(err))
+				return api.Error("invalid attribute filter", http.StatusBadRequest)
+			}
 			log.Error("failed to get products", logger.Err(err))
 			return api.Error("failed to get products", http.StatusInternalServerError)
 		}
 
+		favorites := make(map[string]bool)
+		if userId, ok := ctx.Value("user_id").(string); ok && len(products) > 0 {
+			productIds := make([]string, 0, len(products))
+			for _, product := range products {
+				productIds = append(productIds, product.ID)
+			}
+
+			favorites, err = favoriteProvider.FavoriteProducts(ctx, userId, productIds)
+			if err != nil {
+				log.Error("failed to get favorite products", logger.Err(err))
+				favorites = make(map[string]bool)
+			}
+		}
+
 		productsInfo := make([]productInfo, 0, len(products))
 		for _, product := range products {
 			productInfo := productInfo{
-				ID:       product.ID,
-				Name:     product.Name,
-				Price:    product.Price,
-				ImageUrl: product.ImageUrl,
+				ID:            product.ID,
+				Slug:          product.Slug,
+				Name:          product.Name,
+				Price:         product.Price,
+				OriginalPrice: product.OriginalPrice,
+				ImageUrl:      product.ImageUrl,
+				Rating:        product.Rating,
+				ReviewsCount:  product.ReviewsCount,
+				IsFavorite:    favorites[product.ID],
 			}
 			productsInfo = append(productsInfo, productInfo)
 		}
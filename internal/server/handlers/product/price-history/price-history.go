@@ -0,0 +1,76 @@
+package product_price_history
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Response struct {
+	History []priceRecord `json:"history"`
+}
+
+type priceRecord struct {
+	Price          float32   `json:"price"`
+	CompareAtPrice float32   `json:"compare_at_price,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type PriceHistoryProvider interface {
+	PriceHistory(ctx context.Context, productId string) ([]models.PriceRecord, error)
+}
+
+// New godoc
+//
+//	@Summary		get product price history
+//	@Description	get all prices product had, newest first
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"product id"
+//	@Success		200	{object}	Response
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/product-price-history/{id} [get]
+func New(validator *validator.Validate, priceHistoryProvider PriceHistoryProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.product.price_history.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid product id", http.StatusBadRequest)
+		}
+
+		records, err := priceHistoryProvider.PriceHistory(ctx, id)
+		if err != nil {
+			log.Error("failed to get price history", logger.Err(err))
+			return api.Error("failed to get price history", http.StatusInternalServerError)
+		}
+
+		history := make([]priceRecord, 0, len(records))
+		for _, record := range records {
+			history = append(history, priceRecord{
+				Price:          record.Price,
+				CompareAtPrice: record.CompareAtPrice,
+				CreatedAt:      record.CreatedAt,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			History: history,
+		})
+
+		return nil
+	}
+}
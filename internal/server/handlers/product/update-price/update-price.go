@@ -0,0 +1,103 @@
+package update_product_price
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Price          float32    `json:"price" validate:"required,gt=0"`
+	CompareAtPrice float32    `json:"compare_at_price" validate:"gte=0"`
+	StartsAt       *time.Time `json:"starts_at"`
+}
+
+type Response struct {
+	PriceChangeId string `json:"price_change_id,omitempty"`
+}
+
+type PriceUpdater interface {
+	UpdatePrice(ctx context.Context, productId string, price float32, compareAtPrice float32) error
+	SchedulePriceChange(
+		ctx context.Context,
+		productId string,
+		price float32,
+		compareAtPrice float32,
+		startsAt time.Time,
+	) (string, error)
+}
+
+// New godoc
+//
+//	@Summary		update product price
+//	@Description	set product price and compare-at price, price is changed at starts_at if it is set, otherwise at once
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"product id"
+//	@Param			request	body		Request	true	"new price"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		404		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/update-product-price/{id} [put]
+func New(validator *validator.Validate, priceUpdater PriceUpdater) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.product.update_price.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid product id", http.StatusBadRequest)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		var changeId string
+		var err error
+		if req.StartsAt != nil {
+			changeId, err = priceUpdater.SchedulePriceChange(ctx, id, req.Price, req.CompareAtPrice, *req.StartsAt)
+		} else {
+			err = priceUpdater.UpdatePrice(ctx, id, req.Price, req.CompareAtPrice)
+		}
+		if err != nil {
+			if errors.Is(err, errs.ErrWrongPrice) {
+				log.Error("wrong price", logger.Err(err))
+				return api.Error(errs.ErrWrongPrice.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrProductNotFound) {
+				log.Error("product not found", logger.Err(err))
+				return api.Error(errs.ErrProductNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to update price", logger.Err(err))
+			return api.Error("failed to update price", http.StatusInternalServerError)
+		}
+
+		render.JSON(w, r, Response{
+			PriceChangeId: changeId,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package update_product_tax_class
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	TaxClass string `json:"tax_class" validate:"omitempty,oneof=vat20 vat10 vat0 no_vat"`
+}
+
+type TaxClassUpdater interface {
+	ChangeProductTaxClass(ctx context.Context, productId string, taxClass string) error
+}
+
+// New godoc
+//
+//	@Summary		update product tax class
+//	@Description	update product own VAT class, empty tax class makes product taxed by class of its categories
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"product id"
+//	@Param			request	body	Request	true	"tax class"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/update-product-tax-class/{id} [put]
+func New(validator *validator.Validate, taxClassUpdater TaxClassUpdater) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.product.update_tax_class.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid product id", http.StatusBadRequest)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		err := taxClassUpdater.ChangeProductTaxClass(ctx, id, req.TaxClass)
+		if err != nil {
+			if errors.Is(err, errs.ErrWrongTaxClass) {
+				log.Error("wrong tax class", logger.Err(err))
+				return api.Error(errs.ErrWrongTaxClass.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrProductNotFound) {
+				log.Error("product not found", logger.Err(err))
+				return api.Error(errs.ErrProductNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to update tax class", logger.Err(err))
+			return api.Error("failed to update tax class", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package change_email
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Email string `json:"email" validate:"required,email,max=50"`
+}
+
+type EmailChanger interface {
+	RequestEmailChange(ctx context.Context, userId, email string) (models.User, error)
+}
+
+type TokenCreator interface {
+	CreateEmailChangeToken(ctx context.Context, userId, email string) (string, error)
+}
+
+type EmailChangeSender interface {
+	SendEmailChange(to string, token, login string) error
+}
+
+// New godoc
+//
+//	@Summary		change email
+//	@Description	send confirmation link to new email, account email is changed after the link is opened
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Param			email	body	string	true	"New email"	Format(email)
+//	@Success		202
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me/email [post]
+func New(
+	validator *validator.Validate,
+	emailChanger EmailChanger,
+	tokenCreator TokenCreator,
+	emailChangeSender EmailChangeSender,
+) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.profile.email.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		user, err := emailChanger.RequestEmailChange(ctx, userId, req.Email)
+		if err != nil {
+			if errors.Is(err, errs.ErrUserAlreadyExists) {
+				log.Error("user already exists", logger.Err(err))
+				return api.Error(errs.ErrUserAlreadyExists.Error(), http.StatusBadRequest)
+			}
+			log.Error("failed to request email change", logger.Err(err))
+			return api.Error("failed to request email change", http.StatusInternalServerError)
+		}
+
+		token, err := tokenCreator.CreateEmailChangeToken(ctx, user.ID, req.Email)
+		if err != nil {
+			log.Error("failed to create token", logger.Err(err))
+			return api.Error("failed to create token", http.StatusInternalServerError)
+		}
+
+		err = emailChangeSender.SendEmailChange(req.Email, token, user.Login)
+		if err != nil {
+			log.Error("failed to send email", logger.Err(err))
+			return api.Error("failed to send email", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+
+		return nil
+	}
+}
@@ -0,0 +1,75 @@
+package get_profile
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	ID              string    `json:"id"`
+	Login           string    `json:"login"`
+	Email           string    `json:"email"`
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	Phone           string    `json:"phone"`
+	Role            string    `json:"role"`
+	IsEmailVerified bool      `json:"is_email_verified"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ProfileProvider interface {
+	Profile(ctx context.Context, userId string) (models.User, error)
+}
+
+// New godoc
+//
+//	@Summary		returns user profile
+//	@Description	returns account data of current user
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	Response
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me [get]
+func New(profileProvider ProfileProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.profile.get.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		user, err := profileProvider.Profile(ctx, userId)
+		if err != nil {
+			log.Error("failed to get profile", logger.Err(err))
+			return api.Error("failed to get profile", http.StatusInternalServerError)
+		}
+
+		render.JSON(w, r, Response{
+			ID:              user.ID,
+			Login:           user.Login,
+			Email:           user.Email,
+			FirstName:       user.FirstName,
+			LastName:        user.LastName,
+			Phone:           user.Phone,
+			Role:            user.Role,
+			IsEmailVerified: user.IsEmailVerified,
+			CreatedAt:       user.CreatedAt,
+		})
+
+		return nil
+	}
+}
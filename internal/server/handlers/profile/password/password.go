@@ -0,0 +1,85 @@
+package change_password
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password" validate:"required,min=3"`
+}
+
+type PasswordChanger interface {
+	ChangeOwnPassword(ctx context.Context, userId, currentPassword, password string) error
+}
+
+type SessionCookie interface {
+	Delete(w http.ResponseWriter)
+}
+
+// New godoc
+//
+//	@Summary		change password
+//	@Description	change password of current user, all user sessions including current are revoked.
+//	@Description	User created with identity provider has no password and sets the first one without current_password
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Param			current_password	body	string	false	"Current password, not needed for the first password"
+//	@Param			password			body	string	true	"New password"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		403	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me/password [put]
+func New(validator *validator.Validate, passwordChanger PasswordChanger, sessionCookie SessionCookie) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.profile.password.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		err := passwordChanger.ChangeOwnPassword(ctx, userId, req.CurrentPassword, req.Password)
+		if err != nil {
+			if errors.Is(err, errs.ErrWrongPassword) {
+				log.Error("wrong password", logger.Err(err))
+				return api.Error(errs.ErrWrongPassword.Error(), http.StatusForbidden)
+			}
+			log.Error("failed to change password", logger.Err(err))
+			return api.Error("failed to change password", http.StatusInternalServerError)
+		}
+
+		sessionCookie.Delete(w)
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package update_profile
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Login     *string `json:"login" validate:"omitnil,min=3,max=50"`
+	FirstName *string `json:"first_name" validate:"omitnil,max=50"`
+	LastName  *string `json:"last_name" validate:"omitnil,max=50"`
+	Phone     *string `json:"phone" validate:"omitnil,max=20"`
+}
+
+type ProfileUpdater interface {
+	UpdateProfile(ctx context.Context, userId string, update models.ProfileUpdate) error
+}
+
+// New godoc
+//
+//	@Summary		update user profile
+//	@Description	update passed fields of current user profile, empty phone removes it
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	Request	true	"profile fields"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me [patch]
+func New(validator *validator.Validate, profileUpdater ProfileUpdater) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.profile.update.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		err := profileUpdater.UpdateProfile(ctx, userId, models.ProfileUpdate{
+			Login:     req.Login,
+			FirstName: req.FirstName,
+			LastName:  req.LastName,
+			Phone:     req.Phone,
+		})
+		if err != nil {
+			if errors.Is(err, errs.ErrInvalidPhone) {
+				log.Error("invalid phone", logger.Err(err))
+				return api.Error(errs.ErrInvalidPhone.Error(), http.StatusBadRequest)
+			}
+			log.Error("failed to update profile", logger.Err(err))
+			return api.Error("failed to update profile", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,97 @@
+package apply_promo_code
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Code string `json:"code" validate:"required,max=50"`
+}
+
+type PromoCodeApplier interface {
+	ApplyPromoCode(ctx context.Context, userId, code string) error
+}
+
+// New godoc
+//
+//	@Summary		apply promo code
+//	@Description	apply promo code to users cart, previously applied promo code is replaced
+//	@Tags			cart
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	Request	true	"promo code"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/cart/promo [post]
+func New(validator *validator.Validate, promoCodeApplier PromoCodeApplier) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.promo.apply.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		err := promoCodeApplier.ApplyPromoCode(ctx, userId, req.Code)
+		if err != nil {
+			if errors.Is(err, errs.ErrPromoCodeNotFound) {
+				log.Error("promo code not found", logger.Err(err))
+				return api.Error(errs.ErrPromoCodeNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrCartIsEmpty) {
+				log.Error("cart is empty", logger.Err(err))
+				return api.Error(errs.ErrCartIsEmpty.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrPromoCodeExpired) {
+				log.Error("promo code is not active", logger.Err(err))
+				return api.Error(errs.ErrPromoCodeExpired.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrPromoCodeUsedUp) {
+				log.Error("promo code is used up", logger.Err(err))
+				return api.Error(errs.ErrPromoCodeUsedUp.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrPromoCodeMinPrice) {
+				log.Error("cart price is too low", logger.Err(err))
+				return api.Error(errs.ErrPromoCodeMinPrice.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrPromoCodeNotApplied) {
+				log.Error("promo code is not applicable", logger.Err(err))
+				return api.Error(errs.ErrPromoCodeNotApplied.Error(), http.StatusBadRequest)
+			}
+			log.Error("failed to apply promo code", logger.Err(err))
+			return api.Error("failed to apply promo code", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,105 @@
+package create_promo_code
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Code         string     `json:"code" validate:"required,alphanum,min=3,max=50"`
+	Type         string     `json:"type" validate:"required,oneof=percent fixed free_shipping"`
+	Value        float32    `json:"value" validate:"gte=0"`
+	MinCartPrice float32    `json:"min_cart_price" validate:"gte=0"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	UsageLimit   int        `json:"usage_limit" validate:"gte=0"`
+	PerUserLimit int        `json:"per_user_limit" validate:"gte=0"`
+	CategoryIds  []string   `json:"category_ids" validate:"dive,uuid4"`
+}
+
+type Response struct {
+	ID string `json:"id"`
+}
+
+type PromoCodeCreator interface {
+	CreatePromoCode(ctx context.Context, promo models.PromoCode) (string, error)
+}
+
+// New godoc
+//
+//	@Summary		create new promo code
+//	@Description	create percent, fixed amount or free shipping promo code, zero limits mean unlimited usage
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		Request	true	"promo code"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		404		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/create-promo-code [post]
+func New(validator *validator.Validate, promoCodeCreator PromoCodeCreator) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.promo.create.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		id, err := promoCodeCreator.CreatePromoCode(ctx, models.PromoCode{
+			Code:         req.Code,
+			Type:         req.Type,
+			Value:        req.Value,
+			MinCartPrice: req.MinCartPrice,
+			StartsAt:     req.StartsAt,
+			EndsAt:       req.EndsAt,
+			UsageLimit:   req.UsageLimit,
+			PerUserLimit: req.PerUserLimit,
+			CategoryIds:  req.CategoryIds,
+		})
+		if err != nil {
+			if errors.Is(err, errs.ErrWrongPromoCode) {
+				log.Error("wrong promo code", logger.Err(err))
+				return api.Error(errs.ErrWrongPromoCode.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrPromoCodeExists) {
+				log.Error("promo code already exists", logger.Err(err))
+				return api.Error(errs.ErrPromoCodeExists.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrCategoryNotFound) {
+				log.Error("category not found", logger.Err(err))
+				return api.Error(errs.ErrCategoryNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to create promo code", logger.Err(err))
+			return api.Error("failed to create promo code", http.StatusInternalServerError)
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			ID: id,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+package delete_promo_code
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-playground/validator/v10"
+)
+
+type PromoCodeDeleter interface {
+	DeletePromoCode(ctx context.Context, id string) error
+}
+
+// New godoc
+//
+//	@Summary		delete promo code
+//	@Description	deactivate promo code, usage history is kept
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"promo code id"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/delete-promo-code/{id} [delete]
+func New(validator *validator.Validate, promoCodeDeleter PromoCodeDeleter) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.promo.delete.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid promo code id", http.StatusBadRequest)
+		}
+
+		err := promoCodeDeleter.DeletePromoCode(ctx, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrPromoCodeNotFound) {
+				log.Error("promo code not found", logger.Err(err))
+				return api.Error(errs.ErrPromoCodeNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to delete promo code", logger.Err(err))
+			return api.Error("failed to delete promo code", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+package get_promo_codes
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	PromoCodes []promoCode `json:"promo_codes"`
+}
+
+type promoCode struct {
+	ID           string     `json:"id"`
+	Code         string     `json:"code"`
+	Type         string     `json:"type"`
+	Value        float32    `json:"value"`
+	MinCartPrice float32    `json:"min_cart_price"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	UsageLimit   int        `json:"usage_limit"`
+	PerUserLimit int        `json:"per_user_limit"`
+	UsedCount    int        `json:"used_count"`
+	IsActive     bool       `json:"is_active"`
+	CategoryIds  []string   `json:"category_ids"`
+}
+
+type PromoCodeProvider interface {
+	PromoCodes(ctx context.Context, page int) ([]models.PromoCode, error)
+}
+
+// New godoc
+//
+//	@Summary		get promo codes
+//	@Description	get all promo codes with usage statistics
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	true	"page for pagination"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/promo-codes [get]
+func New(promoCodeProvider PromoCodeProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.promo.get.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			log.Error("failed to convert page", logger.Err(err))
+			return api.Error("page must be int", http.StatusBadRequest)
+		}
+		if page < 0 {
+			log.Error("page is negative number")
+			return api.Error("page must be non negative", http.StatusBadRequest)
+		}
+
+		promoCodesInfo, err := promoCodeProvider.PromoCodes(ctx, page)
+		if err != nil {
+			log.Error("failed to get promo codes", logger.Err(err))
+			return api.Error("failed to get promo codes", http.StatusInternalServerError)
+		}
+
+		promoCodes := make([]promoCode, 0, len(promoCodesInfo))
+		for _, promoCodeInfo := range promoCodesInfo {
+			promoCodes = append(promoCodes, promoCode{
+				ID:           promoCodeInfo.ID,
+				Code:         promoCodeInfo.Code,
+				Type:         promoCodeInfo.Type,
+				Value:        promoCodeInfo.Value,
+				MinCartPrice: promoCodeInfo.MinCartPrice,
+				StartsAt:     promoCodeInfo.StartsAt,
+				EndsAt:       promoCodeInfo.EndsAt,
+				UsageLimit:   promoCodeInfo.UsageLimit,
+				PerUserLimit: promoCodeInfo.PerUserLimit,
+				UsedCount:    promoCodeInfo.UsedCount,
+				IsActive:     promoCodeInfo.IsActive,
+				CategoryIds:  promoCodeInfo.CategoryIds,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			PromoCodes: promoCodes,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,57 @@
+package remove_promo_code
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+)
+
+type PromoCodeRemover interface {
+	RemovePromoCode(ctx context.Context, userId string) error
+}
+
+// New godoc
+//
+//	@Summary		remove promo code
+//	@Description	remove applied promo code from users cart
+//	@Tags			cart
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/cart/promo [delete]
+func New(promoCodeRemover PromoCodeRemover) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.promo.remove.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		err := promoCodeRemover.RemovePromoCode(ctx, userId)
+		if err != nil {
+			if errors.Is(err, errs.ErrPromoCodeNotFound) {
+				log.Error("promo code not applied", logger.Err(err))
+				return api.Error(errs.ErrPromoCodeNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to remove promo code", logger.Err(err))
+			return api.Error("failed to remove promo code", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
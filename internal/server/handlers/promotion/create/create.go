@@ -0,0 +1,115 @@
+package create_promotion
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Name          string     `json:"name" validate:"required,min=1,max=100"`
+	Type          string     `json:"type" validate:"required,oneof=n_for_m order_percent gift"`
+	Priority      int        `json:"priority"`
+	Stackable     bool       `json:"stackable"`
+	IsActive      bool       `json:"is_active" default:"true"`
+	CategoryId    string     `json:"category_id" validate:"omitempty,uuid4"`
+	ProductId     string     `json:"product_id" validate:"omitempty,uuid4"`
+	GiftProductId string     `json:"gift_product_id" validate:"omitempty,uuid4"`
+	BuyQuantity   int        `json:"buy_quantity" validate:"gte=0"`
+	PayQuantity   int        `json:"pay_quantity" validate:"gte=0"`
+	MinCartPrice  float32    `json:"min_cart_price" validate:"gte=0"`
+	Value         float32    `json:"value" validate:"gte=0"`
+	StartsAt      *time.Time `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+}
+
+type Response struct {
+	ID string `json:"id"`
+}
+
+type PromotionCreator interface {
+	CreatePromotion(ctx context.Context, promotion models.Promotion) (string, error)
+}
+
+// New godoc
+//
+//	@Summary		create new promotion
+//	@Description	create promotion applied to carts automatically: n_for_m uses category and quantities, order_percent uses value and min cart price, gift uses product and gift product. Promotion is active unless is_active is false
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		Request	true	"promotion rule"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		404		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/create-promotion [post]
+func New(validator *validator.Validate, promotionCreator PromotionCreator) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.promotion.create.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		req := Request{IsActive: true}
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		id, err := promotionCreator.CreatePromotion(ctx, models.Promotion{
+			Name:          req.Name,
+			Type:          req.Type,
+			Priority:      req.Priority,
+			Stackable:     req.Stackable,
+			IsActive:      req.IsActive,
+			CategoryId:    req.CategoryId,
+			ProductId:     req.ProductId,
+			GiftProductId: req.GiftProductId,
+			BuyQuantity:   req.BuyQuantity,
+			PayQuantity:   req.PayQuantity,
+			MinCartPrice:  req.MinCartPrice,
+			Value:         req.Value,
+			StartsAt:      req.StartsAt,
+			EndsAt:        req.EndsAt,
+		})
+		if err != nil {
+			if errors.Is(err, errs.ErrWrongPromotion) {
+				log.Error("wrong promotion", logger.Err(err))
+				return api.Error(errs.ErrWrongPromotion.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrCategoryNotFound) {
+				log.Error("category not found", logger.Err(err))
+				return api.Error(errs.ErrCategoryNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrProductNotFound) {
+				log.Error("product not found", logger.Err(err))
+				return api.Error(errs.ErrProductNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to create promotion", logger.Err(err))
+			return api.Error("failed to create promotion", http.StatusInternalServerError)
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			ID: id,
+		})
+
+		return nil
+	}
+}
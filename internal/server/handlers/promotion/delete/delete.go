@@ -0,0 +1,59 @@
+package delete_promotion
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-playground/validator/v10"
+)
+
+type PromotionDeleter interface {
+	DeletePromotion(ctx context.Context, id string) error
+}
+
+// New godoc
+//
+//	@Summary		delete promotion
+//	@Description	delete promotion rule
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"promotion id"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/delete-promotion/{id} [delete]
+func New(validator *validator.Validate, promotionDeleter PromotionDeleter) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.promotion.delete.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid promotion id", http.StatusBadRequest)
+		}
+
+		err := promotionDeleter.DeletePromotion(ctx, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrPromotionNotFound) {
+				log.Error("promotion not found", logger.Err(err))
+				return api.Error(errs.ErrPromotionNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to delete promotion", logger.Err(err))
+			return api.Error("failed to delete promotion", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
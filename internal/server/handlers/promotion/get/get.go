@@ -0,0 +1,104 @@
+package get_promotions
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	Promotions []promotion `json:"promotions"`
+}
+
+type promotion struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Type          string     `json:"type"`
+	Priority      int        `json:"priority"`
+	Stackable     bool       `json:"stackable"`
+	IsActive      bool       `json:"is_active"`
+	CategoryId    string     `json:"category_id,omitempty"`
+	ProductId     string     `json:"product_id,omitempty"`
+	GiftProductId string     `json:"gift_product_id,omitempty"`
+	BuyQuantity   int        `json:"buy_quantity"`
+	PayQuantity   int        `json:"pay_quantity"`
+	MinCartPrice  float32    `json:"min_cart_price"`
+	Value         float32    `json:"value"`
+	StartsAt      *time.Time `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+}
+
+type PromotionProvider interface {
+	Promotions(ctx context.Context, page int) ([]models.Promotion, error)
+}
+
+// New godoc
+//
+//	@Summary		get promotions
+//	@Description	get all promotions ordered by priority
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	true	"page for pagination"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/promotions [get]
+func New(promotionProvider PromotionProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.promotion.get.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			log.Error("failed to convert page", logger.Err(err))
+			return api.Error("page must be int", http.StatusBadRequest)
+		}
+		if page < 0 {
+			log.Error("page is negative number")
+			return api.Error("page must be non negative", http.StatusBadRequest)
+		}
+
+		promotionsInfo, err := promotionProvider.Promotions(ctx, page)
+		if err != nil {
+			log.Error("failed to get promotions", logger.Err(err))
+			return api.Error("failed to get promotions", http.StatusInternalServerError)
+		}
+
+		promotions := make([]promotion, 0, len(promotionsInfo))
+		for _, promotionInfo := range promotionsInfo {
+			promotions = append(promotions, promotion{
+				ID:            promotionInfo.ID,
+				Name:          promotionInfo.Name,
+				Type:          promotionInfo.Type,
+				Priority:      promotionInfo.Priority,
+				Stackable:     promotionInfo.Stackable,
+				IsActive:      promotionInfo.IsActive,
+				CategoryId:    promotionInfo.CategoryId,
+				ProductId:     promotionInfo.ProductId,
+				GiftProductId: promotionInfo.GiftProductId,
+				BuyQuantity:   promotionInfo.BuyQuantity,
+				PayQuantity:   promotionInfo.PayQuantity,
+				MinCartPrice:  promotionInfo.MinCartPrice,
+				Value:         promotionInfo.Value,
+				StartsAt:      promotionInfo.StartsAt,
+				EndsAt:        promotionInfo.EndsAt,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			Promotions: promotions,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package update_promotion
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Name          string     `json:"name" validate:"required,min=1,max=100"`
+	Type          string     `json:"type" validate:"required,oneof=n_for_m order_percent gift"`
+	Priority      int        `json:"priority"`
+	Stackable     bool       `json:"stackable"`
+	IsActive      bool       `json:"is_active"`
+	CategoryId    string     `json:"category_id" validate:"omitempty,uuid4"`
+	ProductId     string     `json:"product_id" validate:"omitempty,uuid4"`
+	GiftProductId string     `json:"gift_product_id" validate:"omitempty,uuid4"`
+	BuyQuantity   int        `json:"buy_quantity" validate:"gte=0"`
+	PayQuantity   int        `json:"pay_quantity" validate:"gte=0"`
+	MinCartPrice  float32    `json:"min_cart_price" validate:"gte=0"`
+	Value         float32    `json:"value" validate:"gte=0"`
+	StartsAt      *time.Time `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+}
+
+type PromotionUpdater interface {
+	UpdatePromotion(ctx context.Context, promotion models.Promotion) error
+}
+
+// New godoc
+//
+//	@Summary		update promotion
+//	@Description	replace promotion rule
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"promotion id"
+//	@Param			request	body	Request	true	"promotion rule"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/update-promotion/{id} [put]
+func New(validator *validator.Validate, promotionUpdater PromotionUpdater) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.promotion.update.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid promotion id", http.StatusBadRequest)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		promotion := models.Promotion{
+			Name:          req.Name,
+			Type:          req.Type,
+			Priority:      req.Priority,
+			Stackable:     req.Stackable,
+			IsActive:      req.IsActive,
+			CategoryId:    req.CategoryId,
+			ProductId:     req.ProductId,
+			GiftProductId: req.GiftProductId,
+			BuyQuantity:   req.BuyQuantity,
+			PayQuantity:   req.PayQuantity,
+			MinCartPrice:  req.MinCartPrice,
+			Value:         req.Value,
+			StartsAt:      req.StartsAt,
+			EndsAt:        req.EndsAt,
+		}
+		promotion.ID = id
+
+		err := promotionUpdater.UpdatePromotion(ctx, promotion)
+		if err != nil {
+			if errors.Is(err, errs.ErrPromotionNotFound) {
+				log.Error("promotion not found", logger.Err(err))
+				return api.Error(errs.ErrPromotionNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrWrongPromotion) {
+				log.Error("wrong promotion", logger.Err(err))
+				return api.Error(errs.ErrWrongPromotion.Error(), http.StatusBadRequest)
+			}
+			if errors.Is(err, errs.ErrCategoryNotFound) {
+				log.Error("category not found", logger.Err(err))
+				return api.Error(errs.ErrCategoryNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrProductNotFound) {
+				log.Error("product not found", logger.Err(err))
+				return api.Error(errs.ErrProductNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to update promotion", logger.Err(err))
+			return api.Error("failed to update promotion", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package create_review
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Text   string `json:"text" validate:"max=2000"`
+}
+
+type Response struct {
+	ID string `json:"id"`
+}
+
+type ReviewCreator interface {
+	CreateReview(ctx context.Context, userId, productId string, rating int, text string) (string, error)
+}
+
+// New godoc
+//
+//	@Summary		create product review
+//	@Description	create review for purchased product, review is shown after moderation
+//	@Tags			reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"product id"
+//	@Param			request	body		Request	true	"review"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		401		{object}	api.ErrorResponse
+//	@Failure		403		{object}	api.ErrorResponse
+//	@Failure		404		{object}	api.ErrorResponse
+//	@Failure		409		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/products/{id}/reviews [post]
+func New(validator *validator.Validate, reviewCreator ReviewCreator) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.review.create.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		productId := r.PathValue("id")
+		if err := validator.Var(productId, "required,uuid4"); err != nil {
+			log.Error("failed to validate product id", logger.Err(err))
+			return api.Error("invalid product id", http.StatusBadRequest)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", logger.Err(err))
+			return api.Error("failed to decode request body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request body", logger.Err(err))
+			return api.Error("failed to validate request body", http.StatusBadRequest)
+		}
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		id, err := reviewCreator.CreateReview(ctx, userId, productId, req.Rating, req.Text)
+		if err != nil {
+			if errors.Is(err, errs.ErrProductNotPurchased) {
+				log.Error("product was not purchased", logger.Err(err))
+				return api.Error(errs.ErrProductNotPurchased.Error(), http.StatusForbidden)
+			}
+			if errors.Is(err, errs.ErrReviewAlreadyExists) {
+				log.Error("review already exists", logger.Err(err))
+				return api.Error(errs.ErrReviewAlreadyExists.Error(), http.StatusConflict)
+			}
+			if errors.Is(err, errs.ErrProductNotFound) {
+				log.Error("product not found", logger.Err(err))
+				return api.Error(errs.ErrProductNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to create review", logger.Err(err))
+			return api.Error("failed to create review", http.StatusInternalServerError)
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			ID: id,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+package get_pending_reviews
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	Reviews []review `json:"reviews"`
+}
+
+type review struct {
+	ID        string    `json:"id"`
+	ProductId string    `json:"product_id"`
+	UserId    string    `json:"user_id"`
+	UserLogin string    `json:"user_login"`
+	Rating    int       `json:"rating"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReviewProvider interface {
+	PendingReviews(ctx context.Context, page int) ([]models.Review, error)
+}
+
+// New godoc
+//
+//	@Summary		get reviews waiting for moderation
+//	@Description	get reviews waiting for moderation
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	true	"page for pagination"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/reviews [get]
+func New(reviewProvider ReviewProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.review.get_pending.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			log.Error("failed to convert page", logger.Err(err))
+			return api.Error("page must be int", http.StatusBadRequest)
+		}
+		if page < 0 {
+			log.Error("page is negative number")
+			return api.Error("page must be non negative", http.StatusBadRequest)
+		}
+
+		reviewsInfo, err := reviewProvider.PendingReviews(ctx, page)
+		if err != nil {
+			log.Error("failed to get reviews", logger.Err(err))
+			return api.Error("failed to get reviews", http.StatusInternalServerError)
+		}
+
+		reviews := make([]review, 0, len(reviewsInfo))
+		for _, reviewInfo := range reviewsInfo {
+			reviews = append(reviews, review{
+				ID:        reviewInfo.ID,
+				ProductId: reviewInfo.ProductId,
+				UserId:    reviewInfo.UserId,
+				UserLogin: reviewInfo.UserLogin,
+				Rating:    reviewInfo.Rating,
+				Text:      reviewInfo.Text,
+				CreatedAt: reviewInfo.CreatedAt,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			Reviews: reviews,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package get_reviews
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Response struct {
+	Reviews []review `json:"reviews"`
+}
+
+type review struct {
+	ID        string    `json:"id"`
+	UserLogin string    `json:"user_login"`
+	Rating    int       `json:"rating"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReviewProvider interface {
+	ReviewsByProductId(ctx context.Context, productId string, page int) ([]models.Review, error)
+}
+
+// New godoc
+//
+//	@Summary		get product reviews
+//	@Description	get approved product reviews, newest first
+//	@Tags			reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"product id"
+//	@Param			page	query		int		true	"page for pagination"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Router			/products/{id}/reviews [get]
+func New(validator *validator.Validate, reviewProvider ReviewProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.review.get.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		productId := r.PathValue("id")
+		if err := validator.Var(productId, "required,uuid4"); err != nil {
+			log.Error("failed to validate product id", logger.Err(err))
+			return api.Error("invalid product id", http.StatusBadRequest)
+		}
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			log.Error("failed to convert page", logger.Err(err))
+			return api.Error("page must be int", http.StatusBadRequest)
+		}
+		if page < 0 {
+			log.Error("page is negative number")
+			return api.Error("page must be non negative", http.StatusBadRequest)
+		}
+
+		reviewsInfo, err := reviewProvider.ReviewsByProductId(ctx, productId, page)
+		if err != nil {
+			log.Error("failed to get reviews", logger.Err(err))
+			return api.Error("failed to get reviews", http.StatusInternalServerError)
+		}
+
+		reviews := make([]review, 0, len(reviewsInfo))
+		for _, reviewInfo := range reviewsInfo {
+			reviews = append(reviews, review{
+				ID:        reviewInfo.ID,
+				UserLogin: reviewInfo.UserLogin,
+				Rating:    reviewInfo.Rating,
+				Text:      reviewInfo.Text,
+				CreatedAt: reviewInfo.CreatedAt,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			Reviews: reviews,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package moderate_review
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Status string `json:"status" validate:"required,oneof=approved rejected"`
+}
+
+type ReviewModerator interface {
+	ModerateReview(ctx context.Context, id string, status string) error
+}
+
+// New godoc
+//
+//	@Summary		moderate review
+//	@Description	approve or reject review, product rating is recalculated from approved reviews
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"review id"
+//	@Param			request	body	Request	true	"new review status"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/moderate-review/{id} [put]
+func New(validator *validator.Validate, reviewModerator ReviewModerator) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.review.moderate.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid review id", http.StatusBadRequest)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		err := reviewModerator.ModerateReview(ctx, id, req.Status)
+		if err != nil {
+			if errors.Is(err, errs.ErrReviewNotFound) {
+				log.Error("review not found", logger.Err(err))
+				return api.Error(errs.ErrReviewNotFound.Error(), http.StatusNotFound)
+			}
+			if errors.Is(err, errs.ErrWrongReviewStatus) {
+				log.Error("wrong review status", logger.Err(err))
+				return api.Error(errs.ErrWrongReviewStatus.Error(), http.StatusBadRequest)
+			}
+			log.Error("failed to moderate review", logger.Err(err))
+			return api.Error("failed to moderate review", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package get_sessions
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	Sessions []session `json:"sessions"`
+}
+
+type session struct {
+	ID         string    `json:"id"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+type SessionProvider interface {
+	Sessions(ctx context.Context, userId, currentSessionId string) ([]models.Session, error)
+}
+
+type SessionCookie interface {
+	SessionId(r *http.Request) (string, bool)
+}
+
+// New godoc
+//
+//	@Summary		returns users sessions
+//	@Description	returns active sessions of user with device metadata, recently used first
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	Response
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me/sessions [get]
+func New(sessionProvider SessionProvider, sessionCookie SessionCookie) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.session.get.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+		sessionId, _ := sessionCookie.SessionId(r)
+
+		sessionsInfo, err := sessionProvider.Sessions(ctx, userId, sessionId)
+		if err != nil {
+			log.Error("failed to get sessions", logger.Err(err))
+			return api.Error("failed to get sessions", http.StatusInternalServerError)
+		}
+
+		sessions := make([]session, 0, len(sessionsInfo))
+		for _, sessionInfo := range sessionsInfo {
+			sessions = append(sessions, session{
+				ID:         sessionInfo.ID,
+				IP:         sessionInfo.IP,
+				UserAgent:  sessionInfo.UserAgent,
+				CreatedAt:  sessionInfo.CreatedAt,
+				LastSeenAt: sessionInfo.LastSeenAt,
+				Current:    sessionInfo.Current,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			Sessions: sessions,
+		})
+
+		return nil
+	}
+}
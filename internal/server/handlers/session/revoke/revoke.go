@@ -0,0 +1,66 @@
+package revoke_session
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-playground/validator/v10"
+)
+
+type SessionRevoker interface {
+	RevokeSession(ctx context.Context, userId, publicId string) error
+}
+
+// New godoc
+//
+//	@Summary		revoke session
+//	@Description	delete one of users sessions, revoked device has to login again
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"session id"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me/sessions/{id} [delete]
+func New(validator *validator.Validate, sessionRevoker SessionRevoker) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.session.revoke.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,hexadecimal,len=32"); err != nil {
+			log.Error("failed to validate session id", logger.Err(err))
+			return api.Error("invalid session id", http.StatusBadRequest)
+		}
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		err := sessionRevoker.RevokeSession(ctx, userId, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrSessionNotFound) {
+				log.Error("session not found", logger.Err(err))
+				return api.Error(errs.ErrSessionNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to revoke session", logger.Err(err))
+			return api.Error("failed to revoke session", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package create_shipping_method
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Name     string `json:"name" validate:"required,min=1,max=100"`
+	Type     string `json:"type" validate:"required,oneof=courier pickup post"`
+	IsActive bool   `json:"is_active"`
+	Rates    []rate `json:"rates" validate:"required,min=1,dive"`
+}
+
+type rate struct {
+	Region    string  `json:"region" validate:"max=100"`
+	MaxWeight int     `json:"max_weight" validate:"gte=0"`
+	Price     float32 `json:"price" validate:"gte=0"`
+	MinDays   int     `json:"min_days" validate:"gte=0"`
+	MaxDays   int     `json:"max_days" validate:"gte=0"`
+}
+
+type Response struct {
+	ID string `json:"id"`
+}
+
+type ShippingMethodCreator interface {
+	CreateMethod(ctx context.Context, method models.ShippingMethod) (string, error)
+}
+
+// New godoc
+//
+//	@Summary		create shipping method
+//	@Description	create shipping method with rate table, empty region matches any region, zero max weight matches any weight
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		Request	true	"shipping method"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/create-shipping-method [post]
+func New(validator *validator.Validate, shippingMethodCreator ShippingMethodCreator) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.shipping.create.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		rates := make([]models.ShippingRate, 0, len(req.Rates))
+		for _, rate := range req.Rates {
+			rates = append(rates, models.ShippingRate{
+				Region:    rate.Region,
+				MaxWeight: rate.MaxWeight,
+				Price:     rate.Price,
+				MinDays:   rate.MinDays,
+				MaxDays:   rate.MaxDays,
+			})
+		}
+
+		id, err := shippingMethodCreator.CreateMethod(ctx, models.ShippingMethod{
+			Name:     req.Name,
+			Type:     req.Type,
+			IsActive: req.IsActive,
+			Rates:    rates,
+		})
+		if err != nil {
+			if errors.Is(err, errs.ErrWrongShippingMethod) {
+				log.Error("wrong shipping method", logger.Err(err))
+				return api.Error(errs.ErrWrongShippingMethod.Error(), http.StatusBadRequest)
+			}
+			log.Error("failed to create shipping method", logger.Err(err))
+			return api.Error("failed to create shipping method", http.StatusInternalServerError)
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			ID: id,
+		})
+
+		return nil
+	}
+}
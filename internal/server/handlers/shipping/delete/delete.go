@@ -0,0 +1,59 @@
+package delete_shipping_method
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-playground/validator/v10"
+)
+
+type ShippingMethodDeleter interface {
+	DeleteMethod(ctx context.Context, id string) error
+}
+
+// New godoc
+//
+//	@Summary		delete shipping method
+//	@Description	delete shipping method with its rates
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"shipping method id"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/delete-shipping-method/{id} [delete]
+func New(validator *validator.Validate, shippingMethodDeleter ShippingMethodDeleter) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.shipping.delete.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		id := r.PathValue("id")
+		if err := validator.Var(id, "required,uuid4"); err != nil {
+			log.Error("failed to validate id", logger.Err(err))
+			return api.Error("invalid shipping method id", http.StatusBadRequest)
+		}
+
+		err := shippingMethodDeleter.DeleteMethod(ctx, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrShippingNotFound) {
+				log.Error("shipping method not found", logger.Err(err))
+				return api.Error(errs.ErrShippingNotFound.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to delete shipping method", logger.Err(err))
+			return api.Error("failed to delete shipping method", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package get_shipping_methods
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	Methods []method `json:"methods"`
+}
+
+type method struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	IsActive bool   `json:"is_active"`
+	Rates    []rate `json:"rates"`
+}
+
+type rate struct {
+	Region    string  `json:"region"`
+	MaxWeight int     `json:"max_weight"`
+	Price     float32 `json:"price"`
+	MinDays   int     `json:"min_days"`
+	MaxDays   int     `json:"max_days"`
+}
+
+type ShippingMethodProvider interface {
+	Methods(ctx context.Context) ([]models.ShippingMethod, error)
+}
+
+// New godoc
+//
+//	@Summary		get shipping methods
+//	@Description	get all shipping methods with rate tables
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	Response
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/admin/shipping-methods [get]
+func New(shippingMethodProvider ShippingMethodProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.shipping.get.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		methodsInfo, err := shippingMethodProvider.Methods(ctx)
+		if err != nil {
+			log.Error("failed to get shipping methods", logger.Err(err))
+			return api.Error("failed to get shipping methods", http.StatusInternalServerError)
+		}
+
+		methods := make([]method, 0, len(methodsInfo))
+		for _, methodInfo := range methodsInfo {
+			rates := make([]rate, 0, len(methodInfo.Rates))
+			for _, rateInfo := range methodInfo.Rates {
+				rates = append(rates, rate{
+					Region:    rateInfo.Region,
+					MaxWeight: rateInfo.MaxWeight,
+					Price:     rateInfo.Price,
+					MinDays:   rateInfo.MinDays,
+					MaxDays:   rateInfo.MaxDays,
+				})
+			}
+
+			methods = append(methods, method{
+				ID:       methodInfo.ID,
+				Name:     methodInfo.Name,
+				Type:     methodInfo.Type,
+				IsActive: methodInfo.IsActive,
+				Rates:    rates,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			Methods: methods,
+		})
+
+		return nil
+	}
+}
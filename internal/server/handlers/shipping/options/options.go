@@ -0,0 +1,121 @@
+package shipping_options
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Response struct {
+	Options []option `json:"options"`
+}
+
+type option struct {
+	MethodId string  `json:"method_id"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Price    float32 `json:"price"`
+	MinDays  int     `json:"min_days"`
+	MaxDays  int     `json:"max_days"`
+}
+
+type AddressProvider interface {
+	AddressById(ctx context.Context, userId, id string) (models.Address, error)
+}
+
+type ShippingQuoter interface {
+	ShippingOptions(ctx context.Context, userId, region string) ([]models.ShippingOption, error)
+}
+
+// New godoc
+//
+//	@Summary		get shipping options
+//	@Description	quote shipping methods which can deliver users cart to saved address or region, address_id takes precedence
+//	@Tags			cart
+//	@Accept			json
+//	@Produce		json
+//	@Param			address_id	query		string	false	"delivery address id"
+//	@Param			region		query		string	false	"delivery region"
+//	@Success		200			{object}	Response
+//	@Failure		400			{object}	api.ErrorResponse
+//	@Failure		401			{object}	api.ErrorResponse
+//	@Failure		404			{object}	api.ErrorResponse
+//	@Failure		500			{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/cart/shipping-options [get]
+func New(
+	validator *validator.Validate,
+	addressProvider AddressProvider,
+	shippingQuoter ShippingQuoter,
+) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.shipping.options.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		region := r.URL.Query().Get("region")
+		if addressId := r.URL.Query().Get("address_id"); addressId != "" {
+			if err := validator.Var(addressId, "uuid4"); err != nil {
+				log.Error("failed to validate address id", logger.Err(err))
+				return api.Error("invalid address id", http.StatusBadRequest)
+			}
+
+			address, err := addressProvider.AddressById(ctx, userId, addressId)
+			if err != nil {
+				if errors.Is(err, errs.ErrAddressNotFound) {
+					log.Error("address not found", logger.Err(err))
+					return api.Error(errs.ErrAddressNotFound.Error(), http.StatusNotFound)
+				}
+				log.Error("failed to get address", logger.Err(err))
+				return api.Error("failed to get address", http.StatusInternalServerError)
+			}
+			region = address.City
+		}
+		if region == "" {
+			log.Error("region is empty")
+			return api.Error("address_id or region is required", http.StatusBadRequest)
+		}
+
+		optionsInfo, err := shippingQuoter.ShippingOptions(ctx, userId, region)
+		if err != nil {
+			if errors.Is(err, errs.ErrCartIsEmpty) {
+				log.Error("cart is empty", logger.Err(err))
+				return api.Error(errs.ErrCartIsEmpty.Error(), http.StatusBadRequest)
+			}
+			log.Error("failed to get shipping options", logger.Err(err))
+			return api.Error("failed to get shipping options", http.StatusInternalServerError)
+		}
+
+		options := make([]option, 0, len(optionsInfo))
+		for _, optionInfo := range optionsInfo {
+			options = append(options, option{
+				MethodId: optionInfo.MethodId,
+				Name:     optionInfo.Name,
+				Type:     optionInfo.Type,
+				Price:    optionInfo.Price,
+				MinDays:  optionInfo.MinDays,
+				MaxDays:  optionInfo.MaxDays,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			Options: options,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+package confirm_two_factor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type Response struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorConfirmer interface {
+	Confirm(ctx context.Context, userId, code string) ([]string, error)
+}
+
+type SessionCookie interface {
+	Delete(w http.ResponseWriter)
+}
+
+// New godoc
+//
+//	@Summary		confirm two factor authentication
+//	@Description	enable two factor authentication with code from authenticator app, recovery codes are shown once.
+//	@Description	All user sessions including current are revoked, next login requires two factor code
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body		string	true	"TOTP code"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		401		{object}	api.ErrorResponse
+//	@Failure		403		{object}	api.ErrorResponse
+//	@Failure		409		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me/2fa/confirm [post]
+func New(validator *validator.Validate, twoFactorConfirmer TwoFactorConfirmer, sessionCookie SessionCookie) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.two-factor.confirm.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		codes, err := twoFactorConfirmer.Confirm(ctx, userId, req.Code)
+		if err != nil {
+			if errors.Is(err, errs.ErrWrongTwoFactorCode) {
+				log.Error("wrong two factor code", logger.Err(err))
+				return api.Error(errs.ErrWrongTwoFactorCode.Error(), http.StatusForbidden)
+			}
+			if errors.Is(err, errs.ErrTwoFactorNotEnrolled) {
+				log.Error("two factor enrolment not started", logger.Err(err))
+				return api.Error(errs.ErrTwoFactorNotEnrolled.Error(), http.StatusConflict)
+			}
+			if errors.Is(err, errs.ErrTwoFactorEnabled) {
+				log.Error("two factor authentication already enabled", logger.Err(err))
+				return api.Error(errs.ErrTwoFactorEnabled.Error(), http.StatusConflict)
+			}
+			log.Error("failed to confirm two factor authentication", logger.Err(err))
+			return api.Error("failed to confirm two factor authentication", http.StatusInternalServerError)
+		}
+
+		sessionCookie.Delete(w)
+		render.JSON(w, r, Response{
+			RecoveryCodes: codes,
+		})
+
+		return nil
+	}
+}
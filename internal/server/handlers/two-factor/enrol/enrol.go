@@ -0,0 +1,67 @@
+package enrol_two_factor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorEnroller interface {
+	Enrol(ctx context.Context, userId string) (string, string, error)
+}
+
+// New godoc
+//
+//	@Summary		enrol two factor authentication
+//	@Description	generate totp secret, uri is shown as qr code for authenticator app. Enrolment is finished with /me/2fa/confirm
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	Response
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		409	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me/2fa [post]
+func New(twoFactorEnroller TwoFactorEnroller) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.two-factor.enrol.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		secret, uri, err := twoFactorEnroller.Enrol(ctx, userId)
+		if err != nil {
+			if errors.Is(err, errs.ErrTwoFactorEnabled) {
+				log.Error("two factor authentication already enabled", logger.Err(err))
+				return api.Error(errs.ErrTwoFactorEnabled.Error(), http.StatusConflict)
+			}
+			log.Error("failed to enrol two factor authentication", logger.Err(err))
+			return api.Error("failed to enrol two factor authentication", http.StatusInternalServerError)
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			Secret: secret,
+			URI:    uri,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,93 @@
+package recovery_codes
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type Response struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type RecoveryCodesGenerator interface {
+	RegenerateRecoveryCodes(ctx context.Context, userId, code string) ([]string, error)
+}
+
+// New godoc
+//
+//	@Summary		regenerate recovery codes
+//	@Description	replace all recovery codes with new ones, old codes stop working
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body		string	true	"TOTP code"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		401		{object}	api.ErrorResponse
+//	@Failure		403		{object}	api.ErrorResponse
+//	@Failure		409		{object}	api.ErrorResponse
+//	@Failure		429		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/me/2fa/recovery-codes [post]
+func New(validator *validator.Validate, recoveryCodesGenerator RecoveryCodesGenerator) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.two-factor.recovery-codes.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode body", logger.Err(err))
+			return api.Error("failed to decode body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request", logger.Err(err))
+			return api.Error("failed to validate request", http.StatusBadRequest)
+		}
+
+		codes, err := recoveryCodesGenerator.RegenerateRecoveryCodes(ctx, userId, req.Code)
+		if err != nil {
+			if errors.Is(err, errs.ErrWrongTwoFactorCode) {
+				log.Error("wrong two factor code", logger.Err(err))
+				return api.Error(errs.ErrWrongTwoFactorCode.Error(), http.StatusForbidden)
+			}
+			if errors.Is(err, errs.ErrTooManyRequests) {
+				log.Error("too many wrong codes", logger.Err(err))
+				return api.Error(errs.ErrTooManyRequests.Error(), http.StatusTooManyRequests)
+			}
+			if errors.Is(err, errs.ErrTwoFactorNotEnabled) {
+				log.Error("two factor authentication not enabled", logger.Err(err))
+				return api.Error(errs.ErrTwoFactorNotEnabled.Error(), http.StatusConflict)
+			}
+			log.Error("failed to regenerate recovery codes", logger.Err(err))
+			return api.Error("failed to regenerate recovery codes", http.StatusInternalServerError)
+		}
+
+		render.JSON(w, r, Response{
+			RecoveryCodes: codes,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package wishlist_delete_product
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-playground/validator/v10"
+)
+
+type ProductDeleter interface {
+	DeleteProduct(ctx context.Context, userId, productId string) error
+}
+
+// New godoc
+//
+//	@Summary		remove product from wishlist
+//	@Description	remove product from wishlist
+//	@Tags			wishlist
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"product id"
+//	@Success		204
+//	@Failure		400	{object}	api.ErrorResponse
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		404	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/wishlist/{id} [delete]
+func New(validator *validator.Validate, productDeleter ProductDeleter) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.wishlist.delete-product.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		productId := r.PathValue("id")
+		if err := validator.Var(productId, "required,uuid4"); err != nil {
+			log.Error("failed to validate product id", logger.Err(err))
+			return api.Error("invalid product id", http.StatusBadRequest)
+		}
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		err := productDeleter.DeleteProduct(ctx, userId, productId)
+		if err != nil {
+			if errors.Is(err, errs.ErrProductNotInWishlist) {
+				log.Error("product is not in wishlist", logger.Err(err))
+				return api.Error(errs.ErrProductNotInWishlist.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to delete product", logger.Err(err))
+			return api.Error("failed to delete product", http.StatusInternalServerError)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package get_wishlist
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+)
+
+type Response struct {
+	Products []productInfo `json:"products"`
+}
+
+type productInfo struct {
+	ID            string  `json:"id"`
+	Slug          string  `json:"slug"`
+	Name          string  `json:"name"`
+	Price         float32 `json:"price"`
+	OriginalPrice float32 `json:"original_price,omitempty"`
+	ImageUrl      string  `json:"image_url"`
+	Rating        float32 `json:"rating"`
+	ReviewsCount  int     `json:"reviews_count"`
+}
+
+type WishlistProvider interface {
+	WishlistByUserId(ctx context.Context, userId string) ([]models.ProductCard, error)
+}
+
+// New godoc
+//
+//	@Summary		returns users wishlist
+//	@Description	returns users wishlist, newest first
+//	@Tags			wishlist
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	Response
+//	@Failure		401	{object}	api.ErrorResponse
+//	@Failure		500	{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/wishlist [get]
+func New(wishlistProvider WishlistProvider) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.wishlist.get.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		products, err := wishlistProvider.WishlistByUserId(ctx, userId)
+		if err != nil {
+			log.Error("failed to get wishlist", logger.Err(err))
+			return api.Error("failed to get wishlist", http.StatusInternalServerError)
+		}
+
+		productsInfo := make([]productInfo, 0, len(products))
+		for _, product := range products {
+			productsInfo = append(productsInfo, productInfo{
+				ID:            product.ID,
+				Slug:          product.Slug,
+				Name:          product.Name,
+				Price:         product.Price,
+				OriginalPrice: product.OriginalPrice,
+				ImageUrl:      product.ImageUrl,
+				Rating:        product.Rating,
+				ReviewsCount:  product.ReviewsCount,
+			})
+		}
+
+		render.JSON(w, r, Response{
+			Products: productsInfo,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+package wishlist_move_to_cart
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type Request struct {
+	ProductId string `json:"product_id" validate:"required,uuid4"`
+}
+
+type Response struct {
+	ID string `json:"id"`
+}
+
+type ProductMover interface {
+	MoveToCart(ctx context.Context, userId, productId string) (string, error)
+}
+
+// New godoc
+//
+//	@Summary		move product from wishlist to cart
+//	@Description	add product from wishlist to cart and remove it from wishlist
+//	@Tags			wishlist
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		Request	true	"product id"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	api.ErrorResponse
+//	@Failure		401		{object}	api.ErrorResponse
+//	@Failure		404		{object}	api.ErrorResponse
+//	@Failure		500		{object}	api.ErrorResponse
+//	@Security		SessionAuth
+//	@Router			/wishlist/move-to-cart [post]
+func New(validator *validator.Validate, productMover ProductMover) api.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		const op = "handlers.wishlist.move-to-cart.New"
+		ctx := r.Context()
+		log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", logger.Err(err))
+			return api.Error("failed to decode request body", http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := validator.Struct(&req); err != nil {
+			log.Error("failed to validate request body", logger.Err(err))
+			return api.Error("failed to validate request body", http.StatusBadRequest)
+		}
+
+		userId, ok := ctx.Value("user_id").(string)
+		if !ok {
+			log.Error("failed to get user id")
+			return api.Error("failed to get user id", http.StatusUnauthorized)
+		}
+
+		cartId, err := productMover.MoveToCart(ctx, userId, req.ProductId)
+		if err != nil {
+			if errors.Is(err, errs.ErrProductNotInWishlist) {
+				log.Error("product is not in wishlist", logger.Err(err))
+				return api.Error(errs.ErrProductNotInWishlist.Error(), http.StatusNotFound)
+			}
+			log.Error("failed to move product to cart", logger.Err(err))
+			return api.Error("failed to move product to cart", http.StatusInternalServerError)
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			ID: cartId,
+		})
+
+		return nil
+	}
+}
@@ -2,35 +2,63 @@ package middlewares
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
 	"net"
 	"net/http"
-	"strings"
+	"strconv"
+	"time"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/config"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
+type GuestCookie interface {
+	GuestId(r *http.Request) (string, bool)
+	Create(w http.ResponseWriter, guestId string)
+}
+
+type SessionCookie interface {
+	SessionId(r *http.Request) (string, bool)
+}
+
+type RateLimiter interface {
+	Hit(ctx context.Context, key string, limit int, window time.Duration) (models.RateLimit, error)
+}
+
 type SessionValidator interface {
 	ValidateAdminSession(ctx context.Context, sessionId string) error
 	ValidateUserSession(ctx context.Context, sessionId string) (string, error)
 }
 
-func Admin(sessionValidator SessionValidator) func(next http.Handler) http.Handler {
+func Admin(sessionValidator SessionValidator, sessionCookie SessionCookie) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			const op = "middlewares.Auth"
 			ctx := r.Context()
 			log := logger.FromCtx(ctx).With(slog.String("op", op))
 
-			session, err := r.Cookie("session_id")
-			if err != nil {
-				log.Error("failed to get session", logger.Err(err))
+			sessionId, ok := sessionCookie.SessionId(r)
+			if !ok {
+				log.Error("failed to get session")
 				w.WriteHeader(http.StatusUnauthorized)
 				return
 			}
 
-			err = sessionValidator.ValidateAdminSession(ctx, session.Value)
+			err := sessionValidator.ValidateAdminSession(ctx, sessionId)
+			if errors.Is(err, errs.ErrTwoFactorRequired) {
+				log.Error("two factor authentication is not enabled", logger.Err(err))
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
 			if err != nil {
 				log.Error("failed to validate session", logger.Err(err))
 				w.WriteHeader(http.StatusUnauthorized)
@@ -42,21 +70,21 @@ func Admin(sessionValidator SessionValidator) func(next http.Handler) http.Handl
 	}
 }
 
-func User(sessionValidator SessionValidator) func(next http.Handler) http.Handler {
+func User(sessionValidator SessionValidator, sessionCookie SessionCookie) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			const op = "middlewares.Auth"
 			ctx := r.Context()
 			log := logger.FromCtx(ctx).With(slog.String("op", op))
 
-			session, err := r.Cookie("session_id")
-			if err != nil {
-				log.Error("failed to get session", logger.Err(err))
+			sessionId, ok := sessionCookie.SessionId(r)
+			if !ok {
+				log.Error("failed to get session")
 				w.WriteHeader(http.StatusUnauthorized)
 				return
 			}
 
-			userId, err := sessionValidator.ValidateUserSession(ctx, session.Value)
+			userId, err := sessionValidator.ValidateUserSession(ctx, sessionId)
 			if err != nil {
 				log.Error("failed to validate session", logger.Err(err))
 				w.WriteHeader(http.StatusUnauthorized)
@@ -71,31 +99,202 @@ func User(sessionValidator SessionValidator) func(next http.Handler) http.Handle
 	}
 }
 
-func IPFilterMiddleware(allowedCIDRs []string) func(next http.Handler) http.Handler {
+// OptionalUser puts user id in context when request has valid session
+// and passes anonymous requests through
+func OptionalUser(sessionValidator SessionValidator, sessionCookie SessionCookie) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			sessionId, ok := sessionCookie.SessionId(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userId, err := sessionValidator.ValidateUserSession(ctx, sessionId)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx = context.WithValue(ctx, "user_id", userId)
+			r = r.WithContext(ctx)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Cart puts user id in context for logged in users, anonymous visitors
+// get guest id from signed cookie which is created on first visit
+func Cart(sessionValidator SessionValidator, sessionCookie SessionCookie, guestCookie GuestCookie) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			remoteIP := r.RemoteAddr
-			log.Printf("Initial remote IP: %s", remoteIP)
-
-			// Проверяем X-Real-IP, если доступен.
-			if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
-				log.Printf("Using X-Real-IP header: %s", realIP)
-				remoteIP = realIP
-			}
-
-			// Разделяем адрес на хост и порт.
-			var host string
-			if strings.Contains(remoteIP, ":") {
-				var err error
-				host, _, err = net.SplitHostPort(remoteIP)
-				if err != nil {
-					http.Error(w, "Invalid remote IP address", http.StatusBadRequest)
+			ctx := r.Context()
+
+			sessionId, ok := sessionCookie.SessionId(r)
+			if ok {
+				userId, err := sessionValidator.ValidateUserSession(ctx, sessionId)
+				if err == nil {
+					ctx = context.WithValue(ctx, "user_id", userId)
+					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
-			} else {
-				host = remoteIP
 			}
 
+			guestId, ok := guestCookie.GuestId(r)
+			if !ok {
+				guestId = uuid.NewString()
+				guestCookie.Create(w, guestId)
+			}
+
+			ctx = context.WithValue(ctx, "guest_id", guestId)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RateLimit limits requests with rule of route from cfg.Routes matched by
+// "METHOD /pattern" then by "/pattern", where pattern is route pattern like
+// /products/{id}, other routes use cfg.Default. Requests are counted per
+// client ip, per user or per route depending on rule key, user is taken from
+// context or valid session and falls back to ip. Limiter failure does not
+// block requests
+func RateLimit(
+	rateLimiter RateLimiter,
+	sessionValidator SessionValidator,
+	sessionCookie SessionCookie,
+	cfg config.RateLimitConfig,
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "middlewares.RateLimit"
+			ctx := r.Context()
+			log := logger.FromCtx(ctx).With(slog.String("op", op))
+
+			if !cfg.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			pattern := routePattern(r)
+			name, rule := rateLimitRule(r.Method, pattern, cfg)
+			key := name + ":" + rateLimitKey(r, rule.Key, pattern, sessionValidator, sessionCookie)
+
+			limit, err := rateLimiter.Hit(ctx, key, rule.Limit, rule.Window)
+			if err != nil {
+				log.Error("failed to hit rate limit", logger.Err(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reset := strconv.Itoa(int(math.Ceil(limit.Reset.Seconds())))
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(limit.Remaining))
+			w.Header().Set("RateLimit-Reset", reset)
+			w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rule.Limit, int(rule.Window.Seconds())))
+
+			if !limit.Allowed {
+				log.Info("rate limit exceeded", slog.String("key", key))
+				w.Header().Set("Retry-After", reset)
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// routePattern returns pattern of route request is routed to,
+// path of request is returned for unknown routes
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return r.URL.Path
+	}
+
+	pattern := rctx.Routes.Find(chi.NewRouteContext(), r.Method, r.URL.Path)
+	if pattern == "" {
+		return r.URL.Path
+	}
+
+	return pattern
+}
+
+// rateLimitRule returns name and rule of route pattern,
+// empty rule fields are taken from default rule
+func rateLimitRule(method, pattern string, cfg config.RateLimitConfig) (string, config.RateLimitRule) {
+	for _, name := range []string{method + " " + pattern, pattern} {
+		rule, ok := cfg.Routes[name]
+		if !ok {
+			continue
+		}
+		if rule.Limit == 0 {
+			rule.Limit = cfg.Default.Limit
+		}
+		if rule.Window == 0 {
+			rule.Window = cfg.Default.Window
+		}
+		if rule.Key == "" {
+			rule.Key = cfg.Default.Key
+		}
+		return name, rule
+	}
+
+	return "default", cfg.Default
+}
+
+// rateLimitKey returns counter key of request, route key includes route
+// pattern so routes limited by default rule do not share one counter
+func rateLimitKey(
+	r *http.Request,
+	key string,
+	pattern string,
+	sessionValidator SessionValidator,
+	sessionCookie SessionCookie,
+) string {
+	switch key {
+	case consts.RateLimitKeyRoute:
+		return "route:" + pattern
+	case consts.RateLimitKeyUser:
+		if userId, ok := r.Context().Value("user_id").(string); ok {
+			return "user:" + userId
+		}
+		if sessionId, ok := sessionCookie.SessionId(r); ok {
+			userId, err := sessionValidator.ValidateUserSession(r.Context(), sessionId)
+			if err == nil {
+				return "user:" + userId
+			}
+		}
+	}
+
+	return "ip:" + api.ClientIP(r)
+}
+
+// RealIP takes client address from X-Real-IP header only if request
+// comes from one of trustedProxies CIDRs or addresses, so clients can not spoof it
+func RealIP(trustedProxies []string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			realIP := net.ParseIP(r.Header.Get("X-Real-IP"))
+			if realIP != nil && isIPAllowed(api.ClientIP(r), trustedProxies) {
+				r = r.WithContext(api.WithClientIP(r.Context(), realIP.String()))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func IPFilterMiddleware(allowedCIDRs []string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Адрес клиента из X-Real-IP учитывается только от доверенных прокси.
+			host := api.ClientIP(r)
+			logger.FromCtx(r.Context()).Debug("checking client ip", slog.String("ip", host))
+
 			// Проверяем, разрешен ли IP-адрес.
 			if !isIPAllowed(host, allowedCIDRs) {
 				http.Error(w, "Forbidden", http.StatusForbidden)
@@ -116,6 +315,13 @@ func isIPAllowed(ip string, allowedCIDRs []string) bool {
 	}
 
 	for _, cidr := range allowedCIDRs {
+		if allowedIP := net.ParseIP(cidr); allowedIP != nil {
+			if allowedIP.Equal(parsedIP) {
+				return true
+			}
+			continue
+		}
+
 		_, allowedNet, err := net.ParseCIDR(cidr)
 		if err != nil {
 			continue
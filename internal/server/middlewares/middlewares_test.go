@@ -0,0 +1,235 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/config"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	middlewares_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/server/middlewares/__mocks__"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Default: config.RateLimitRule{Limit: 100, Window: time.Minute, Key: "ip"},
+		Routes: map[string]config.RateLimitRule{
+			"POST /auth/login": {Limit: 5, Window: 10 * time.Minute},
+			"/me/export":       {Limit: 1, Window: time.Hour, Key: "user"},
+			"/products/{id}":   {Limit: 10, Key: "route"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		sessionId  string
+		sessionErr error
+		wantKey    string
+		wantLimit  int
+		wantWindow time.Duration
+		limit      models.RateLimit
+		wantStatus int
+	}{
+		{
+			name:       "default rule case",
+			method:     http.MethodGet,
+			path:       "/products",
+			wantKey:    "default:ip:192.0.2.1",
+			wantLimit:  100,
+			wantWindow: time.Minute,
+			limit:      models.RateLimit{Allowed: true, Limit: 100, Remaining: 99, Reset: time.Minute},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "route rule case",
+			method:     http.MethodPost,
+			path:       "/auth/login",
+			wantKey:    "POST /auth/login:ip:192.0.2.1",
+			wantLimit:  5,
+			wantWindow: 10 * time.Minute,
+			limit:      models.RateLimit{Allowed: false, Limit: 5, Remaining: 0, Reset: 1500 * time.Millisecond},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "user key case",
+			method:     http.MethodGet,
+			path:       "/me/export",
+			sessionId:  "session",
+			wantKey:    "/me/export:user:user",
+			wantLimit:  1,
+			wantWindow: time.Hour,
+			limit:      models.RateLimit{Allowed: true, Limit: 1, Remaining: 0, Reset: time.Hour},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid session case",
+			method:     http.MethodGet,
+			path:       "/me/export",
+			sessionId:  "fake",
+			sessionErr: errs.ErrSessionNotFound,
+			wantKey:    "/me/export:ip:192.0.2.1",
+			wantLimit:  1,
+			wantWindow: time.Hour,
+			limit:      models.RateLimit{Allowed: true, Limit: 1, Remaining: 0, Reset: time.Hour},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "route pattern case",
+			method:     http.MethodGet,
+			path:       "/products/42",
+			wantKey:    "/products/{id}:route:/products/{id}",
+			wantLimit:  10,
+			wantWindow: time.Minute,
+			limit:      models.RateLimit{Allowed: true, Limit: 10, Remaining: 9, Reset: time.Minute},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mLimiter := middlewares_mocks.NewMockRateLimiter(t)
+			mCookie := middlewares_mocks.NewMockSessionCookie(t)
+			mValidator := middlewares_mocks.NewMockSessionValidator(t)
+
+			mLimiter.EXPECT().Hit(
+				mock.Anything,
+				tt.wantKey,
+				tt.wantLimit,
+				tt.wantWindow,
+			).Return(tt.limit, nil).Once()
+
+			if tt.sessionId != "" {
+				mCookie.EXPECT().SessionId(mock.Anything).Return(tt.sessionId, true).Once()
+				mValidator.EXPECT().ValidateUserSession(mock.Anything, tt.sessionId).Return("user", tt.sessionErr).Once()
+			}
+
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			r.RemoteAddr = "192.0.2.1:1234"
+			w := httptest.NewRecorder()
+
+			next := func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}
+			router := chi.NewRouter()
+			router.Use(RateLimit(mLimiter, mValidator, mCookie, cfg))
+			router.Get("/products", next)
+			router.Get("/products/{id}", next)
+			router.Post("/auth/login", next)
+			router.Get("/me/export", next)
+			router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.NotEmpty(t, w.Header().Get("RateLimit-Limit"))
+			require.NotEmpty(t, w.Header().Get("RateLimit-Reset"))
+			if tt.wantStatus == http.StatusTooManyRequests {
+				require.Equal(t, "2", w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestRealIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		realIP     string
+		want       string
+	}{
+		{
+			name:       "trusted proxy",
+			remoteAddr: "10.0.0.2:1234",
+			realIP:     "1.2.3.4",
+			want:       "1.2.3.4",
+		},
+		{
+			name:       "untrusted client",
+			remoteAddr: "5.6.7.8:1234",
+			realIP:     "1.2.3.4",
+			want:       "5.6.7.8",
+		},
+		{
+			name:       "invalid header",
+			remoteAddr: "10.0.0.2:1234",
+			realIP:     "not ip",
+			want:       "10.0.0.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := RealIP([]string{"10.0.0.0/8"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = api.ClientIP(r)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Real-IP", tt.realIP)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("ClientIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPFilterMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		realIP     string
+		wantStatus int
+	}{
+		{
+			name:       "allowed client through trusted proxy",
+			remoteAddr: "10.0.0.2:1234",
+			realIP:     "185.71.76.1",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "allowed single address through trusted proxy",
+			remoteAddr: "10.0.0.2:1234",
+			realIP:     "77.75.156.11",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "other client through trusted proxy",
+			remoteAddr: "10.0.0.2:1234",
+			realIP:     "1.2.3.4",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "spoofed header from untrusted client",
+			remoteAddr: "1.2.3.4:1234",
+			realIP:     "185.71.76.1",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RealIP([]string{"10.0.0.0/8"})(
+				IPFilterMiddleware([]string{"185.71.76.0/27", "77.75.156.11"})(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+				),
+			)
+
+			req := httptest.NewRequest(http.MethodPost, "/pay/webhook", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Real-IP", tt.realIP)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("IPFilterMiddleware() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -3,27 +3,92 @@ package server
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"net/http"
+	"time"
 
 	_ "github.com/AlexMickh/coledzh-shop-backend/docs"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/config"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/email"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	cancel_deletion "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/account/cancel-deletion"
+	delete_account "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/account/delete"
+	export_account "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/account/export"
+	create_address "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/address/create"
+	delete_address "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/address/delete"
+	get_addresses "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/address/get"
+	update_address "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/address/update"
+	create_attribute "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/attribute/create"
+	delete_attribute "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/attribute/delete"
+	get_attributes "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/attribute/get"
+	update_attribute "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/attribute/update"
+	confirm_email "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/confirm-email"
+	consume_magic_link "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/consume-magic-link"
+	forgot_password "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/forgot-password"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/login"
+	login_2fa "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/login-2fa"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/logout"
+	logout_all "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/logout-all"
+	magic_link "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/magic-link"
+	oidc_callback "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/oidc-callback"
+	oidc_login "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/oidc-login"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/register"
+	resend_verification "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/resend-verification"
+	reset_password "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/reset-password"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/auth/verify"
 	cart_add_product "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/cart/add-product"
 	get_cart "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/cart/get"
 	pay_cart "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/cart/pay"
+	cart_update_product "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/cart/update-product"
 	create_category "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/category/create"
 	get_category "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/category/get"
+	get_category_by_id "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/category/get-by-id"
+	update_category_slug "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/category/update-slug"
+	update_category_tax_class "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/category/update-tax-class"
 	create_product "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/product/create"
 	get_product "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/product/get"
 	get_product_by_id "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/product/get-by-id"
+	product_price_history "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/product/price-history"
+	update_product_price "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/product/update-price"
+	update_product_slug "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/product/update-slug"
+	update_product_tax_class "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/product/update-tax-class"
+	change_email "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/profile/email"
+	get_profile "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/profile/get"
+	change_password "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/profile/password"
+	update_profile "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/profile/update"
+	apply_promo_code "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/promo/apply"
+	create_promo_code "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/promo/create"
+	delete_promo_code "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/promo/delete"
+	get_promo_codes "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/promo/get"
+	remove_promo_code "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/promo/remove"
+	create_promotion "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/promotion/create"
+	delete_promotion "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/promotion/delete"
+	get_promotions "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/promotion/get"
+	update_promotion "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/promotion/update"
+	create_review "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/review/create"
+	get_reviews "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/review/get"
+	get_pending_reviews "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/review/get-pending"
+	moderate_review "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/review/moderate"
+	get_sessions "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/session/get"
+	revoke_session "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/session/revoke"
+	create_shipping_method "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/shipping/create"
+	delete_shipping_method "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/shipping/delete"
+	get_shipping_methods "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/shipping/get"
+	shipping_options "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/shipping/options"
+	confirm_two_factor "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/two-factor/confirm"
+	disable_two_factor "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/two-factor/disable"
+	enrol_two_factor "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/two-factor/enrol"
+	recovery_codes "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/two-factor/recovery-codes"
+	wishlist_add_product "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/wishlist/add-product"
+	wishlist_delete_product "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/wishlist/delete-product"
+	get_wishlist "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/wishlist/get"
+	wishlist_move_to_cart "github.com/AlexMickh/coledzh-shop-backend/internal/server/handlers/wishlist/move-to-cart"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/server/middlewares"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/api"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
 	"github.com/AlexMickh/coledzh-shop-backend/pkg/session"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/signer"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-playground/validator/v10"
@@ -39,22 +104,43 @@ type Server struct {
 
 type AuthService interface {
 	Register(ctx context.Context, login, email, password string) (string, error)
-	Login(ctx context.Context, email, password string) (string, error)
+	Login(ctx context.Context, email, password, guestId string, session models.Session) (models.LoginResult, error)
+	LoginTwoFactor(ctx context.Context, token, code string) (string, error)
+	Logout(ctx context.Context, sessionId string) error
+	LogoutEverywhere(ctx context.Context, userId string) error
+	UserByEmail(ctx context.Context, email string) (models.User, error)
+	ChangeOwnPassword(ctx context.Context, userId, currentPassword, password string) error
+	RequestEmailChange(ctx context.Context, userId, email string) (models.User, error)
 }
 
 type TokenService interface {
 	CreateToken(ctx context.Context, userId, tokenType string) (string, error)
 	VerifyEmail(ctx context.Context, token string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	CreatePasswordResetToken(ctx context.Context, email string) (models.User, string, error)
+	ResendVerification(ctx context.Context, email string) (models.User, string, error)
+	ConfirmEmail(ctx context.Context, token string) (string, string, error)
+	CreateEmailChangeToken(ctx context.Context, userId, email string) (string, error)
+	CreateMagicLink(ctx context.Context, email string) (models.User, string, error)
+	LoginByMagicLink(ctx context.Context, token, guestId string, session models.Session) (models.LoginResult, error)
 }
 
 type CategoryService interface {
 	CreateCategory(ctx context.Context, name string) (string, error)
 	AllCategories(ctx context.Context) ([]models.Category, error)
+	CategoryByIdOrSlug(ctx context.Context, idOrSlug string) (models.Category, error)
+	CategoryRedirect(ctx context.Context, oldSlug string) (string, error)
+	ChangeCategorySlug(ctx context.Context, id string, slug string) error
+	ChangeCategoryTaxClass(ctx context.Context, id string, taxClass string) error
 }
 
 type UserService interface {
 	ValidateAdminSession(ctx context.Context, sessionId string) error
 	ValidateUserSession(ctx context.Context, sessionId string) (string, error)
+	Sessions(ctx context.Context, userId, currentSessionId string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userId, publicId string) error
+	Profile(ctx context.Context, userId string) (models.User, error)
+	UpdateProfile(ctx context.Context, userId string, update models.ProfileUpdate) error
 }
 
 type ProductService interface {
@@ -64,17 +150,137 @@ type ProductService interface {
 		name string,
 		description string,
 		price float32,
+		weight int,
 		image []byte,
+		attributes map[string]string,
 	) (string, error)
-	ProductsCard(ctx context.Context, categoryId string, page int) ([]models.ProductCard, error)
+	ProductsCard(
+		ctx context.Context,
+		categoryId string,
+		page int,
+		filters map[string]string,
+	) ([]models.ProductCard, error)
 	ProductById(ctx context.Context, productId string) (models.Product, error)
+	ProductBySlug(ctx context.Context, slug string) (models.Product, error)
+	ProductRedirect(ctx context.Context, oldSlug string) (string, error)
+	ChangeProductSlug(ctx context.Context, productId string, slug string) error
+	ChangeProductTaxClass(ctx context.Context, productId string, taxClass string) error
+	UpdatePrice(ctx context.Context, productId string, price float32, compareAtPrice float32) error
+	SchedulePriceChange(
+		ctx context.Context,
+		productId string,
+		price float32,
+		compareAtPrice float32,
+		startsAt time.Time,
+	) (string, error)
+	PriceHistory(ctx context.Context, productId string) ([]models.PriceRecord, error)
+}
+
+type AttributeService interface {
+	CreateAttribute(
+		ctx context.Context,
+		categoryId string,
+		name string,
+		attributeType string,
+		unit string,
+		isRequired bool,
+	) (string, error)
+	UpdateAttribute(
+		ctx context.Context,
+		id string,
+		name string,
+		attributeType string,
+		unit string,
+		isRequired bool,
+	) error
+	DeleteAttribute(ctx context.Context, id string) error
+	AttributesByCategoryId(ctx context.Context, categoryId string) ([]models.Attribute, error)
+}
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, userId, productId string, rating int, text string) (string, error)
+	ReviewsByProductId(ctx context.Context, productId string, page int) ([]models.Review, error)
+	PendingReviews(ctx context.Context, page int) ([]models.Review, error)
+	ModerateReview(ctx context.Context, id string, status string) error
+}
+
+type WishlistService interface {
+	AddProduct(ctx context.Context, userId, productId string) error
+	DeleteProduct(ctx context.Context, userId, productId string) error
+	WishlistByUserId(ctx context.Context, userId string) ([]models.ProductCard, error)
+	MoveToCart(ctx context.Context, userId, productId string) (string, error)
+	FavoriteProducts(ctx context.Context, userId string, productIds []string) (map[string]bool, error)
 }
 
 type CartService interface {
 	AddProduct(ctx context.Context, userId, productId string) (string, error)
+	UpdateProduct(ctx context.Context, userId, productId string, quantity int) error
+	AddGuestProduct(ctx context.Context, guestId, productId string) error
+	UpdateGuestProduct(ctx context.Context, guestId, productId string, quantity int) error
 	CartByUserId(ctx context.Context, userId string) (models.Cart, error)
+	CartByGuestId(ctx context.Context, guestId string) (models.Cart, error)
 	CartPriceByUserId(ctx context.Context, userId string) (float32, error)
 	DeleteCartByUserId(ctx context.Context, userId string) error
+	SavePayment(ctx context.Context, payment models.Payment, cart models.Cart) error
+	CompleteOrder(ctx context.Context, userId, paymentId string) (string, error)
+	CancelPayment(ctx context.Context, paymentId string) error
+}
+
+type PromoService interface {
+	CreatePromoCode(ctx context.Context, promo models.PromoCode) (string, error)
+	PromoCodes(ctx context.Context, page int) ([]models.PromoCode, error)
+	DeletePromoCode(ctx context.Context, id string) error
+	ApplyPromoCode(ctx context.Context, userId, code string) error
+	RemovePromoCode(ctx context.Context, userId string) error
+}
+
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, promotion models.Promotion) (string, error)
+	UpdatePromotion(ctx context.Context, promotion models.Promotion) error
+	DeletePromotion(ctx context.Context, id string) error
+	Promotions(ctx context.Context, page int) ([]models.Promotion, error)
+}
+
+type ShippingService interface {
+	CreateMethod(ctx context.Context, method models.ShippingMethod) (string, error)
+	Methods(ctx context.Context) ([]models.ShippingMethod, error)
+	DeleteMethod(ctx context.Context, id string) error
+	ShippingOptions(ctx context.Context, userId, region string) ([]models.ShippingOption, error)
+	ShippingOption(ctx context.Context, userId, methodId, region string) (models.ShippingOption, error)
+}
+
+type RateLimiter interface {
+	Hit(ctx context.Context, key string, limit int, window time.Duration) (models.RateLimit, error)
+}
+
+type AccountService interface {
+	Export(ctx context.Context, userId string) (models.AccountExport, error)
+	DeleteAccount(ctx context.Context, userId string) (time.Time, error)
+	CancelDeletion(ctx context.Context, userId string) error
+}
+
+type TwoFactorService interface {
+	Enrol(ctx context.Context, userId string) (string, string, error)
+	Confirm(ctx context.Context, userId, code string) ([]string, error)
+	RegenerateRecoveryCodes(ctx context.Context, userId, code string) ([]string, error)
+	Disable(ctx context.Context, userId, code string) error
+}
+
+type OIDCService interface {
+	AuthURL(ctx context.Context, provider string) (string, string, error)
+	Callback(
+		ctx context.Context,
+		provider, state, code, guestId string,
+		session models.Session,
+	) (models.LoginResult, error)
+}
+
+type AddressService interface {
+	CreateAddress(ctx context.Context, address models.Address) (string, error)
+	UpdateAddress(ctx context.Context, address models.Address) error
+	DeleteAddress(ctx context.Context, userId, id string) error
+	AddressesByUserId(ctx context.Context, userId string) ([]models.Address, error)
+	AddressById(ctx context.Context, userId, id string) (models.Address, error)
 }
 
 // @title						Your API
@@ -94,6 +300,20 @@ func New(
 	productService ProductService,
 	cartService CartService,
 	yookassaConfig config.YookassaConfig,
+	storageCfg config.StorageConfig,
+	attributeService AttributeService,
+	reviewService ReviewService,
+	wishlistService WishlistService,
+	cartCfg config.CartConfig,
+	promoService PromoService,
+	promotionService PromotionService,
+	shippingService ShippingService,
+	addressService AddressService,
+	accountService AccountService,
+	twoFactorService TwoFactorService,
+	oidcCfg config.OIDCConfig,
+	oidcService OIDCService,
+	rateLimiter RateLimiter,
 ) (*Server, error) {
 	const op = "server.New"
 
@@ -101,7 +321,23 @@ func New(
 
 	validator := validator.New()
 	email := email.New(mailCfg)
-	session := session.New("session_id", true, false, 60*60*24*5)
+	cookieSigner := signer.New(cartCfg.CookieSecret)
+	guestCookie := session.NewGuest("guest_cart_id", cfg.Session.Secure, int(cartCfg.GuestExpire.Seconds()), cookieSigner)
+	oidcStateCookie := session.NewState(
+		"oidc_state",
+		"/auth/oidc",
+		cfg.Session.Secure,
+		session.ParseSameSite(cfg.Session.SameSite),
+		int(oidcCfg.StateTTL.Seconds()),
+		cookieSigner,
+	)
+	session := session.New(
+		cfg.Session.CookieName,
+		true,
+		cfg.Session.Secure,
+		session.ParseSameSite(cfg.Session.SameSite),
+		int(cfg.Session.MaxAge.Seconds()),
+	)
 
 	yooClient := yookassa.NewClient(yookassaConfig.ShopId, yookassaConfig.SecretKey)
 	settingsHandler := yookassa.NewSettingsHandler(yooClient)
@@ -131,9 +367,18 @@ func New(
 		AllowCredentials: true,
 	}).Handler)
 
+	if len(cfg.TrustedProxies) == 0 {
+		logger.FromCtx(ctx).Warn(
+			"no trusted proxies are set, X-Real-IP is ignored: behind a reverse proxy payment webhooks " +
+				"are rejected and all clients share proxy address, set SERVER_TRUSTED_PROXIES",
+		)
+	}
+
 	r.Use(middleware.RequestID)
+	r.Use(middlewares.RealIP(cfg.TrustedProxies))
 	r.Use(logger.ChiMiddleware(ctx))
 	r.Use(middleware.Recoverer)
+	r.Use(middlewares.RateLimit(rateLimiter, userService, session, cfg.RateLimit))
 	// r.Use(middleware.URLFormat)
 
 	r.Get("/swagger/*", httpSwagger.Handler(
@@ -146,37 +391,122 @@ func New(
 		return nil
 	}))
 
+	if storageCfg.Type == consts.StorageTypeLocal {
+		r.Handle("/images/*", http.StripPrefix("/images/", http.FileServer(filesOnly{http.Dir(storageCfg.LocalPath)})))
+	}
+
 	r.Route("/auth", func(r chi.Router) {
 		r.Post("/register", api.ErrorWrapper(register.New(validator, authService, tokenService, email)))
-		r.Post("/login", api.ErrorWrapper(login.New(authService, *validator, session)))
+		r.Post("/login", api.ErrorWrapper(login.New(authService, *validator, session, guestCookie, email)))
+		r.Post("/login/2fa", api.ErrorWrapper(login_2fa.New(validator, authService, session, guestCookie)))
+		r.Post("/magic-link", api.ErrorWrapper(magic_link.New(validator, tokenService, email)))
+		r.Post("/magic-link/consume", api.ErrorWrapper(consume_magic_link.New(validator, tokenService, session, guestCookie)))
+		r.Get("/oidc/{provider}", api.ErrorWrapper(oidc_login.New(oidcService, oidcStateCookie)))
+		r.Post("/oidc/{provider}/callback", api.ErrorWrapper(oidc_callback.New(validator, oidcService, session, guestCookie, oidcStateCookie)))
 		r.Get("/verify/{token}", api.ErrorWrapper(verify.New(tokenService)))
+		r.Get("/confirm-email/{token}", api.ErrorWrapper(confirm_email.New(tokenService, email)))
+		r.Post("/resend-verification", api.ErrorWrapper(resend_verification.New(validator, tokenService, email)))
+		r.Post("/logout", api.ErrorWrapper(logout.New(authService, session)))
+		r.With(middlewares.User(userService, session)).
+			Post("/logout-all", api.ErrorWrapper(logout_all.New(authService, session)))
+		r.Post("/forgot-password", api.ErrorWrapper(forgot_password.New(validator, tokenService, email)))
+		r.Post("/reset-password", api.ErrorWrapper(reset_password.New(validator, tokenService)))
 	})
 
 	r.Route("/category", func(r chi.Router) {
 		r.Get("/", api.ErrorWrapper(get_category.New(categoryService)))
+		r.Get("/{id}", api.ErrorWrapper(get_category_by_id.New(categoryService)))
+		r.Get("/{id}/attributes", api.ErrorWrapper(get_attributes.New(validator, attributeService)))
 	})
 
 	r.Route("/products", func(r chi.Router) {
-		r.Get("/", api.ErrorWrapper(get_product.New(productService)))
-		r.Get("/{id}", api.ErrorWrapper(get_product_by_id.New(productService)))
+		r.Use(middlewares.OptionalUser(userService, session))
+		r.Get("/", api.ErrorWrapper(get_product.New(productService, wishlistService)))
+		r.Get("/{id}", api.ErrorWrapper(get_product_by_id.New(productService, wishlistService)))
+		r.Get("/{id}/reviews", api.ErrorWrapper(get_reviews.New(validator, reviewService)))
+		r.With(middlewares.User(userService, session)).
+			Post("/{id}/reviews", api.ErrorWrapper(create_review.New(validator, reviewService)))
 	})
 
 	r.Route("/admin", func(r chi.Router) {
-		r.Use(middlewares.Admin(userService))
+		r.Use(middlewares.Admin(userService, session))
 		r.Post("/create-category", api.ErrorWrapper(create_category.New(categoryService, validator)))
 		r.Post("/create-product", api.ErrorWrapper(create_product.New(validator, productService)))
+		r.Post("/create-attribute", api.ErrorWrapper(create_attribute.New(validator, attributeService)))
+		r.Put("/update-attribute/{id}", api.ErrorWrapper(update_attribute.New(validator, attributeService)))
+		r.Delete("/delete-attribute/{id}", api.ErrorWrapper(delete_attribute.New(validator, attributeService)))
+		r.Put("/update-product-slug/{id}", api.ErrorWrapper(update_product_slug.New(validator, productService)))
+		r.Put("/update-product-price/{id}", api.ErrorWrapper(update_product_price.New(validator, productService)))
+		r.Get("/product-price-history/{id}", api.ErrorWrapper(product_price_history.New(validator, productService)))
+		r.Put("/update-product-tax-class/{id}", api.ErrorWrapper(update_product_tax_class.New(validator, productService)))
+		r.Put("/update-category-slug/{id}", api.ErrorWrapper(update_category_slug.New(validator, categoryService)))
+		r.Put("/update-category-tax-class/{id}", api.ErrorWrapper(update_category_tax_class.New(validator, categoryService)))
+		r.Get("/reviews", api.ErrorWrapper(get_pending_reviews.New(reviewService)))
+		r.Put("/moderate-review/{id}", api.ErrorWrapper(moderate_review.New(validator, reviewService)))
+		r.Post("/create-promo-code", api.ErrorWrapper(create_promo_code.New(validator, promoService)))
+		r.Get("/promo-codes", api.ErrorWrapper(get_promo_codes.New(promoService)))
+		r.Delete("/delete-promo-code/{id}", api.ErrorWrapper(delete_promo_code.New(validator, promoService)))
+		r.Post("/create-promotion", api.ErrorWrapper(create_promotion.New(validator, promotionService)))
+		r.Get("/promotions", api.ErrorWrapper(get_promotions.New(promotionService)))
+		r.Put("/update-promotion/{id}", api.ErrorWrapper(update_promotion.New(validator, promotionService)))
+		r.Delete("/delete-promotion/{id}", api.ErrorWrapper(delete_promotion.New(validator, promotionService)))
+		r.Post("/create-shipping-method", api.ErrorWrapper(create_shipping_method.New(validator, shippingService)))
+		r.Get("/shipping-methods", api.ErrorWrapper(get_shipping_methods.New(shippingService)))
+		r.Delete("/delete-shipping-method/{id}", api.ErrorWrapper(delete_shipping_method.New(validator, shippingService)))
 	})
 
 	r.Route("/cart", func(r chi.Router) {
-		r.Use(middlewares.User(userService))
-		r.Post("/add", api.ErrorWrapper(cart_add_product.New(validator, cartService)))
-		r.Get("/", api.ErrorWrapper(get_cart.New(cartService)))
-		r.Post("/pay", api.ErrorWrapper(pay_cart.Pay(paymentHandler, cartService)))
+		r.Group(func(r chi.Router) {
+			r.Use(middlewares.Cart(userService, session, guestCookie))
+			r.Post("/add", api.ErrorWrapper(cart_add_product.New(validator, cartService)))
+			r.Put("/update", api.ErrorWrapper(cart_update_product.New(validator, cartService)))
+			r.Get("/", api.ErrorWrapper(get_cart.New(cartService)))
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(middlewares.User(userService, session))
+			r.Get("/shipping-options", api.ErrorWrapper(shipping_options.New(validator, addressService, shippingService)))
+			r.Post("/pay", api.ErrorWrapper(pay_cart.Pay(validator, paymentHandler, yookassaConfig.Receipts, cartService, addressService, shippingService)))
+			r.Post("/promo", api.ErrorWrapper(apply_promo_code.New(validator, promoService)))
+			r.Delete("/promo", api.ErrorWrapper(remove_promo_code.New(promoService)))
+		})
+	})
+
+	r.Route("/wishlist", func(r chi.Router) {
+		r.Use(middlewares.User(userService, session))
+		r.Get("/", api.ErrorWrapper(get_wishlist.New(wishlistService)))
+		r.Post("/add", api.ErrorWrapper(wishlist_add_product.New(validator, wishlistService)))
+		r.Post("/move-to-cart", api.ErrorWrapper(wishlist_move_to_cart.New(validator, wishlistService)))
+		r.Delete("/{id}", api.ErrorWrapper(wishlist_delete_product.New(validator, wishlistService)))
+	})
+
+	r.Route("/me", func(r chi.Router) {
+		r.Use(middlewares.User(userService, session))
+		r.Get("/", api.ErrorWrapper(get_profile.New(userService)))
+		r.Patch("/", api.ErrorWrapper(update_profile.New(validator, userService)))
+		r.Delete("/", api.ErrorWrapper(delete_account.New(accountService)))
+		r.Post("/cancel-deletion", api.ErrorWrapper(cancel_deletion.New(accountService)))
+		r.Get("/export", api.ErrorWrapper(export_account.New(accountService)))
+		r.Put("/password", api.ErrorWrapper(change_password.New(validator, authService, session)))
+		r.Post("/email", api.ErrorWrapper(change_email.New(validator, authService, tokenService, email)))
+		r.Get("/sessions", api.ErrorWrapper(get_sessions.New(userService, session)))
+		r.Delete("/sessions/{id}", api.ErrorWrapper(revoke_session.New(validator, userService)))
+		r.Post("/2fa", api.ErrorWrapper(enrol_two_factor.New(twoFactorService)))
+		r.Post("/2fa/confirm", api.ErrorWrapper(confirm_two_factor.New(validator, twoFactorService, session)))
+		r.Post("/2fa/recovery-codes", api.ErrorWrapper(recovery_codes.New(validator, twoFactorService)))
+		r.Post("/2fa/disable", api.ErrorWrapper(disable_two_factor.New(validator, twoFactorService)))
+	})
+
+	r.Route("/addresses", func(r chi.Router) {
+		r.Use(middlewares.User(userService, session))
+		r.Get("/", api.ErrorWrapper(get_addresses.New(addressService)))
+		r.Post("/", api.ErrorWrapper(create_address.New(validator, addressService)))
+		r.Put("/{id}", api.ErrorWrapper(update_address.New(validator, addressService)))
+		r.Delete("/{id}", api.ErrorWrapper(delete_address.New(validator, addressService)))
 	})
 
 	r.Route("/pay", func(r chi.Router) {
 		r.Use(middlewares.IPFilterMiddleware(allowedCIDRs))
-		r.Post("/webhook", api.ErrorWrapper(pay_cart.Webhook(cartService)))
+		r.Post("/webhook", api.ErrorWrapper(pay_cart.Webhook(cartService, cartService)))
 	})
 
 	return &Server{
@@ -213,3 +543,28 @@ func (s *Server) GracefulStop(ctx context.Context) error {
 func (s *Server) Addr() string {
 	return s.srv.Addr
 }
+
+// filesOnly reports directories as missing, so file server
+// responds 404 instead of listing them
+type filesOnly struct {
+	fs http.FileSystem
+}
+
+func (f filesOnly) Open(name string) (http.File, error) {
+	file, err := f.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if stat.IsDir() {
+		_ = file.Close()
+		return nil, fs.ErrNotExist
+	}
+
+	return file, nil
+}
@@ -0,0 +1,144 @@
+package account_service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+type Repository interface {
+	OrdersByUserId(ctx context.Context, userId string) ([]models.Order, error)
+	ReviewsByUserId(ctx context.Context, userId string) ([]models.Review, error)
+	RequestDeletion(ctx context.Context, userId string) (time.Time, error)
+	CancelDeletion(ctx context.Context, userId string) error
+	AnonymiseUsers(ctx context.Context, gracePeriod time.Duration) ([]string, error)
+}
+
+type UserProvider interface {
+	UserById(ctx context.Context, id string) (models.User, error)
+}
+
+type AddressProvider interface {
+	AddressesByUserId(ctx context.Context, userId string) ([]models.Address, error)
+}
+
+type CartProvider interface {
+	CartByUserId(ctx context.Context, userId string) (models.Cart, error)
+}
+
+type SessionStore interface {
+	DeleteUserSessions(ctx context.Context, userId string) error
+}
+
+// Service anonymises account gracePeriod after user asked
+// to delete it, user can cancel deletion until then
+type Service struct {
+	repository      Repository
+	userProvider    UserProvider
+	addressProvider AddressProvider
+	cartProvider    CartProvider
+	sessionStore    SessionStore
+	gracePeriod     time.Duration
+}
+
+func New(
+	repository Repository,
+	userProvider UserProvider,
+	addressProvider AddressProvider,
+	cartProvider CartProvider,
+	sessionStore SessionStore,
+	gracePeriod time.Duration,
+) *Service {
+	return &Service{
+		repository:      repository,
+		userProvider:    userProvider,
+		addressProvider: addressProvider,
+		cartProvider:    cartProvider,
+		sessionStore:    sessionStore,
+		gracePeriod:     gracePeriod,
+	}
+}
+
+// Export collects personal data of user
+func (s *Service) Export(ctx context.Context, userId string) (models.AccountExport, error) {
+	const op = "services.account.Export"
+
+	user, err := s.userProvider.UserById(ctx, userId)
+	if err != nil {
+		return models.AccountExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	addresses, err := s.addressProvider.AddressesByUserId(ctx, userId)
+	if err != nil {
+		return models.AccountExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cart, err := s.cartProvider.CartByUserId(ctx, userId)
+	if err != nil {
+		return models.AccountExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	orders, err := s.repository.OrdersByUserId(ctx, userId)
+	if err != nil {
+		return models.AccountExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reviews, err := s.repository.ReviewsByUserId(ctx, userId)
+	if err != nil {
+		return models.AccountExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return models.AccountExport{
+		User:       user,
+		Addresses:  addresses,
+		Cart:       cart,
+		Orders:     orders,
+		Reviews:    reviews,
+		ExportedAt: time.Now(),
+	}, nil
+}
+
+// DeleteAccount schedules account anonymisation and returns time it happens at
+func (s *Service) DeleteAccount(ctx context.Context, userId string) (time.Time, error) {
+	const op = "services.account.DeleteAccount"
+
+	requestedAt, err := s.repository.RequestDeletion(ctx, userId)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return requestedAt.Add(s.gracePeriod), nil
+}
+
+func (s *Service) CancelDeletion(ctx context.Context, userId string) error {
+	const op = "services.account.CancelDeletion"
+
+	err := s.repository.CancelDeletion(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AnonymiseAccounts anonymises accounts which grace period is over
+// and revokes their sessions
+func (s *Service) AnonymiseAccounts(ctx context.Context) error {
+	const op = "services.account.AnonymiseAccounts"
+
+	ids, err := s.repository.AnonymiseUsers(ctx, s.gracePeriod)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, id := range ids {
+		err = s.sessionStore.DeleteUserSessions(ctx, id)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
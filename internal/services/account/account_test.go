@@ -0,0 +1,102 @@
+package account_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	account_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/account/__mocks__"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_DeleteAccount(t *testing.T) {
+	mRepository := account_service_mocks.NewMockRepository(t)
+
+	requestedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mRepository.EXPECT().RequestDeletion(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"user",
+	).Return(requestedAt, nil).Once()
+
+	s := New(mRepository, nil, nil, nil, nil, 30*24*time.Hour)
+	got, err := s.DeleteAccount(context.Background(), "user")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), got)
+}
+
+func TestService_AnonymiseAccounts(t *testing.T) {
+	tests := []struct {
+		name         string
+		ids          []string
+		anonymiseErr error
+		wantErr      error
+	}{
+		{
+			name:    "good case",
+			ids:     []string{"first", "second"},
+			wantErr: nil,
+		},
+		{
+			name:    "nothing to anonymise case",
+			ids:     []string{},
+			wantErr: nil,
+		},
+		{
+			name:         "repository error case",
+			anonymiseErr: errors.New("db is down"),
+			wantErr:      errors.New("db is down"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := account_service_mocks.NewMockRepository(t)
+			mSessionStore := account_service_mocks.NewMockSessionStore(t)
+
+			mRepository.EXPECT().AnonymiseUsers(
+				mock.AnythingOfType("context.backgroundCtx"),
+				time.Hour,
+			).Return(tt.ids, tt.anonymiseErr).Once()
+
+			for _, id := range tt.ids {
+				mSessionStore.EXPECT().DeleteUserSessions(
+					mock.AnythingOfType("context.backgroundCtx"),
+					id,
+				).Return(nil).Once()
+			}
+
+			s := New(mRepository, nil, nil, nil, mSessionStore, time.Hour)
+			err := s.AnonymiseAccounts(context.Background())
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Export(t *testing.T) {
+	mRepository := account_service_mocks.NewMockRepository(t)
+	mUserProvider := account_service_mocks.NewMockUserProvider(t)
+	mAddressProvider := account_service_mocks.NewMockAddressProvider(t)
+	mCartProvider := account_service_mocks.NewMockCartProvider(t)
+
+	ctx := mock.AnythingOfType("context.backgroundCtx")
+	mUserProvider.EXPECT().UserById(ctx, "user").Return(models.User{ID: "user", Login: "login"}, nil).Once()
+	mAddressProvider.EXPECT().AddressesByUserId(ctx, "user").Return([]models.Address{{ID: "address"}}, nil).Once()
+	mCartProvider.EXPECT().CartByUserId(ctx, "user").Return(models.Cart{UserId: "user"}, nil).Once()
+	mRepository.EXPECT().OrdersByUserId(ctx, "user").Return([]models.Order{{ID: "order"}}, nil).Once()
+	mRepository.EXPECT().ReviewsByUserId(ctx, "user").Return([]models.Review{{ID: "review"}}, nil).Once()
+
+	s := New(mRepository, mUserProvider, mAddressProvider, mCartProvider, nil, time.Hour)
+	got, err := s.Export(context.Background(), "user")
+	require.NoError(t, err)
+	require.Equal(t, "login", got.User.Login)
+	require.Len(t, got.Addresses, 1)
+	require.Len(t, got.Orders, 1)
+	require.Len(t, got.Reviews, 1)
+	require.False(t, got.ExportedAt.IsZero())
+}
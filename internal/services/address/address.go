@@ -0,0 +1,119 @@
+package address_service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/phone"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+var postalCodeRegexp = regexp.MustCompile(`^[1-9]\d{5}$`)
+
+type Repository interface {
+	SaveAddress(ctx context.Context, address models.Address) (string, error)
+	UpdateAddress(ctx context.Context, address models.Address) error
+	DeleteAddress(ctx context.Context, userId, id string) error
+	AddressesByUserId(ctx context.Context, userId string) ([]models.Address, error)
+	AddressById(ctx context.Context, userId, id string) (models.Address, error)
+}
+
+type Service struct {
+	repository Repository
+}
+
+func New(repository Repository) *Service {
+	return &Service{
+		repository: repository,
+	}
+}
+
+func (s *Service) CreateAddress(ctx context.Context, address models.Address) (string, error) {
+	const op = "services.address.CreateAddress"
+
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.repository.SaveAddress(ctx, address)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) UpdateAddress(ctx context.Context, address models.Address) error {
+	const op = "services.address.UpdateAddress"
+
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.repository.UpdateAddress(ctx, address)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteAddress(ctx context.Context, userId, id string) error {
+	const op = "services.address.DeleteAddress"
+
+	err := s.repository.DeleteAddress(ctx, userId, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) AddressesByUserId(ctx context.Context, userId string) ([]models.Address, error) {
+	const op = "services.address.AddressesByUserId"
+
+	addresses, err := s.repository.AddressesByUserId(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return addresses, nil
+}
+
+func (s *Service) AddressById(ctx context.Context, userId, id string) (models.Address, error) {
+	const op = "services.address.AddressById"
+
+	address, err := s.repository.AddressById(ctx, userId, id)
+	if err != nil {
+		return models.Address{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return address, nil
+}
+
+// normalizeAddress validates russian phone and postal code,
+// phone is brought to +7XXXXXXXXXX form
+func normalizeAddress(address models.Address) (models.Address, error) {
+	address.Recipient = strings.TrimSpace(address.Recipient)
+	address.City = strings.TrimSpace(address.City)
+	address.Street = strings.TrimSpace(address.Street)
+	address.PostalCode = strings.TrimSpace(address.PostalCode)
+	address.Comment = strings.TrimSpace(address.Comment)
+
+	phone, ok := phone.Normalize(address.Phone)
+	if !ok {
+		return models.Address{}, errs.ErrInvalidPhone
+	}
+	address.Phone = phone
+
+	if !postalCodeRegexp.MatchString(address.PostalCode) {
+		return models.Address{}, errs.ErrInvalidPostalCode
+	}
+
+	return address, nil
+}
@@ -0,0 +1,96 @@
+package address_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	address_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/address/__mocks__"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CreateAddress(t *testing.T) {
+	tests := []struct {
+		name       string
+		phone      string
+		postalCode string
+		wantPhone  string
+		wantErr    error
+	}{
+		{
+			name:       "good case",
+			phone:      "+79991234567",
+			postalCode: "101000",
+			wantPhone:  "+79991234567",
+			wantErr:    nil,
+		},
+		{
+			name:       "formatted phone case",
+			phone:      "8 (999) 123-45-67",
+			postalCode: "101000",
+			wantPhone:  "+79991234567",
+			wantErr:    nil,
+		},
+		{
+			name:       "foreign phone case",
+			phone:      "+19991234567",
+			postalCode: "101000",
+			wantErr:    errs.ErrInvalidPhone,
+		},
+		{
+			name:       "short phone case",
+			phone:      "+7999123456",
+			postalCode: "101000",
+			wantErr:    errs.ErrInvalidPhone,
+		},
+		{
+			name:       "short postal code case",
+			phone:      "+79991234567",
+			postalCode: "10100",
+			wantErr:    errs.ErrInvalidPostalCode,
+		},
+		{
+			name:       "postal code starting with zero case",
+			phone:      "+79991234567",
+			postalCode: "010000",
+			wantErr:    errs.ErrInvalidPostalCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := address_service_mocks.NewMockRepository(t)
+
+			address := models.Address{
+				UserId:     "user",
+				Recipient:  " Иванов Иван ",
+				Phone:      tt.phone,
+				City:       "Москва",
+				Street:     "ул. Тверская, д. 1",
+				PostalCode: tt.postalCode,
+			}
+
+			if tt.wantErr == nil {
+				want := address
+				want.Recipient = "Иванов Иван"
+				want.Phone = tt.wantPhone
+				mRepository.EXPECT().SaveAddress(
+					mock.AnythingOfType("context.backgroundCtx"),
+					want,
+				).Return("id", nil)
+			}
+
+			s := New(mRepository)
+			id, err := s.CreateAddress(context.Background(), address)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateAddress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil {
+				require.Equal(t, "id", id)
+			}
+		})
+	}
+}
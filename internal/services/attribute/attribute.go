@@ -0,0 +1,112 @@
+package attribute_service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+type Repository interface {
+	SaveAttribute(ctx context.Context, attribute models.Attribute) (string, error)
+	UpdateAttribute(ctx context.Context, attribute models.Attribute) error
+	DeleteAttribute(ctx context.Context, id string) error
+	AttributesByCategoryIds(ctx context.Context, categoryIds []string) ([]models.Attribute, error)
+}
+
+type Service struct {
+	repository Repository
+}
+
+func New(repository Repository) *Service {
+	return &Service{
+		repository: repository,
+	}
+}
+
+var attributeTypes = []string{
+	consts.AttributeTypeString,
+	consts.AttributeTypeNumber,
+	consts.AttributeTypeBool,
+}
+
+func (s *Service) CreateAttribute(
+	ctx context.Context,
+	categoryId string,
+	name string,
+	attributeType string,
+	unit string,
+	isRequired bool,
+) (string, error) {
+	const op = "services.attribute.CreateAttribute"
+
+	if !slices.Contains(attributeTypes, attributeType) {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrWrongAttributeType)
+	}
+
+	id, err := s.repository.SaveAttribute(ctx, models.Attribute{
+		CategoryId: categoryId,
+		Name:       name,
+		Type:       attributeType,
+		Unit:       unit,
+		IsRequired: isRequired,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) UpdateAttribute(
+	ctx context.Context,
+	id string,
+	name string,
+	attributeType string,
+	unit string,
+	isRequired bool,
+) error {
+	const op = "services.attribute.UpdateAttribute"
+
+	if !slices.Contains(attributeTypes, attributeType) {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongAttributeType)
+	}
+
+	err := s.repository.UpdateAttribute(ctx, models.Attribute{
+		ID:         id,
+		Name:       name,
+		Type:       attributeType,
+		Unit:       unit,
+		IsRequired: isRequired,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteAttribute(ctx context.Context, id string) error {
+	const op = "services.attribute.DeleteAttribute"
+
+	err := s.repository.DeleteAttribute(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) AttributesByCategoryId(ctx context.Context, categoryId string) ([]models.Attribute, error) {
+	const op = "services.attribute.AttributesByCategoryId"
+
+	attributes, err := s.repository.AttributesByCategoryIds(ctx, []string{categoryId})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return attributes, nil
+}
@@ -6,6 +6,7 @@ package auth_service_mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	mock "github.com/stretchr/testify/mock"
@@ -38,6 +39,78 @@ func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
 	return &MockStorage_Expecter{mock: &_m.Mock}
 }
 
+// ConfirmPendingEmail provides a mock function for the type MockStorage
+func (_mock *MockStorage) ConfirmPendingEmail(ctx context.Context, id string, email string) (string, error) {
+	ret := _mock.Called(ctx, id, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmPendingEmail")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, id, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, id, email)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_ConfirmPendingEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmPendingEmail'
+type MockStorage_ConfirmPendingEmail_Call struct {
+	*mock.Call
+}
+
+// ConfirmPendingEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - email string
+func (_e *MockStorage_Expecter) ConfirmPendingEmail(ctx interface{}, id interface{}, email interface{}) *MockStorage_ConfirmPendingEmail_Call {
+	return &MockStorage_ConfirmPendingEmail_Call{Call: _e.mock.On("ConfirmPendingEmail", ctx, id, email)}
+}
+
+func (_c *MockStorage_ConfirmPendingEmail_Call) Run(run func(ctx context.Context, id string, email string)) *MockStorage_ConfirmPendingEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ConfirmPendingEmail_Call) Return(s string, err error) *MockStorage_ConfirmPendingEmail_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockStorage_ConfirmPendingEmail_Call) RunAndReturn(run func(ctx context.Context, id string, email string) (string, error)) *MockStorage_ConfirmPendingEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveAdmin provides a mock function for the type MockStorage
 func (_mock *MockStorage) SaveAdmin(ctx context.Context, login string, email string, password string) error {
 	ret := _mock.Called(ctx, login, email, password)
@@ -185,45 +258,37 @@ func (_c *MockStorage_SaveUser_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
-// UserByEmail provides a mock function for the type MockStorage
-func (_mock *MockStorage) UserByEmail(ctx context.Context, email string) (models.User, error) {
-	ret := _mock.Called(ctx, email)
+// SetPendingEmail provides a mock function for the type MockStorage
+func (_mock *MockStorage) SetPendingEmail(ctx context.Context, id string, email string) error {
+	ret := _mock.Called(ctx, id, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserByEmail")
+		panic("no return value specified for SetPendingEmail")
 	}
 
-	var r0 models.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.User, error)); ok {
-		return returnFunc(ctx, email)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.User); ok {
-		r0 = returnFunc(ctx, email)
-	} else {
-		r0 = ret.Get(0).(models.User)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, email)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, email)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStorage_UserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserByEmail'
-type MockStorage_UserByEmail_Call struct {
+// MockStorage_SetPendingEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPendingEmail'
+type MockStorage_SetPendingEmail_Call struct {
 	*mock.Call
 }
 
-// UserByEmail is a helper method to define mock.On call
+// SetPendingEmail is a helper method to define mock.On call
 //   - ctx context.Context
+//   - id string
 //   - email string
-func (_e *MockStorage_Expecter) UserByEmail(ctx interface{}, email interface{}) *MockStorage_UserByEmail_Call {
-	return &MockStorage_UserByEmail_Call{Call: _e.mock.On("UserByEmail", ctx, email)}
+func (_e *MockStorage_Expecter) SetPendingEmail(ctx interface{}, id interface{}, email interface{}) *MockStorage_SetPendingEmail_Call {
+	return &MockStorage_SetPendingEmail_Call{Call: _e.mock.On("SetPendingEmail", ctx, id, email)}
 }
 
-func (_c *MockStorage_UserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockStorage_UserByEmail_Call {
+func (_c *MockStorage_SetPendingEmail_Call) Run(run func(ctx context.Context, id string, email string)) *MockStorage_SetPendingEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -233,54 +298,60 @@ func (_c *MockStorage_UserByEmail_Call) Run(run func(ctx context.Context, email
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_UserByEmail_Call) Return(user models.User, err error) *MockStorage_UserByEmail_Call {
-	_c.Call.Return(user, err)
+func (_c *MockStorage_SetPendingEmail_Call) Return(err error) *MockStorage_SetPendingEmail_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_UserByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (models.User, error)) *MockStorage_UserByEmail_Call {
+func (_c *MockStorage_SetPendingEmail_Call) RunAndReturn(run func(ctx context.Context, id string, email string) error) *MockStorage_SetPendingEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// VerifyEmail provides a mock function for the type MockStorage
-func (_mock *MockStorage) VerifyEmail(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// UpdatePassword provides a mock function for the type MockStorage
+func (_mock *MockStorage) UpdatePassword(ctx context.Context, id string, password string) error {
+	ret := _mock.Called(ctx, id, password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for VerifyEmail")
+		panic("no return value specified for UpdatePassword")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, password)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStorage_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
-type MockStorage_VerifyEmail_Call struct {
+// MockStorage_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type MockStorage_UpdatePassword_Call struct {
 	*mock.Call
 }
 
-// VerifyEmail is a helper method to define mock.On call
+// UpdatePassword is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *MockStorage_Expecter) VerifyEmail(ctx interface{}, id interface{}) *MockStorage_VerifyEmail_Call {
-	return &MockStorage_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, id)}
+//   - password string
+func (_e *MockStorage_Expecter) UpdatePassword(ctx interface{}, id interface{}, password interface{}) *MockStorage_UpdatePassword_Call {
+	return &MockStorage_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, id, password)}
 }
 
-func (_c *MockStorage_VerifyEmail_Call) Run(run func(ctx context.Context, id string)) *MockStorage_VerifyEmail_Call {
+func (_c *MockStorage_UpdatePassword_Call) Run(run func(ctx context.Context, id string, password string)) *MockStorage_UpdatePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -290,82 +361,123 @@ func (_c *MockStorage_VerifyEmail_Call) Run(run func(ctx context.Context, id str
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_VerifyEmail_Call) Return(err error) *MockStorage_VerifyEmail_Call {
+func (_c *MockStorage_UpdatePassword_Call) Return(err error) *MockStorage_UpdatePassword_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_VerifyEmail_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockStorage_VerifyEmail_Call {
+func (_c *MockStorage_UpdatePassword_Call) RunAndReturn(run func(ctx context.Context, id string, password string) error) *MockStorage_UpdatePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockSessionStore creates a new instance of MockSessionStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockSessionStore(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockSessionStore {
-	mock := &MockSessionStore{}
-	mock.Mock.Test(t)
+// UseRecoveryCode provides a mock function for the type MockStorage
+func (_mock *MockStorage) UseRecoveryCode(ctx context.Context, id string, codeHash string) error {
+	ret := _mock.Called(ctx, id, codeHash)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
 
-	return mock
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockSessionStore is an autogenerated mock type for the SessionStore type
-type MockSessionStore struct {
-	mock.Mock
+// MockStorage_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type MockStorage_UseRecoveryCode_Call struct {
+	*mock.Call
 }
 
-type MockSessionStore_Expecter struct {
-	mock *mock.Mock
+// UseRecoveryCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - codeHash string
+func (_e *MockStorage_Expecter) UseRecoveryCode(ctx interface{}, id interface{}, codeHash interface{}) *MockStorage_UseRecoveryCode_Call {
+	return &MockStorage_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, id, codeHash)}
 }
 
-func (_m *MockSessionStore) EXPECT() *MockSessionStore_Expecter {
-	return &MockSessionStore_Expecter{mock: &_m.Mock}
+func (_c *MockStorage_UseRecoveryCode_Call) Run(run func(ctx context.Context, id string, codeHash string)) *MockStorage_UseRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
 }
 
-// SaveSession provides a mock function for the type MockSessionStore
-func (_mock *MockSessionStore) SaveSession(ctx context.Context, id string, user models.User) error {
-	ret := _mock.Called(ctx, id, user)
+func (_c *MockStorage_UseRecoveryCode_Call) Return(err error) *MockStorage_UseRecoveryCode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_UseRecoveryCode_Call) RunAndReturn(run func(ctx context.Context, id string, codeHash string) error) *MockStorage_UseRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseTwoFactorStep provides a mock function for the type MockStorage
+func (_mock *MockStorage) UseTwoFactorStep(ctx context.Context, id string, step int64) error {
+	ret := _mock.Called(ctx, id, step)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveSession")
+		panic("no return value specified for UseTwoFactorStep")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.User) error); ok {
-		r0 = returnFunc(ctx, id, user)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, id, step)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockSessionStore_SaveSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSession'
-type MockSessionStore_SaveSession_Call struct {
+// MockStorage_UseTwoFactorStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseTwoFactorStep'
+type MockStorage_UseTwoFactorStep_Call struct {
 	*mock.Call
 }
 
-// SaveSession is a helper method to define mock.On call
+// UseTwoFactorStep is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-//   - user models.User
-func (_e *MockSessionStore_Expecter) SaveSession(ctx interface{}, id interface{}, user interface{}) *MockSessionStore_SaveSession_Call {
-	return &MockSessionStore_SaveSession_Call{Call: _e.mock.On("SaveSession", ctx, id, user)}
+//   - step int64
+func (_e *MockStorage_Expecter) UseTwoFactorStep(ctx interface{}, id interface{}, step interface{}) *MockStorage_UseTwoFactorStep_Call {
+	return &MockStorage_UseTwoFactorStep_Call{Call: _e.mock.On("UseTwoFactorStep", ctx, id, step)}
 }
 
-func (_c *MockSessionStore_SaveSession_Call) Run(run func(ctx context.Context, id string, user models.User)) *MockSessionStore_SaveSession_Call {
+func (_c *MockStorage_UseTwoFactorStep_Call) Run(run func(ctx context.Context, id string, step int64)) *MockStorage_UseTwoFactorStep_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -375,9 +487,9 @@ func (_c *MockSessionStore_SaveSession_Call) Run(run func(ctx context.Context, i
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 models.User
+		var arg2 int64
 		if args[2] != nil {
-			arg2 = args[2].(models.User)
+			arg2 = args[2].(int64)
 		}
 		run(
 			arg0,
@@ -388,12 +500,993 @@ func (_c *MockSessionStore_SaveSession_Call) Run(run func(ctx context.Context, i
 	return _c
 }
 
-func (_c *MockSessionStore_SaveSession_Call) Return(err error) *MockSessionStore_SaveSession_Call {
+func (_c *MockStorage_UseTwoFactorStep_Call) Return(err error) *MockStorage_UseTwoFactorStep_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_UseTwoFactorStep_Call) RunAndReturn(run func(ctx context.Context, id string, step int64) error) *MockStorage_UseTwoFactorStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserByEmail provides a mock function for the type MockStorage
+func (_mock *MockStorage) UserByEmail(ctx context.Context, email string) (models.User, error) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserByEmail")
+	}
+
+	var r0 models.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.User, error)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.User); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Get(0).(models.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_UserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserByEmail'
+type MockStorage_UserByEmail_Call struct {
+	*mock.Call
+}
+
+// UserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockStorage_Expecter) UserByEmail(ctx interface{}, email interface{}) *MockStorage_UserByEmail_Call {
+	return &MockStorage_UserByEmail_Call{Call: _e.mock.On("UserByEmail", ctx, email)}
+}
+
+func (_c *MockStorage_UserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockStorage_UserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_UserByEmail_Call) Return(user models.User, err error) *MockStorage_UserByEmail_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockStorage_UserByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (models.User, error)) *MockStorage_UserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserById provides a mock function for the type MockStorage
+func (_mock *MockStorage) UserById(ctx context.Context, id string) (models.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserById")
+	}
+
+	var r0 models.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_UserById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserById'
+type MockStorage_UserById_Call struct {
+	*mock.Call
+}
+
+// UserById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStorage_Expecter) UserById(ctx interface{}, id interface{}) *MockStorage_UserById_Call {
+	return &MockStorage_UserById_Call{Call: _e.mock.On("UserById", ctx, id)}
+}
+
+func (_c *MockStorage_UserById_Call) Run(run func(ctx context.Context, id string)) *MockStorage_UserById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_UserById_Call) Return(user models.User, err error) *MockStorage_UserById_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockStorage_UserById_Call) RunAndReturn(run func(ctx context.Context, id string) (models.User, error)) *MockStorage_UserById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function for the type MockStorage
+func (_mock *MockStorage) VerifyEmail(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type MockStorage_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStorage_Expecter) VerifyEmail(ctx interface{}, id interface{}) *MockStorage_VerifyEmail_Call {
+	return &MockStorage_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, id)}
+}
+
+func (_c *MockStorage_VerifyEmail_Call) Run(run func(ctx context.Context, id string)) *MockStorage_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_VerifyEmail_Call) Return(err error) *MockStorage_VerifyEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_VerifyEmail_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockStorage_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionStore creates a new instance of MockSessionStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionStore {
+	mock := &MockSessionStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSessionStore is an autogenerated mock type for the SessionStore type
+type MockSessionStore struct {
+	mock.Mock
+}
+
+type MockSessionStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionStore) EXPECT() *MockSessionStore_Expecter {
+	return &MockSessionStore_Expecter{mock: &_m.Mock}
+}
+
+// DeleteSession provides a mock function for the type MockSessionStore
+func (_mock *MockSessionStore) DeleteSession(ctx context.Context, sessionId string) error {
+	ret := _mock.Called(ctx, sessionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, sessionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionStore_DeleteSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSession'
+type MockSessionStore_DeleteSession_Call struct {
+	*mock.Call
+}
+
+// DeleteSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionId string
+func (_e *MockSessionStore_Expecter) DeleteSession(ctx interface{}, sessionId interface{}) *MockSessionStore_DeleteSession_Call {
+	return &MockSessionStore_DeleteSession_Call{Call: _e.mock.On("DeleteSession", ctx, sessionId)}
+}
+
+func (_c *MockSessionStore_DeleteSession_Call) Run(run func(ctx context.Context, sessionId string)) *MockSessionStore_DeleteSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionStore_DeleteSession_Call) Return(err error) *MockSessionStore_DeleteSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionStore_DeleteSession_Call) RunAndReturn(run func(ctx context.Context, sessionId string) error) *MockSessionStore_DeleteSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserSessions provides a mock function for the type MockSessionStore
+func (_mock *MockSessionStore) DeleteUserSessions(ctx context.Context, userId string) error {
+	ret := _mock.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserSessions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionStore_DeleteUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserSessions'
+type MockSessionStore_DeleteUserSessions_Call struct {
+	*mock.Call
+}
+
+// DeleteUserSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockSessionStore_Expecter) DeleteUserSessions(ctx interface{}, userId interface{}) *MockSessionStore_DeleteUserSessions_Call {
+	return &MockSessionStore_DeleteUserSessions_Call{Call: _e.mock.On("DeleteUserSessions", ctx, userId)}
+}
+
+func (_c *MockSessionStore_DeleteUserSessions_Call) Run(run func(ctx context.Context, userId string)) *MockSessionStore_DeleteUserSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionStore_DeleteUserSessions_Call) Return(err error) *MockSessionStore_DeleteUserSessions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionStore_DeleteUserSessions_Call) RunAndReturn(run func(ctx context.Context, userId string) error) *MockSessionStore_DeleteUserSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveSession provides a mock function for the type MockSessionStore
+func (_mock *MockSessionStore) SaveSession(ctx context.Context, id string, user models.User, session models.Session) error {
+	ret := _mock.Called(ctx, id, user, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.User, models.Session) error); ok {
+		r0 = returnFunc(ctx, id, user, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionStore_SaveSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSession'
+type MockSessionStore_SaveSession_Call struct {
+	*mock.Call
+}
+
+// SaveSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - user models.User
+//   - session models.Session
+func (_e *MockSessionStore_Expecter) SaveSession(ctx interface{}, id interface{}, user interface{}, session interface{}) *MockSessionStore_SaveSession_Call {
+	return &MockSessionStore_SaveSession_Call{Call: _e.mock.On("SaveSession", ctx, id, user, session)}
+}
+
+func (_c *MockSessionStore_SaveSession_Call) Run(run func(ctx context.Context, id string, user models.User, session models.Session)) *MockSessionStore_SaveSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models.User
+		if args[2] != nil {
+			arg2 = args[2].(models.User)
+		}
+		var arg3 models.Session
+		if args[3] != nil {
+			arg3 = args[3].(models.Session)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionStore_SaveSession_Call) Return(err error) *MockSessionStore_SaveSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionStore_SaveSession_Call) RunAndReturn(run func(ctx context.Context, id string, user models.User, session models.Session) error) *MockSessionStore_SaveSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCartMerger creates a new instance of MockCartMerger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCartMerger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCartMerger {
+	mock := &MockCartMerger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCartMerger is an autogenerated mock type for the CartMerger type
+type MockCartMerger struct {
+	mock.Mock
+}
+
+type MockCartMerger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCartMerger) EXPECT() *MockCartMerger_Expecter {
+	return &MockCartMerger_Expecter{mock: &_m.Mock}
+}
+
+// MergeGuestCart provides a mock function for the type MockCartMerger
+func (_mock *MockCartMerger) MergeGuestCart(ctx context.Context, guestId string, userId string) error {
+	ret := _mock.Called(ctx, guestId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeGuestCart")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, guestId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCartMerger_MergeGuestCart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeGuestCart'
+type MockCartMerger_MergeGuestCart_Call struct {
+	*mock.Call
+}
+
+// MergeGuestCart is a helper method to define mock.On call
+//   - ctx context.Context
+//   - guestId string
+//   - userId string
+func (_e *MockCartMerger_Expecter) MergeGuestCart(ctx interface{}, guestId interface{}, userId interface{}) *MockCartMerger_MergeGuestCart_Call {
+	return &MockCartMerger_MergeGuestCart_Call{Call: _e.mock.On("MergeGuestCart", ctx, guestId, userId)}
+}
+
+func (_c *MockCartMerger_MergeGuestCart_Call) Run(run func(ctx context.Context, guestId string, userId string)) *MockCartMerger_MergeGuestCart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCartMerger_MergeGuestCart_Call) Return(err error) *MockCartMerger_MergeGuestCart_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCartMerger_MergeGuestCart_Call) RunAndReturn(run func(ctx context.Context, guestId string, userId string) error) *MockCartMerger_MergeGuestCart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLoginLimiter creates a new instance of MockLoginLimiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLoginLimiter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLoginLimiter {
+	mock := &MockLoginLimiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLoginLimiter is an autogenerated mock type for the LoginLimiter type
+type MockLoginLimiter struct {
+	mock.Mock
+}
+
+type MockLoginLimiter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLoginLimiter) EXPECT() *MockLoginLimiter_Expecter {
+	return &MockLoginLimiter_Expecter{mock: &_m.Mock}
+}
+
+// AddFailure provides a mock function for the type MockLoginLimiter
+func (_mock *MockLoginLimiter) AddFailure(ctx context.Context, key string) (int, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFailure")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLoginLimiter_AddFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddFailure'
+type MockLoginLimiter_AddFailure_Call struct {
+	*mock.Call
+}
+
+// AddFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockLoginLimiter_Expecter) AddFailure(ctx interface{}, key interface{}) *MockLoginLimiter_AddFailure_Call {
+	return &MockLoginLimiter_AddFailure_Call{Call: _e.mock.On("AddFailure", ctx, key)}
+}
+
+func (_c *MockLoginLimiter_AddFailure_Call) Run(run func(ctx context.Context, key string)) *MockLoginLimiter_AddFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLoginLimiter_AddFailure_Call) Return(n int, err error) *MockLoginLimiter_AddFailure_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockLoginLimiter_AddFailure_Call) RunAndReturn(run func(ctx context.Context, key string) (int, error)) *MockLoginLimiter_AddFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function for the type MockLoginLimiter
+func (_mock *MockLoginLimiter) Lock(ctx context.Context, key string, expire time.Duration) error {
+	ret := _mock.Called(ctx, key, expire)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = returnFunc(ctx, key, expire)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLoginLimiter_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type MockLoginLimiter_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - expire time.Duration
+func (_e *MockLoginLimiter_Expecter) Lock(ctx interface{}, key interface{}, expire interface{}) *MockLoginLimiter_Lock_Call {
+	return &MockLoginLimiter_Lock_Call{Call: _e.mock.On("Lock", ctx, key, expire)}
+}
+
+func (_c *MockLoginLimiter_Lock_Call) Run(run func(ctx context.Context, key string, expire time.Duration)) *MockLoginLimiter_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLoginLimiter_Lock_Call) Return(err error) *MockLoginLimiter_Lock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLoginLimiter_Lock_Call) RunAndReturn(run func(ctx context.Context, key string, expire time.Duration) error) *MockLoginLimiter_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockedFor provides a mock function for the type MockLoginLimiter
+func (_mock *MockLoginLimiter) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockedFor")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (time.Duration, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLoginLimiter_LockedFor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockedFor'
+type MockLoginLimiter_LockedFor_Call struct {
+	*mock.Call
+}
+
+// LockedFor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockLoginLimiter_Expecter) LockedFor(ctx interface{}, key interface{}) *MockLoginLimiter_LockedFor_Call {
+	return &MockLoginLimiter_LockedFor_Call{Call: _e.mock.On("LockedFor", ctx, key)}
+}
+
+func (_c *MockLoginLimiter_LockedFor_Call) Run(run func(ctx context.Context, key string)) *MockLoginLimiter_LockedFor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLoginLimiter_LockedFor_Call) Return(duration time.Duration, err error) *MockLoginLimiter_LockedFor_Call {
+	_c.Call.Return(duration, err)
+	return _c
+}
+
+func (_c *MockLoginLimiter_LockedFor_Call) RunAndReturn(run func(ctx context.Context, key string) (time.Duration, error)) *MockLoginLimiter_LockedFor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetFailures provides a mock function for the type MockLoginLimiter
+func (_mock *MockLoginLimiter) ResetFailures(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetFailures")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLoginLimiter_ResetFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetFailures'
+type MockLoginLimiter_ResetFailures_Call struct {
+	*mock.Call
+}
+
+// ResetFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockLoginLimiter_Expecter) ResetFailures(ctx interface{}, key interface{}) *MockLoginLimiter_ResetFailures_Call {
+	return &MockLoginLimiter_ResetFailures_Call{Call: _e.mock.On("ResetFailures", ctx, key)}
+}
+
+func (_c *MockLoginLimiter_ResetFailures_Call) Run(run func(ctx context.Context, key string)) *MockLoginLimiter_ResetFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLoginLimiter_ResetFailures_Call) Return(err error) *MockLoginLimiter_ResetFailures_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLoginLimiter_ResetFailures_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockLoginLimiter_ResetFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPendingLoginStore creates a new instance of MockPendingLoginStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPendingLoginStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPendingLoginStore {
+	mock := &MockPendingLoginStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPendingLoginStore is an autogenerated mock type for the PendingLoginStore type
+type MockPendingLoginStore struct {
+	mock.Mock
+}
+
+type MockPendingLoginStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPendingLoginStore) EXPECT() *MockPendingLoginStore_Expecter {
+	return &MockPendingLoginStore_Expecter{mock: &_m.Mock}
+}
+
+// DeletePendingLogin provides a mock function for the type MockPendingLoginStore
+func (_mock *MockPendingLoginStore) DeletePendingLogin(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePendingLogin")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPendingLoginStore_DeletePendingLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePendingLogin'
+type MockPendingLoginStore_DeletePendingLogin_Call struct {
+	*mock.Call
+}
+
+// DeletePendingLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockPendingLoginStore_Expecter) DeletePendingLogin(ctx interface{}, token interface{}) *MockPendingLoginStore_DeletePendingLogin_Call {
+	return &MockPendingLoginStore_DeletePendingLogin_Call{Call: _e.mock.On("DeletePendingLogin", ctx, token)}
+}
+
+func (_c *MockPendingLoginStore_DeletePendingLogin_Call) Run(run func(ctx context.Context, token string)) *MockPendingLoginStore_DeletePendingLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPendingLoginStore_DeletePendingLogin_Call) Return(err error) *MockPendingLoginStore_DeletePendingLogin_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPendingLoginStore_DeletePendingLogin_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockPendingLoginStore_DeletePendingLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PendingLogin provides a mock function for the type MockPendingLoginStore
+func (_mock *MockPendingLoginStore) PendingLogin(ctx context.Context, token string) (models.PendingLogin, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingLogin")
+	}
+
+	var r0 models.PendingLogin
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.PendingLogin, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.PendingLogin); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Get(0).(models.PendingLogin)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPendingLoginStore_PendingLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PendingLogin'
+type MockPendingLoginStore_PendingLogin_Call struct {
+	*mock.Call
+}
+
+// PendingLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockPendingLoginStore_Expecter) PendingLogin(ctx interface{}, token interface{}) *MockPendingLoginStore_PendingLogin_Call {
+	return &MockPendingLoginStore_PendingLogin_Call{Call: _e.mock.On("PendingLogin", ctx, token)}
+}
+
+func (_c *MockPendingLoginStore_PendingLogin_Call) Run(run func(ctx context.Context, token string)) *MockPendingLoginStore_PendingLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPendingLoginStore_PendingLogin_Call) Return(pendingLogin models.PendingLogin, err error) *MockPendingLoginStore_PendingLogin_Call {
+	_c.Call.Return(pendingLogin, err)
+	return _c
+}
+
+func (_c *MockPendingLoginStore_PendingLogin_Call) RunAndReturn(run func(ctx context.Context, token string) (models.PendingLogin, error)) *MockPendingLoginStore_PendingLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePendingLogin provides a mock function for the type MockPendingLoginStore
+func (_mock *MockPendingLoginStore) SavePendingLogin(ctx context.Context, token string, login models.PendingLogin) error {
+	ret := _mock.Called(ctx, token, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePendingLogin")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.PendingLogin) error); ok {
+		r0 = returnFunc(ctx, token, login)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPendingLoginStore_SavePendingLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePendingLogin'
+type MockPendingLoginStore_SavePendingLogin_Call struct {
+	*mock.Call
+}
+
+// SavePendingLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - login models.PendingLogin
+func (_e *MockPendingLoginStore_Expecter) SavePendingLogin(ctx interface{}, token interface{}, login interface{}) *MockPendingLoginStore_SavePendingLogin_Call {
+	return &MockPendingLoginStore_SavePendingLogin_Call{Call: _e.mock.On("SavePendingLogin", ctx, token, login)}
+}
+
+func (_c *MockPendingLoginStore_SavePendingLogin_Call) Run(run func(ctx context.Context, token string, login models.PendingLogin)) *MockPendingLoginStore_SavePendingLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models.PendingLogin
+		if args[2] != nil {
+			arg2 = args[2].(models.PendingLogin)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPendingLoginStore_SavePendingLogin_Call) Return(err error) *MockPendingLoginStore_SavePendingLogin_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSessionStore_SaveSession_Call) RunAndReturn(run func(ctx context.Context, id string, user models.User) error) *MockSessionStore_SaveSession_Call {
+func (_c *MockPendingLoginStore_SavePendingLogin_Call) RunAndReturn(run func(ctx context.Context, token string, login models.PendingLogin) error) *MockPendingLoginStore_SavePendingLogin_Call {
 	_c.Call.Return(run)
 	return _c
 }
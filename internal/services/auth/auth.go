@@ -2,9 +2,13 @@ package auth_service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/totp"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -14,22 +18,76 @@ type Storage interface {
 	SaveUser(ctx context.Context, login, email, password string) (string, error)
 	SaveAdmin(ctx context.Context, login, email, password string) error
 	UserByEmail(ctx context.Context, email string) (models.User, error)
+	UserById(ctx context.Context, id string) (models.User, error)
 	VerifyEmail(ctx context.Context, id string) error
+	UpdatePassword(ctx context.Context, id, password string) error
+	UseRecoveryCode(ctx context.Context, id, codeHash string) error
+	UseTwoFactorStep(ctx context.Context, id string, step int64) error
+	SetPendingEmail(ctx context.Context, id, email string) error
+	ConfirmPendingEmail(ctx context.Context, id, email string) (string, error)
 }
 
 type SessionStore interface {
-	SaveSession(ctx context.Context, id string, user models.User) error
+	SaveSession(ctx context.Context, id string, user models.User, session models.Session) error
+	DeleteSession(ctx context.Context, sessionId string) error
+	DeleteUserSessions(ctx context.Context, userId string) error
 }
 
+type CartMerger interface {
+	MergeGuestCart(ctx context.Context, guestId, userId string) error
+}
+
+type LoginLimiter interface {
+	AddFailure(ctx context.Context, key string) (int, error)
+	ResetFailures(ctx context.Context, key string) error
+	Lock(ctx context.Context, key string, expire time.Duration) error
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+}
+
+type PendingLoginStore interface {
+	SavePendingLogin(ctx context.Context, token string, login models.PendingLogin) error
+	PendingLogin(ctx context.Context, token string) (models.PendingLogin, error)
+	DeletePendingLogin(ctx context.Context, token string) error
+}
+
+// LoginPolicy AccountAttempts failed logins per account and IPAttempts per ip
+// are allowed, each next failure locks login for Lockout doubled per failure
+// up to MaxLockout
+type LoginPolicy struct {
+	AccountAttempts int
+	IPAttempts      int
+	Lockout         time.Duration
+	MaxLockout      time.Duration
+}
+
+// dummyHash is compared with password of unknown user,
+// so login takes the same time whether user exists or not
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
 type Service struct {
-	storage      Storage
-	sessionStore SessionStore
+	storage           Storage
+	sessionStore      SessionStore
+	cartMerger        CartMerger
+	loginLimiter      LoginLimiter
+	pendingLoginStore PendingLoginStore
+	loginPolicy       LoginPolicy
 }
 
-func New(storage Storage, sessionStore SessionStore) *Service {
+func New(
+	storage Storage,
+	sessionStore SessionStore,
+	cartMerger CartMerger,
+	loginLimiter LoginLimiter,
+	pendingLoginStore PendingLoginStore,
+	loginPolicy LoginPolicy,
+) *Service {
 	return &Service{
-		storage:      storage,
-		sessionStore: sessionStore,
+		storage:           storage,
+		sessionStore:      sessionStore,
+		cartMerger:        cartMerger,
+		loginLimiter:      loginLimiter,
+		pendingLoginStore: pendingLoginStore,
+		loginPolicy:       loginPolicy,
 	}
 }
 
@@ -65,32 +123,416 @@ func (s *Service) RegisterAdmin(ctx context.Context, login, email, password stri
 	return nil
 }
 
-func (s *Service) Login(ctx context.Context, email, password string) (string, error) {
+// Login creates user session with client metadata from session. If guestId is not
+// empty guest cart is merged into user cart, merge failure does not break login
+// and is returned as ErrFailedToMergeCart together with session id.
+// Wrong email and wrong password both give ErrInvalidCredentials, when failure
+// locks existing account ErrAccountLocked is returned too. Locked login gives
+// ErrTooManyRequests with RetryAfter. Users with two factor authentication get TwoFactorToken
+// instead of session, login is completed with LoginTwoFactor
+func (s *Service) Login(
+	ctx context.Context,
+	email string,
+	password string,
+	guestId string,
+	session models.Session,
+) (models.LoginResult, error) {
 	const op = "services.auth.Login"
 
+	accountKey := "account:" + strings.ToLower(email)
+	ipKey := "ip:" + session.IP
+	for _, key := range []string{accountKey, ipKey} {
+		lockedFor, err := s.loginLimiter.LockedFor(ctx, key)
+		if err != nil {
+			return models.LoginResult{}, fmt.Errorf("%s: %w", op, err)
+		}
+		if lockedFor > 0 {
+			return models.LoginResult{RetryAfter: lockedFor}, fmt.Errorf("%s: %w", op, errs.ErrTooManyRequests)
+		}
+	}
+
 	user, err := s.storage.UserByEmail(ctx, email)
+	if err != nil && !errors.Is(err, errs.ErrUserNotFound) {
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	userExists := err == nil
+
+	hash := dummyHash
+	if userExists {
+		hash = []byte(user.Password)
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || !userExists {
+		accountLocked, err := s.loginFailed(ctx, accountKey, ipKey)
+		if err != nil {
+			return models.LoginResult{}, fmt.Errorf("%s: %w", op, err)
+		}
+		if accountLocked && userExists {
+			return models.LoginResult{}, fmt.Errorf("%s: %w: %w", op, errs.ErrInvalidCredentials, errs.ErrAccountLocked)
+		}
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, errs.ErrInvalidCredentials)
+	}
+
+	if !user.IsEmailVerified {
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, errs.ErrEmailNotVerify)
+	}
+
+	err = s.loginLimiter.ResetFailures(ctx, accountKey)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := s.completeLogin(ctx, user, guestId, session)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// LoginById logs in user whose identity is already proven, for example by
+// magic link. Two factor authentication and cart merge work as in Login
+func (s *Service) LoginById(
+	ctx context.Context,
+	userId string,
+	guestId string,
+	session models.Session,
+) (models.LoginResult, error) {
+	const op = "services.auth.LoginById"
+
+	user, err := s.storage.UserById(ctx, userId)
+	if err != nil {
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	if !user.IsEmailVerified {
-		return "", fmt.Errorf("%s: %w", op, errs.ErrEmailNotVerify)
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, errs.ErrEmailNotVerify)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	result, err := s.completeLogin(ctx, user, guestId, session)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, errs.ErrUserNotFound)
+		return result, fmt.Errorf("%s: %w", op, err)
 	}
 
-	sessionId := uuid.NewString()
-	err = s.sessionStore.SaveSession(ctx, sessionId, user)
+	return result, nil
+}
+
+// LoginTwoFactor completes login started by Login with totp or recovery code,
+// wrong code gives ErrWrongTwoFactorCode. Too many wrong codes remove pending
+// login and lock two factor login of user for Lockout doubled per failure,
+// locked login gives ErrTooManyRequests. Cart merge failure is returned as in Login
+func (s *Service) LoginTwoFactor(ctx context.Context, token, code string) (string, error) {
+	const op = "services.auth.LoginTwoFactor"
+
+	login, err := s.pendingLoginStore.PendingLogin(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := s.storage.UserById(ctx, login.UserId)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
+	if !user.TwoFactorEnabled {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrTwoFactorNotEnabled)
+	}
+
+	key := twoFactorKey(user.ID)
+	lockedFor, err := s.loginLimiter.LockedFor(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if lockedFor > 0 {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrTooManyRequests)
+	}
+
+	err = s.checkTwoFactorCode(ctx, user, code)
+	if err != nil && !errors.Is(err, errs.ErrWrongTwoFactorCode) {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if err != nil {
+		failures, err := s.loginLimiter.AddFailure(ctx, key)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		if failures >= s.loginPolicy.AccountAttempts {
+			err = s.loginLimiter.Lock(ctx, key, s.lockout(failures-s.loginPolicy.AccountAttempts+1))
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", op, err)
+			}
+			err = s.pendingLoginStore.DeletePendingLogin(ctx, token)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", op, err)
+			}
+			return "", fmt.Errorf("%s: %w", op, errs.ErrTooManyRequests)
+		}
+		return "", fmt.Errorf("%s: %w", op, errs.ErrWrongTwoFactorCode)
+	}
+
+	err = s.loginLimiter.ResetFailures(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.pendingLoginStore.DeletePendingLogin(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessionId, err := s.createSession(ctx, user, login.GuestId, login.Session)
+	if err != nil {
+		return sessionId, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessionId, nil
+}
+
+// checkTwoFactorCode accepts totp code or unused recovery code,
+// accepted totp or recovery code can not be used again
+func (s *Service) checkTwoFactorCode(ctx context.Context, user models.User, code string) error {
+	if step, ok := totp.ValidateStep(user.TwoFactorSecret, code, time.Now()); ok {
+		return s.storage.UseTwoFactorStep(ctx, user.ID, step)
+	}
+
+	return s.storage.UseRecoveryCode(ctx, user.ID, totp.HashRecoveryCode(code))
+}
+
+// completeLogin creates session of user or pending login
+// if user has two factor authentication, pending login is not
+// created while two factor login is locked
+func (s *Service) completeLogin(
+	ctx context.Context,
+	user models.User,
+	guestId string,
+	session models.Session,
+) (models.LoginResult, error) {
+	if user.TwoFactorEnabled {
+		lockedFor, err := s.loginLimiter.LockedFor(ctx, twoFactorKey(user.ID))
+		if err != nil {
+			return models.LoginResult{}, err
+		}
+		if lockedFor > 0 {
+			return models.LoginResult{RetryAfter: lockedFor}, errs.ErrTooManyRequests
+		}
+
+		token := uuid.NewString()
+		err = s.pendingLoginStore.SavePendingLogin(ctx, token, models.PendingLogin{
+			UserId:  user.ID,
+			GuestId: guestId,
+			Session: session,
+		})
+		if err != nil {
+			return models.LoginResult{}, err
+		}
+
+		return models.LoginResult{TwoFactorToken: token}, nil
+	}
+
+	sessionId, err := s.createSession(ctx, user, guestId, session)
+	if err != nil {
+		return models.LoginResult{SessionId: sessionId}, err
+	}
+
+	return models.LoginResult{SessionId: sessionId}, nil
+}
+
+// createSession saves new user session and merges guest cart into user cart,
+// session id is returned together with ErrFailedToMergeCart
+func (s *Service) createSession(ctx context.Context, user models.User, guestId string, session models.Session) (string, error) {
+	sessionId := uuid.NewString()
+	session.CreatedAt = time.Now()
+	err := s.sessionStore.SaveSession(ctx, sessionId, user, session)
+	if err != nil {
+		return "", err
+	}
+
+	if guestId != "" {
+		err = s.cartMerger.MergeGuestCart(ctx, guestId, user.ID)
+		if err != nil {
+			return sessionId, fmt.Errorf("%w: %w", errs.ErrFailedToMergeCart, err)
+		}
+	}
 
 	return sessionId, nil
 }
 
+// loginFailed counts failed login and locks account and ip which exceeded
+// attempts limit, returns true if account got locked for the first time
+func (s *Service) loginFailed(ctx context.Context, accountKey, ipKey string) (bool, error) {
+	failures, err := s.loginLimiter.AddFailure(ctx, accountKey)
+	if err != nil {
+		return false, err
+	}
+	accountLocked := failures > s.loginPolicy.AccountAttempts
+	if accountLocked {
+		err = s.loginLimiter.Lock(ctx, accountKey, s.lockout(failures-s.loginPolicy.AccountAttempts))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	ipFailures, err := s.loginLimiter.AddFailure(ctx, ipKey)
+	if err != nil {
+		return false, err
+	}
+	if ipFailures > s.loginPolicy.IPAttempts {
+		err = s.loginLimiter.Lock(ctx, ipKey, s.lockout(ipFailures-s.loginPolicy.IPAttempts))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return accountLocked && failures == s.loginPolicy.AccountAttempts+1, nil
+}
+
+// twoFactorKey returns login limiter key of user two factor failures
+func twoFactorKey(userId string) string {
+	return "2fa:" + userId
+}
+
+// lockout returns lock time after n failures over limit,
+// it doubles with each failure and is capped by MaxLockout
+func (s *Service) lockout(n int) time.Duration {
+	lockout := s.loginPolicy.Lockout
+	for i := 1; i < n && lockout < s.loginPolicy.MaxLockout; i++ {
+		lockout *= 2
+	}
+
+	return min(lockout, s.loginPolicy.MaxLockout)
+}
+
+func (s *Service) Logout(ctx context.Context, sessionId string) error {
+	const op = "services.auth.Logout"
+
+	err := s.sessionStore.DeleteSession(ctx, sessionId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// LogoutEverywhere revokes all sessions of user
+func (s *Service) LogoutEverywhere(ctx context.Context, userId string) error {
+	const op = "services.auth.LogoutEverywhere"
+
+	err := s.sessionStore.DeleteUserSessions(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// HashPassword returns hash of password the way it is stored
+func (s *Service) HashPassword(password string) (string, error) {
+	const op = "services.auth.HashPassword"
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return string(hashPassword), nil
+}
+
+// ChangePassword sets new user password and revokes all user sessions
+func (s *Service) ChangePassword(ctx context.Context, userId, password string) error {
+	const op = "services.auth.ChangePassword"
+
+	hashPassword, err := s.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.UpdatePassword(ctx, userId, hashPassword)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.sessionStore.DeleteUserSessions(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ChangeOwnPassword checks current user password before changing it,
+// user created with identity provider has no password and sets the first
+// one without current password, all user sessions are revoked
+func (s *Service) ChangeOwnPassword(ctx context.Context, userId, currentPassword, password string) error {
+	const op = "services.auth.ChangeOwnPassword"
+
+	user, err := s.storage.UserById(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if user.Password != "" {
+		err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword))
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, errs.ErrWrongPassword)
+		}
+	}
+
+	err = s.ChangePassword(ctx, userId, password)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RequestEmailChange saves email which becomes user email after
+// ConfirmEmailChange, returns user to send confirmation to
+func (s *Service) RequestEmailChange(ctx context.Context, userId, email string) (models.User, error) {
+	const op = "services.auth.RequestEmailChange"
+
+	_, err := s.storage.UserByEmail(ctx, email)
+	if err == nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, errs.ErrUserAlreadyExists)
+	}
+	if !errors.Is(err, errs.ErrUserNotFound) {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := s.storage.UserById(ctx, userId)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.SetPendingEmail(ctx, userId, email)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// ConfirmEmailChange replaces user email with pending one if it is email,
+// returns replaced email
+func (s *Service) ConfirmEmailChange(ctx context.Context, userId, email string) (string, error) {
+	const op = "services.auth.ConfirmEmailChange"
+
+	oldEmail, err := s.storage.ConfirmPendingEmail(ctx, userId, email)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return oldEmail, nil
+}
+
+func (s *Service) UserByEmail(ctx context.Context, email string) (models.User, error) {
+	const op = "services.auth.UserByEmail"
+
+	user, err := s.storage.UserByEmail(ctx, email)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
 // TODO: move to user service
 func (s *Service) VerifyEmail(ctx context.Context, id string) error {
 	const op = "services.auth.VerifyEmail"
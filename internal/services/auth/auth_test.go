@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/totp"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	auth_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/auth/__mocks__"
 	"github.com/stretchr/testify/mock"
@@ -128,15 +131,28 @@ func TestService_Login(t *testing.T) {
 	type fields struct {
 		storage      Storage
 		sessionStore SessionStore
+		cartMerger   CartMerger
 	}
 	type args struct {
 		ctx      context.Context
 		email    string
 		password string
+		guestId  string
 	}
 
 	ms := auth_service_mocks.NewMockStorage(t)
 	mc := auth_service_mocks.NewMockSessionStore(t)
+	mm := auth_service_mocks.NewMockCartMerger(t)
+	ml := auth_service_mocks.NewMockLoginLimiter(t)
+
+	ml.EXPECT().LockedFor(
+		mock.AnythingOfType("context.backgroundCtx"),
+		mock.AnythingOfType("string"),
+	).Return(0, nil)
+	ml.EXPECT().ResetFailures(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"account:sas@gmail.com",
+	).Return(nil)
 
 	tests := []struct {
 		name           string
@@ -145,12 +161,14 @@ func TestService_Login(t *testing.T) {
 		wantErr        error
 		wantStorageErr error
 		wantCashErr    error
+		wantMergeErr   error
 	}{
 		{
 			name: "good case",
 			fields: fields{
 				storage:      ms,
 				sessionStore: mc,
+				cartMerger:   mm,
 			},
 			args: args{
 				ctx:      context.Background(),
@@ -161,6 +179,38 @@ func TestService_Login(t *testing.T) {
 			wantStorageErr: nil,
 			wantCashErr:    nil,
 		},
+		{
+			name: "guest cart merge case",
+			fields: fields{
+				storage:      ms,
+				sessionStore: mc,
+				cartMerger:   mm,
+			},
+			args: args{
+				ctx:      context.Background(),
+				email:    "sas@gmail.com",
+				password: "test123",
+				guestId:  "guest",
+			},
+			wantErr:      nil,
+			wantMergeErr: nil,
+		},
+		{
+			name: "failed to merge guest cart case",
+			fields: fields{
+				storage:      ms,
+				sessionStore: mc,
+				cartMerger:   mm,
+			},
+			args: args{
+				ctx:      context.Background(),
+				email:    "sas@gmail.com",
+				password: "test123",
+				guestId:  "guest",
+			},
+			wantErr:      errs.ErrFailedToMergeCart,
+			wantMergeErr: errors.New("failed to merge"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -182,22 +232,475 @@ func TestService_Login(t *testing.T) {
 				mock.AnythingOfType("context.backgroundCtx"),
 				mock.AnythingOfType("string"),
 				mock.AnythingOfType("models.User"),
+				mock.AnythingOfType("models.Session"),
 			).Return(tt.wantCashErr)
 
+			if tt.args.guestId != "" {
+				mm.EXPECT().MergeGuestCart(
+					mock.AnythingOfType("context.backgroundCtx"),
+					tt.args.guestId,
+					"test",
+				).Return(tt.wantMergeErr).Once()
+			}
+
 			s := &Service{
 				storage:      tt.fields.storage,
 				sessionStore: tt.fields.sessionStore,
+				cartMerger:   tt.fields.cartMerger,
+				loginLimiter: ml,
 			}
-			got, err := s.Login(tt.args.ctx, tt.args.email, tt.args.password)
-			if err != nil {
+			got, err := s.Login(tt.args.ctx, tt.args.email, tt.args.password, tt.args.guestId, models.Session{})
+			if err != nil || tt.wantErr != nil {
 				if !errors.Is(err, tt.wantErr) {
 					t.Errorf("Service.Login() error = %v, wantErr %v", err, tt.wantErr)
 					return
 				}
 			}
-			if got == "" {
+			if got.SessionId == "" {
 				t.Error("Service.Login() = id is empty")
 			}
 		})
 	}
 }
+
+func TestService_ChangePassword(t *testing.T) {
+	tests := []struct {
+		name          string
+		wantUpdateErr error
+		wantErr       error
+	}{
+		{
+			name:          "good case",
+			wantUpdateErr: nil,
+			wantErr:       nil,
+		},
+		{
+			name:          "user not found case",
+			wantUpdateErr: errs.ErrUserNotFound,
+			wantErr:       errs.ErrUserNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := auth_service_mocks.NewMockStorage(t)
+			mc := auth_service_mocks.NewMockSessionStore(t)
+
+			ms.EXPECT().UpdatePassword(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+				mock.MatchedBy(func(hash string) bool {
+					return bcrypt.CompareHashAndPassword([]byte(hash), []byte("new-password")) == nil
+				}),
+			).Return(tt.wantUpdateErr)
+
+			if tt.wantUpdateErr == nil {
+				mc.EXPECT().DeleteUserSessions(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+				).Return(nil).Once()
+			}
+
+			s := New(ms, mc, nil, nil, nil, LoginPolicy{})
+			err := s.ChangePassword(context.Background(), "user", "new-password")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ChangePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_ChangeOwnPassword(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+
+	tests := []struct {
+		name            string
+		password        string
+		currentPassword string
+		wantChanged     bool
+		wantErr         error
+	}{
+		{
+			name:            "good case",
+			password:        string(hash),
+			currentPassword: "password",
+			wantChanged:     true,
+			wantErr:         nil,
+		},
+		{
+			name:            "wrong password case",
+			password:        string(hash),
+			currentPassword: "wrong",
+			wantChanged:     false,
+			wantErr:         errs.ErrWrongPassword,
+		},
+		{
+			name:            "first password case",
+			password:        "",
+			currentPassword: "",
+			wantChanged:     true,
+			wantErr:         nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := auth_service_mocks.NewMockStorage(t)
+			mc := auth_service_mocks.NewMockSessionStore(t)
+
+			ms.EXPECT().UserById(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+			).Return(models.User{ID: "user", Password: tt.password}, nil).Once()
+
+			if tt.wantChanged {
+				ms.EXPECT().UpdatePassword(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					mock.AnythingOfType("string"),
+				).Return(nil).Once()
+				mc.EXPECT().DeleteUserSessions(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+				).Return(nil).Once()
+			}
+
+			s := New(ms, mc, nil, nil, nil, LoginPolicy{})
+			err := s.ChangeOwnPassword(context.Background(), "user", tt.currentPassword, "new-password")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ChangeOwnPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_RequestEmailChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		emailOwner error
+		wantErr    error
+	}{
+		{
+			name:       "good case",
+			emailOwner: errs.ErrUserNotFound,
+			wantErr:    nil,
+		},
+		{
+			name:       "email taken case",
+			emailOwner: nil,
+			wantErr:    errs.ErrUserAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := auth_service_mocks.NewMockStorage(t)
+
+			ms.EXPECT().UserByEmail(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"new@mail.com",
+			).Return(models.User{}, tt.emailOwner).Once()
+
+			if tt.wantErr == nil {
+				ms.EXPECT().UserById(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+				).Return(models.User{ID: "user", Login: "login"}, nil).Once()
+				ms.EXPECT().SetPendingEmail(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					"new@mail.com",
+				).Return(nil).Once()
+			}
+
+			s := New(ms, nil, nil, nil, nil, LoginPolicy{})
+			_, err := s.RequestEmailChange(context.Background(), "user", "new@mail.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.RequestEmailChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_Login_Lockout(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	policy := LoginPolicy{
+		AccountAttempts: 3,
+		IPAttempts:      10,
+		Lockout:         time.Minute,
+		MaxLockout:      time.Hour,
+	}
+
+	tests := []struct {
+		name        string
+		userErr     error
+		lockedFor   time.Duration
+		failures    int
+		wantLock    time.Duration
+		wantErr     error
+		wantLockErr bool
+	}{
+		{
+			name:     "wrong password case",
+			failures: 1,
+			wantErr:  errs.ErrInvalidCredentials,
+		},
+		{
+			name:     "unknown user case",
+			userErr:  errs.ErrUserNotFound,
+			failures: 1,
+			wantErr:  errs.ErrInvalidCredentials,
+		},
+		{
+			name:        "first lock case",
+			failures:    4,
+			wantLock:    time.Minute,
+			wantErr:     errs.ErrInvalidCredentials,
+			wantLockErr: true,
+		},
+		{
+			name:     "backoff case",
+			failures: 6,
+			wantLock: 4 * time.Minute,
+			wantErr:  errs.ErrInvalidCredentials,
+		},
+		{
+			name:     "max lockout case",
+			failures: 40,
+			wantLock: time.Hour,
+			wantErr:  errs.ErrInvalidCredentials,
+		},
+		{
+			name:      "locked case",
+			lockedFor: time.Minute,
+			wantErr:   errs.ErrTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := auth_service_mocks.NewMockStorage(t)
+			ml := auth_service_mocks.NewMockLoginLimiter(t)
+
+			ml.EXPECT().LockedFor(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"account:sas@gmail.com",
+			).Return(tt.lockedFor, nil).Once()
+
+			if tt.lockedFor == 0 {
+				ml.EXPECT().LockedFor(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"ip:127.0.0.1",
+				).Return(0, nil).Once()
+				ms.EXPECT().UserByEmail(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"sas@gmail.com",
+				).Return(models.User{ID: "user", Password: string(hash), IsEmailVerified: true}, tt.userErr).Once()
+				ml.EXPECT().AddFailure(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"account:sas@gmail.com",
+				).Return(tt.failures, nil).Once()
+				ml.EXPECT().AddFailure(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"ip:127.0.0.1",
+				).Return(1, nil).Once()
+			}
+			if tt.wantLock > 0 {
+				ml.EXPECT().Lock(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"account:sas@gmail.com",
+					tt.wantLock,
+				).Return(nil).Once()
+			}
+
+			s := New(ms, nil, nil, ml, nil, policy)
+			got, err := s.Login(context.Background(), "sas@gmail.com", "wrong", "", models.Session{IP: "127.0.0.1"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.Login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.RetryAfter != tt.lockedFor {
+				t.Errorf("Service.Login() retry after = %v, want %v", got.RetryAfter, tt.lockedFor)
+			}
+			if errors.Is(err, errs.ErrAccountLocked) != tt.wantLockErr {
+				t.Errorf("Service.Login() error = %v, want account locked %v", err, tt.wantLockErr)
+			}
+		})
+	}
+}
+
+func TestService_Login_TwoFactor(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("test123"), bcrypt.MinCost)
+
+	ms := auth_service_mocks.NewMockStorage(t)
+	ml := auth_service_mocks.NewMockLoginLimiter(t)
+	mp := auth_service_mocks.NewMockPendingLoginStore(t)
+
+	ml.EXPECT().LockedFor(
+		mock.AnythingOfType("context.backgroundCtx"),
+		mock.AnythingOfType("string"),
+	).Return(0, nil)
+	ml.EXPECT().ResetFailures(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"account:sas@gmail.com",
+	).Return(nil).Once()
+	ms.EXPECT().UserByEmail(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"sas@gmail.com",
+	).Return(models.User{
+		ID:               "user",
+		Password:         string(hash),
+		IsEmailVerified:  true,
+		TwoFactorEnabled: true,
+	}, nil).Once()
+	mp.EXPECT().SavePendingLogin(
+		mock.AnythingOfType("context.backgroundCtx"),
+		mock.AnythingOfType("string"),
+		models.PendingLogin{
+			UserId:  "user",
+			GuestId: "guest",
+			Session: models.Session{IP: "127.0.0.1"},
+		},
+	).Return(nil).Once()
+
+	s := New(ms, nil, nil, ml, mp, LoginPolicy{AccountAttempts: 5})
+	got, err := s.Login(context.Background(), "sas@gmail.com", "test123", "guest", models.Session{IP: "127.0.0.1"})
+	if err != nil {
+		t.Errorf("Service.Login() error = %v", err)
+	}
+	if got.SessionId != "" || got.TwoFactorToken == "" {
+		t.Errorf("Service.Login() = %v, want two factor token only", got)
+	}
+}
+
+func TestService_LoginTwoFactor(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.Code(secret, time.Now())
+
+	tests := []struct {
+		name           string
+		code           string
+		stepErr        error
+		recoveryErr    error
+		failures       int
+		lockedFor      time.Duration
+		wantErr        error
+		wantRecovery   bool
+		wantSession    bool
+		wantDeleteOnly bool
+	}{
+		{
+			name:        "totp code case",
+			code:        code,
+			wantSession: true,
+		},
+		{
+			name:         "recovery code case",
+			code:         "abcd-efgh",
+			wantRecovery: true,
+			wantSession:  true,
+		},
+		{
+			name:         "wrong code case",
+			code:         "abcd-efgh",
+			recoveryErr:  errs.ErrWrongTwoFactorCode,
+			failures:     1,
+			wantRecovery: true,
+			wantErr:      errs.ErrWrongTwoFactorCode,
+		},
+		{
+			name:           "too many wrong codes case",
+			code:           "abcd-efgh",
+			recoveryErr:    errs.ErrWrongTwoFactorCode,
+			failures:       5,
+			wantRecovery:   true,
+			wantErr:        errs.ErrTooManyRequests,
+			wantDeleteOnly: true,
+		},
+		{
+			name:     "replayed totp code case",
+			code:     code,
+			stepErr:  errs.ErrWrongTwoFactorCode,
+			failures: 1,
+			wantErr:  errs.ErrWrongTwoFactorCode,
+		},
+		{
+			name:      "locked case",
+			code:      code,
+			lockedFor: time.Minute,
+			wantErr:   errs.ErrTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := auth_service_mocks.NewMockStorage(t)
+			mc := auth_service_mocks.NewMockSessionStore(t)
+			ml := auth_service_mocks.NewMockLoginLimiter(t)
+			mp := auth_service_mocks.NewMockPendingLoginStore(t)
+
+			ml.EXPECT().LockedFor(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"2fa:user",
+			).Return(tt.lockedFor, nil).Once()
+
+			mp.EXPECT().PendingLogin(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"token",
+			).Return(models.PendingLogin{UserId: "user"}, nil).Once()
+			ms.EXPECT().UserById(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+			).Return(models.User{ID: "user", TwoFactorSecret: secret, TwoFactorEnabled: true}, nil).Once()
+
+			if tt.code == code && tt.lockedFor == 0 {
+				ms.EXPECT().UseTwoFactorStep(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					mock.AnythingOfType("int64"),
+				).Return(tt.stepErr).Once()
+			}
+			if tt.wantRecovery {
+				ms.EXPECT().UseRecoveryCode(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					totp.HashRecoveryCode(tt.code),
+				).Return(tt.recoveryErr).Once()
+			}
+			if tt.failures > 0 {
+				ml.EXPECT().AddFailure(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"2fa:user",
+				).Return(tt.failures, nil).Once()
+			}
+			if tt.wantDeleteOnly {
+				ml.EXPECT().Lock(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"2fa:user",
+					time.Minute,
+				).Return(nil).Once()
+			}
+			if tt.wantSession {
+				ml.EXPECT().ResetFailures(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"2fa:user",
+				).Return(nil).Once()
+				mc.EXPECT().SaveSession(
+					mock.AnythingOfType("context.backgroundCtx"),
+					mock.AnythingOfType("string"),
+					mock.AnythingOfType("models.User"),
+					mock.AnythingOfType("models.Session"),
+				).Return(nil).Once()
+			}
+			if tt.wantSession || tt.wantDeleteOnly {
+				mp.EXPECT().DeletePendingLogin(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"token",
+				).Return(nil).Once()
+			}
+
+			s := New(ms, mc, nil, ml, mp, LoginPolicy{AccountAttempts: 5, Lockout: time.Minute, MaxLockout: time.Hour})
+			got, err := s.LoginTwoFactor(context.Background(), "token", tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.LoginTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got != "") != tt.wantSession {
+				t.Errorf("Service.LoginTwoFactor() = %v, want session %v", got, tt.wantSession)
+			}
+		})
+	}
+}
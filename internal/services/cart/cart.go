@@ -3,30 +3,66 @@ package cart_service
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/tax"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 )
 
 type Repository interface {
-	AddProduct(ctx context.Context, userId, productId string) (string, error)
+	AddProduct(ctx context.Context, userId, productId string, maxQuantity int) (string, error)
+	UpdateQuantity(ctx context.Context, userId, productId string, quantity int) error
 	CartByUserId(ctx context.Context, userId string) (models.Cart, error)
+	ItemsByProductIds(ctx context.Context, productIds []string) ([]models.CartItem, error)
+	MergeItems(ctx context.Context, userId string, items map[string]int, strategy string, maxQuantity int) error
 	DeleteCartByUserId(ctx context.Context, userId string) error
+	SavePayment(ctx context.Context, payment models.Payment) error
+	CreateOrder(ctx context.Context, userId, paymentId string) (string, error)
+	ReleasePromoCodes(ctx context.Context, paymentId string) error
+}
+
+type GuestCash interface {
+	AddProduct(ctx context.Context, guestId, productId string, maxQuantity int) (int, error)
+	SetQuantity(ctx context.Context, guestId, productId string, quantity int) (bool, error)
+	CartByGuestId(ctx context.Context, guestId string) (map[string]int, error)
+	DeleteCart(ctx context.Context, guestId string) error
+}
+
+// Discounter returns discounts which should be applied to user cart
+type Discounter interface {
+	Adjustments(ctx context.Context, cart models.Cart) ([]models.Adjustment, error)
 }
 
 type Service struct {
-	repository Repository
+	repository    Repository
+	guestCash     GuestCash
+	discounters   []Discounter
+	mergeStrategy string
+	maxQuantity   int
 }
 
-func New(repository Repository) *Service {
+func New(
+	repository Repository,
+	guestCash GuestCash,
+	mergeStrategy string,
+	maxQuantity int,
+	discounters ...Discounter,
+) *Service {
 	return &Service{
-		repository: repository,
+		repository:    repository,
+		guestCash:     guestCash,
+		discounters:   discounters,
+		mergeStrategy: mergeStrategy,
+		maxQuantity:   maxQuantity,
 	}
 }
 
 func (s *Service) AddProduct(ctx context.Context, userId, productId string) (string, error) {
 	const op = "services.cart.AddProduct"
 
-	cartId, err := s.repository.AddProduct(ctx, userId, productId)
+	cartId, err := s.repository.AddProduct(ctx, userId, productId, s.maxQuantity)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
@@ -34,33 +70,40 @@ func (s *Service) AddProduct(ctx context.Context, userId, productId string) (str
 	return cartId, nil
 }
 
+func (s *Service) UpdateProduct(ctx context.Context, userId, productId string, quantity int) error {
+	const op = "services.cart.UpdateProduct"
+
+	if quantity < 0 || quantity > s.maxQuantity {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongQuantity)
+	}
+
+	err := s.repository.UpdateQuantity(ctx, userId, productId, quantity)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (s *Service) CartByUserId(ctx context.Context, userId string) (models.Cart, error) {
 	const op = "services.cart.CartByUserId"
 
-	cart, err := s.repository.CartByUserId(ctx, userId)
+	cart, err := s.userCart(ctx, userId)
 	if err != nil {
 		return models.Cart{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	for _, product := range cart.Products {
-		cart.Price += product.Price
-	}
-
 	return cart, nil
 }
 
 func (s *Service) CartPriceByUserId(ctx context.Context, userId string) (float32, error) {
 	const op = "services.cart.CartPriceByUserId"
 
-	cart, err := s.repository.CartByUserId(ctx, userId)
+	cart, err := s.userCart(ctx, userId)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	for _, product := range cart.Products {
-		cart.Price += product.Price
-	}
-
 	return cart.Price, nil
 }
 
@@ -74,3 +117,210 @@ func (s *Service) DeleteCartByUserId(ctx context.Context, userId string) error {
 
 	return nil
 }
+
+// SavePayment saves payment with snapshot of paid cart: items with their
+// prices after discounts, discount, tax and applied promo codes. Promo codes
+// are reserved until payment is completed or canceled
+func (s *Service) SavePayment(ctx context.Context, payment models.Payment, cart models.Cart) error {
+	const op = "services.cart.SavePayment"
+
+	payment.Price = cart.Price
+	payment.Discount = cart.Discount
+	payment.Tax = cart.Tax
+	payment.PromoCodeIds = make([]string, 0)
+	for _, adjustment := range cart.Adjustments {
+		if adjustment.Source == consts.AdjustmentPromoCode {
+			payment.PromoCodeIds = append(payment.PromoCodeIds, adjustment.SourceId)
+		}
+	}
+	payment.Items = make([]models.OrderItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		payment.Items = append(payment.Items, models.OrderItem{
+			ProductId: item.Product.ID,
+			Name:      item.Product.Name,
+			Price:     item.Product.Price,
+			Total:     item.Total,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	err := s.repository.SavePayment(ctx, payment)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CompleteOrder creates order from snapshot of cart saved with payment,
+// so order matches paid amount even if cart was changed after payment
+func (s *Service) CompleteOrder(ctx context.Context, userId, paymentId string) (string, error) {
+	const op = "services.cart.CompleteOrder"
+
+	orderId, err := s.repository.CreateOrder(ctx, userId, paymentId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orderId, nil
+}
+
+// CancelPayment releases promo codes reserved for canceled or expired payment
+func (s *Service) CancelPayment(ctx context.Context, paymentId string) error {
+	const op = "services.cart.CancelPayment"
+
+	err := s.repository.ReleasePromoCodes(ctx, paymentId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) AddGuestProduct(ctx context.Context, guestId, productId string) error {
+	const op = "services.cart.AddGuestProduct"
+
+	items, err := s.repository.ItemsByProductIds(ctx, []string{productId})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrProductNotFound)
+	}
+
+	_, err = s.guestCash.AddProduct(ctx, guestId, productId, s.maxQuantity)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) UpdateGuestProduct(ctx context.Context, guestId, productId string, quantity int) error {
+	const op = "services.cart.UpdateGuestProduct"
+
+	if quantity < 0 || quantity > s.maxQuantity {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongQuantity)
+	}
+
+	ok, err := s.guestCash.SetQuantity(ctx, guestId, productId, quantity)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !ok {
+		return fmt.Errorf("%s: %w", op, errs.ErrProductNotInCart)
+	}
+
+	return nil
+}
+
+func (s *Service) CartByGuestId(ctx context.Context, guestId string) (models.Cart, error) {
+	const op = "services.cart.CartByGuestId"
+
+	quantities, err := s.guestCash.CartByGuestId(ctx, guestId)
+	if err != nil {
+		return models.Cart{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cart := models.Cart{
+		ID:    guestId,
+		Items: make([]models.CartItem, 0, len(quantities)),
+	}
+	if len(quantities) == 0 {
+		return cart, nil
+	}
+
+	productIds := make([]string, 0, len(quantities))
+	for productId := range quantities {
+		productIds = append(productIds, productId)
+	}
+
+	items, err := s.repository.ItemsByProductIds(ctx, productIds)
+	if err != nil {
+		return models.Cart{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, item := range items {
+		item.Quantity = quantities[item.Product.ID]
+		cart.Items = append(cart.Items, item)
+	}
+	cart.Subtotal = cartPrice(cart.Items)
+	cart.Price = cart.Subtotal
+
+	return tax.Calculate(cart), nil
+}
+
+// MergeGuestCart moves guest cart into user cart and deletes guest cart
+func (s *Service) MergeGuestCart(ctx context.Context, guestId, userId string) error {
+	const op = "services.cart.MergeGuestCart"
+
+	items, err := s.guestCash.CartByGuestId(ctx, guestId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	err = s.repository.MergeItems(ctx, userId, items, s.mergeStrategy, s.maxQuantity)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.guestCash.DeleteCart(ctx, guestId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// userCart returns user cart with applied discounts and taxes, discounters
+// after the one which returned exclusive adjustment are skipped
+func (s *Service) userCart(ctx context.Context, userId string) (models.Cart, error) {
+	const op = "services.cart.userCart"
+
+	cart, err := s.repository.CartByUserId(ctx, userId)
+	if err != nil {
+		return models.Cart{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cart.Subtotal = cartPrice(cart.Items)
+	cart.Adjustments = make([]models.Adjustment, 0)
+	if len(cart.Items) > 0 {
+		for _, discounter := range s.discounters {
+			adjustments, err := discounter.Adjustments(ctx, cart)
+			if err != nil {
+				return models.Cart{}, fmt.Errorf("%s: %w", op, err)
+			}
+			cart.Adjustments = append(cart.Adjustments, adjustments...)
+			if slices.ContainsFunc(adjustments, isExclusive) {
+				break
+			}
+		}
+	}
+
+	for _, adjustment := range cart.Adjustments {
+		cart.Discount += adjustment.Amount
+		if adjustment.FreeShipping {
+			cart.FreeShipping = true
+		}
+	}
+	cart.Discount = min(cart.Discount, cart.Subtotal)
+	cart.Price = cart.Subtotal - cart.Discount
+
+	return tax.Calculate(cart), nil
+}
+
+func isExclusive(adjustment models.Adjustment) bool {
+	return adjustment.Exclusive
+}
+
+func cartPrice(items []models.CartItem) float32 {
+	var price float32
+	for _, item := range items {
+		price += item.Product.Price * float32(item.Quantity)
+	}
+
+	return price
+}
@@ -0,0 +1,287 @@
+package cart_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	cart_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/cart/__mocks__"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_MergeGuestCart(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		guestId string
+		userId  string
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		guestItems   map[string]int
+		wantMerge    bool
+		wantMergeErr error
+		wantErr      error
+	}{
+		{
+			name: "good case",
+			args: args{
+				ctx:     context.Background(),
+				guestId: "guest",
+				userId:  "user",
+			},
+			guestItems: map[string]int{"product": 2},
+			wantMerge:  true,
+			wantErr:    nil,
+		},
+		{
+			name: "empty guest cart case",
+			args: args{
+				ctx:     context.Background(),
+				guestId: "guest",
+				userId:  "user",
+			},
+			guestItems: map[string]int{},
+			wantMerge:  false,
+			wantErr:    nil,
+		},
+		{
+			name: "unknown strategy case",
+			args: args{
+				ctx:     context.Background(),
+				guestId: "guest",
+				userId:  "user",
+			},
+			guestItems:   map[string]int{"product": 2},
+			wantMerge:    true,
+			wantMergeErr: errs.ErrUnknownMergeStrategy,
+			wantErr:      errs.ErrUnknownMergeStrategy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := cart_service_mocks.NewMockRepository(t)
+			mGuestCash := cart_service_mocks.NewMockGuestCash(t)
+
+			mGuestCash.EXPECT().CartByGuestId(
+				mock.AnythingOfType("context.backgroundCtx"),
+				tt.args.guestId,
+			).Return(tt.guestItems, nil)
+
+			if tt.wantMerge {
+				mRepository.EXPECT().MergeItems(
+					mock.AnythingOfType("context.backgroundCtx"),
+					tt.args.userId,
+					tt.guestItems,
+					consts.CartMergeMax,
+					99,
+				).Return(tt.wantMergeErr)
+			}
+
+			if tt.wantMerge && tt.wantMergeErr == nil {
+				mGuestCash.EXPECT().DeleteCart(
+					mock.AnythingOfType("context.backgroundCtx"),
+					tt.args.guestId,
+				).Return(nil)
+			}
+
+			s := New(mRepository, mGuestCash, consts.CartMergeMax, 99)
+			if err := s.MergeGuestCart(tt.args.ctx, tt.args.guestId, tt.args.userId); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.MergeGuestCart() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_CartByGuestId(t *testing.T) {
+	mRepository := cart_service_mocks.NewMockRepository(t)
+	mGuestCash := cart_service_mocks.NewMockGuestCash(t)
+
+	mGuestCash.EXPECT().CartByGuestId(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"guest",
+	).Return(map[string]int{"first": 2, "deleted": 1}, nil)
+
+	mRepository.EXPECT().ItemsByProductIds(
+		mock.AnythingOfType("context.backgroundCtx"),
+		mock.AnythingOfType("[]string"),
+	).Return([]models.CartItem{
+		{
+			Product: models.ProductCard{
+				ID:    "first",
+				Price: 150,
+			},
+			TaxClass: consts.TaxClassVat20,
+		},
+	}, nil)
+
+	s := New(mRepository, mGuestCash, consts.CartMergeMax, 99)
+	cart, err := s.CartByGuestId(context.Background(), "guest")
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 1)
+	require.Equal(t, 2, cart.Items[0].Quantity)
+	require.Equal(t, float32(300), cart.Price)
+	require.Equal(t, float32(50), cart.Tax)
+}
+
+func TestService_UpdateProduct(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity int
+		wantCall bool
+		wantErr  error
+	}{
+		{
+			name:     "good case",
+			quantity: 3,
+			wantCall: true,
+			wantErr:  nil,
+		},
+		{
+			name:     "remove case",
+			quantity: 0,
+			wantCall: true,
+			wantErr:  nil,
+		},
+		{
+			name:     "negative quantity case",
+			quantity: -1,
+			wantCall: false,
+			wantErr:  errs.ErrWrongQuantity,
+		},
+		{
+			name:     "too big quantity case",
+			quantity: 100,
+			wantCall: false,
+			wantErr:  errs.ErrWrongQuantity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := cart_service_mocks.NewMockRepository(t)
+
+			if tt.wantCall {
+				mRepository.EXPECT().UpdateQuantity(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					"product",
+					tt.quantity,
+				).Return(nil)
+			}
+
+			s := New(mRepository, nil, consts.CartMergeMax, 99)
+			if err := s.UpdateProduct(context.Background(), "user", "product", tt.quantity); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.UpdateProduct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_CartPriceByUserId(t *testing.T) {
+	mRepository := cart_service_mocks.NewMockRepository(t)
+	mDiscounter := cart_service_mocks.NewMockDiscounter(t)
+
+	cart := models.Cart{
+		ID:     "cart",
+		UserId: "user",
+		Items: []models.CartItem{
+			{
+				Product:  models.ProductCard{ID: "product", Price: 150},
+				Quantity: 2,
+			},
+		},
+	}
+
+	mRepository.EXPECT().CartByUserId(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"user",
+	).Return(cart, nil)
+
+	mDiscounter.EXPECT().Adjustments(
+		mock.AnythingOfType("context.backgroundCtx"),
+		mock.AnythingOfType("models.Cart"),
+	).Return([]models.Adjustment{
+		{
+			Source: consts.AdjustmentPromoCode,
+			Amount: 50,
+		},
+	}, nil)
+
+	s := New(mRepository, nil, consts.CartMergeMax, 99, mDiscounter)
+	price, err := s.CartPriceByUserId(context.Background(), "user")
+	require.NoError(t, err)
+	require.Equal(t, float32(250), price)
+}
+
+func TestService_CartPriceByUserId_ExclusivePromotion(t *testing.T) {
+	mRepository := cart_service_mocks.NewMockRepository(t)
+	mPromotions := cart_service_mocks.NewMockDiscounter(t)
+	mPromoCodes := cart_service_mocks.NewMockDiscounter(t)
+
+	mRepository.EXPECT().CartByUserId(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"user",
+	).Return(models.Cart{
+		UserId: "user",
+		Items: []models.CartItem{
+			{Product: models.ProductCard{ID: "product", Price: 150}, Quantity: 2},
+		},
+	}, nil)
+
+	mPromotions.EXPECT().Adjustments(
+		mock.AnythingOfType("context.backgroundCtx"),
+		mock.AnythingOfType("models.Cart"),
+	).Return([]models.Adjustment{
+		{Source: consts.AdjustmentPromotion, Amount: 100, Exclusive: true},
+	}, nil)
+
+	s := New(mRepository, nil, consts.CartMergeMax, 99, mPromotions, mPromoCodes)
+	price, err := s.CartPriceByUserId(context.Background(), "user")
+	require.NoError(t, err)
+	require.Equal(t, float32(200), price)
+	mPromoCodes.AssertNotCalled(t, "Adjustments", mock.Anything, mock.Anything)
+}
+
+func TestService_SavePayment(t *testing.T) {
+	mRepository := cart_service_mocks.NewMockRepository(t)
+
+	cart := models.Cart{
+		Price:    150,
+		Discount: 50,
+		Tax:      25,
+		Items: []models.CartItem{
+			{Product: models.ProductCard{ID: "first", Name: "First", Price: 100}, Quantity: 2, Total: 150},
+		},
+		Adjustments: []models.Adjustment{
+			{Source: consts.AdjustmentPromoCode, SourceId: "promo", Amount: 30},
+			{Source: consts.AdjustmentPromotion, SourceId: "promotion", Amount: 20},
+		},
+	}
+
+	mRepository.EXPECT().SavePayment(
+		mock.AnythingOfType("context.backgroundCtx"),
+		models.Payment{
+			ID:           "payment",
+			UserId:       "user",
+			Amount:       200,
+			Price:        150,
+			Discount:     50,
+			Tax:          25,
+			PromoCodeIds: []string{"promo"},
+			Items: []models.OrderItem{
+				{ProductId: "first", Name: "First", Price: 100, Total: 150, Quantity: 2},
+			},
+		},
+	).Return(nil).Once()
+
+	s := New(mRepository, nil, consts.CartMergeMax, 99)
+	err := s.SavePayment(context.Background(), models.Payment{ID: "payment", UserId: "user", Amount: 200}, cart)
+	if err != nil {
+		t.Errorf("Service.SavePayment() error = %v", err)
+	}
+}
@@ -100,17 +100,145 @@ func (_c *MockRepository_AllCategories_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// CategoriesWithIdSlug provides a mock function for the type MockRepository
+func (_mock *MockRepository) CategoriesWithIdSlug(ctx context.Context) ([]models.Category, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CategoriesWithIdSlug")
+	}
+
+	var r0 []models.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Category, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Category); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CategoriesWithIdSlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CategoriesWithIdSlug'
+type MockRepository_CategoriesWithIdSlug_Call struct {
+	*mock.Call
+}
+
+// CategoriesWithIdSlug is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) CategoriesWithIdSlug(ctx interface{}) *MockRepository_CategoriesWithIdSlug_Call {
+	return &MockRepository_CategoriesWithIdSlug_Call{Call: _e.mock.On("CategoriesWithIdSlug", ctx)}
+}
+
+func (_c *MockRepository_CategoriesWithIdSlug_Call) Run(run func(ctx context.Context)) *MockRepository_CategoriesWithIdSlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CategoriesWithIdSlug_Call) Return(categorys []models.Category, err error) *MockRepository_CategoriesWithIdSlug_Call {
+	_c.Call.Return(categorys, err)
+	return _c
+}
+
+func (_c *MockRepository_CategoriesWithIdSlug_Call) RunAndReturn(run func(ctx context.Context) ([]models.Category, error)) *MockRepository_CategoriesWithIdSlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CategoryByIdOrSlug provides a mock function for the type MockRepository
+func (_mock *MockRepository) CategoryByIdOrSlug(ctx context.Context, idOrSlug string) (models.Category, error) {
+	ret := _mock.Called(ctx, idOrSlug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CategoryByIdOrSlug")
+	}
+
+	var r0 models.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.Category, error)); ok {
+		return returnFunc(ctx, idOrSlug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.Category); ok {
+		r0 = returnFunc(ctx, idOrSlug)
+	} else {
+		r0 = ret.Get(0).(models.Category)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, idOrSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CategoryByIdOrSlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CategoryByIdOrSlug'
+type MockRepository_CategoryByIdOrSlug_Call struct {
+	*mock.Call
+}
+
+// CategoryByIdOrSlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idOrSlug string
+func (_e *MockRepository_Expecter) CategoryByIdOrSlug(ctx interface{}, idOrSlug interface{}) *MockRepository_CategoryByIdOrSlug_Call {
+	return &MockRepository_CategoryByIdOrSlug_Call{Call: _e.mock.On("CategoryByIdOrSlug", ctx, idOrSlug)}
+}
+
+func (_c *MockRepository_CategoryByIdOrSlug_Call) Run(run func(ctx context.Context, idOrSlug string)) *MockRepository_CategoryByIdOrSlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CategoryByIdOrSlug_Call) Return(category models.Category, err error) *MockRepository_CategoryByIdOrSlug_Call {
+	_c.Call.Return(category, err)
+	return _c
+}
+
+func (_c *MockRepository_CategoryByIdOrSlug_Call) RunAndReturn(run func(ctx context.Context, idOrSlug string) (models.Category, error)) *MockRepository_CategoryByIdOrSlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveCategory provides a mock function for the type MockRepository
-func (_mock *MockRepository) SaveCategory(ctx context.Context, id string, name string) error {
-	ret := _mock.Called(ctx, id, name)
+func (_mock *MockRepository) SaveCategory(ctx context.Context, id string, name string, slug string) error {
+	ret := _mock.Called(ctx, id, name, slug)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveCategory")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, id, name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, id, name, slug)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -126,11 +254,12 @@ type MockRepository_SaveCategory_Call struct {
 //   - ctx context.Context
 //   - id string
 //   - name string
-func (_e *MockRepository_Expecter) SaveCategory(ctx interface{}, id interface{}, name interface{}) *MockRepository_SaveCategory_Call {
-	return &MockRepository_SaveCategory_Call{Call: _e.mock.On("SaveCategory", ctx, id, name)}
+//   - slug string
+func (_e *MockRepository_Expecter) SaveCategory(ctx interface{}, id interface{}, name interface{}, slug interface{}) *MockRepository_SaveCategory_Call {
+	return &MockRepository_SaveCategory_Call{Call: _e.mock.On("SaveCategory", ctx, id, name, slug)}
 }
 
-func (_c *MockRepository_SaveCategory_Call) Run(run func(ctx context.Context, id string, name string)) *MockRepository_SaveCategory_Call {
+func (_c *MockRepository_SaveCategory_Call) Run(run func(ctx context.Context, id string, name string, slug string)) *MockRepository_SaveCategory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -144,10 +273,15 @@ func (_c *MockRepository_SaveCategory_Call) Run(run func(ctx context.Context, id
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -158,7 +292,274 @@ func (_c *MockRepository_SaveCategory_Call) Return(err error) *MockRepository_Sa
 	return _c
 }
 
-func (_c *MockRepository_SaveCategory_Call) RunAndReturn(run func(ctx context.Context, id string, name string) error) *MockRepository_SaveCategory_Call {
+func (_c *MockRepository_SaveCategory_Call) RunAndReturn(run func(ctx context.Context, id string, name string, slug string) error) *MockRepository_SaveCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SlugByOldSlug provides a mock function for the type MockRepository
+func (_mock *MockRepository) SlugByOldSlug(ctx context.Context, oldSlug string) (string, error) {
+	ret := _mock.Called(ctx, oldSlug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SlugByOldSlug")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, oldSlug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, oldSlug)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, oldSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_SlugByOldSlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SlugByOldSlug'
+type MockRepository_SlugByOldSlug_Call struct {
+	*mock.Call
+}
+
+// SlugByOldSlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldSlug string
+func (_e *MockRepository_Expecter) SlugByOldSlug(ctx interface{}, oldSlug interface{}) *MockRepository_SlugByOldSlug_Call {
+	return &MockRepository_SlugByOldSlug_Call{Call: _e.mock.On("SlugByOldSlug", ctx, oldSlug)}
+}
+
+func (_c *MockRepository_SlugByOldSlug_Call) Run(run func(ctx context.Context, oldSlug string)) *MockRepository_SlugByOldSlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_SlugByOldSlug_Call) Return(s string, err error) *MockRepository_SlugByOldSlug_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockRepository_SlugByOldSlug_Call) RunAndReturn(run func(ctx context.Context, oldSlug string) (string, error)) *MockRepository_SlugByOldSlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SlugExists provides a mock function for the type MockRepository
+func (_mock *MockRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	ret := _mock.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SlugExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_SlugExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SlugExists'
+type MockRepository_SlugExists_Call struct {
+	*mock.Call
+}
+
+// SlugExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *MockRepository_Expecter) SlugExists(ctx interface{}, slug interface{}) *MockRepository_SlugExists_Call {
+	return &MockRepository_SlugExists_Call{Call: _e.mock.On("SlugExists", ctx, slug)}
+}
+
+func (_c *MockRepository_SlugExists_Call) Run(run func(ctx context.Context, slug string)) *MockRepository_SlugExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_SlugExists_Call) Return(b bool, err error) *MockRepository_SlugExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockRepository_SlugExists_Call) RunAndReturn(run func(ctx context.Context, slug string) (bool, error)) *MockRepository_SlugExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSlug provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateSlug(ctx context.Context, id string, slug string) (models.Category, error) {
+	ret := _mock.Called(ctx, id, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSlug")
+	}
+
+	var r0 models.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (models.Category, error)); ok {
+		return returnFunc(ctx, id, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) models.Category); ok {
+		r0 = returnFunc(ctx, id, slug)
+	} else {
+		r0 = ret.Get(0).(models.Category)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_UpdateSlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSlug'
+type MockRepository_UpdateSlug_Call struct {
+	*mock.Call
+}
+
+// UpdateSlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - slug string
+func (_e *MockRepository_Expecter) UpdateSlug(ctx interface{}, id interface{}, slug interface{}) *MockRepository_UpdateSlug_Call {
+	return &MockRepository_UpdateSlug_Call{Call: _e.mock.On("UpdateSlug", ctx, id, slug)}
+}
+
+func (_c *MockRepository_UpdateSlug_Call) Run(run func(ctx context.Context, id string, slug string)) *MockRepository_UpdateSlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateSlug_Call) Return(category models.Category, err error) *MockRepository_UpdateSlug_Call {
+	_c.Call.Return(category, err)
+	return _c
+}
+
+func (_c *MockRepository_UpdateSlug_Call) RunAndReturn(run func(ctx context.Context, id string, slug string) (models.Category, error)) *MockRepository_UpdateSlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTaxClass provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateTaxClass(ctx context.Context, id string, taxClass string) error {
+	ret := _mock.Called(ctx, id, taxClass)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTaxClass")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, taxClass)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_UpdateTaxClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTaxClass'
+type MockRepository_UpdateTaxClass_Call struct {
+	*mock.Call
+}
+
+// UpdateTaxClass is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - taxClass string
+func (_e *MockRepository_Expecter) UpdateTaxClass(ctx interface{}, id interface{}, taxClass interface{}) *MockRepository_UpdateTaxClass_Call {
+	return &MockRepository_UpdateTaxClass_Call{Call: _e.mock.On("UpdateTaxClass", ctx, id, taxClass)}
+}
+
+func (_c *MockRepository_UpdateTaxClass_Call) Run(run func(ctx context.Context, id string, taxClass string)) *MockRepository_UpdateTaxClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateTaxClass_Call) Return(err error) *MockRepository_UpdateTaxClass_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_UpdateTaxClass_Call) RunAndReturn(run func(ctx context.Context, id string, taxClass string) error) *MockRepository_UpdateTaxClass_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -252,6 +653,57 @@ func (_c *MockCash_AllCategories_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// DeleteCategories provides a mock function for the type MockCash
+func (_mock *MockCash) DeleteCategories(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategories")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCash_DeleteCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategories'
+type MockCash_DeleteCategories_Call struct {
+	*mock.Call
+}
+
+// DeleteCategories is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCash_Expecter) DeleteCategories(ctx interface{}) *MockCash_DeleteCategories_Call {
+	return &MockCash_DeleteCategories_Call{Call: _e.mock.On("DeleteCategories", ctx)}
+}
+
+func (_c *MockCash_DeleteCategories_Call) Run(run func(ctx context.Context)) *MockCash_DeleteCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCash_DeleteCategories_Call) Return(err error) *MockCash_DeleteCategories_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCash_DeleteCategories_Call) RunAndReturn(run func(ctx context.Context) error) *MockCash_DeleteCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveCategory provides a mock function for the type MockCash
 func (_mock *MockCash) SaveCategory(ctx context.Context, categoty models.Category) error {
 	ret := _mock.Called(ctx, categoty)
@@ -7,18 +7,27 @@ import (
 	"sort"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/tax"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/slug"
 	"github.com/google/uuid"
 )
 
 type Repository interface {
-	SaveCategory(ctx context.Context, id string, name string) error
+	SaveCategory(ctx context.Context, id string, name string, slug string) error
 	AllCategories(ctx context.Context) ([]models.Category, error)
+	CategoryByIdOrSlug(ctx context.Context, idOrSlug string) (models.Category, error)
+	SlugExists(ctx context.Context, slug string) (bool, error)
+	SlugByOldSlug(ctx context.Context, oldSlug string) (string, error)
+	UpdateSlug(ctx context.Context, id string, slug string) (models.Category, error)
+	CategoriesWithIdSlug(ctx context.Context) ([]models.Category, error)
+	UpdateTaxClass(ctx context.Context, id string, taxClass string) error
 }
 
 type Cash interface {
 	SaveCategory(ctx context.Context, categoty models.Category) error
 	AllCategories(ctx context.Context) ([]models.Category, error)
+	DeleteCategories(ctx context.Context) error
 }
 
 type Service struct {
@@ -36,13 +45,18 @@ func New(repository Repository, cash Cash) *Service {
 func (s *Service) CreateCategory(ctx context.Context, name string) (string, error) {
 	const op = "services.category.CreateCategory"
 
+	categorySlug, err := slug.Unique(ctx, name, "category", s.repository.SlugExists)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
 	id := uuid.NewString()
-	err := s.repository.SaveCategory(ctx, id, name)
+	err = s.repository.SaveCategory(ctx, id, name, categorySlug)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = s.cash.SaveCategory(ctx, models.Category{ID: id, Name: name})
+	err = s.cash.SaveCategory(ctx, models.Category{ID: id, Name: name, Slug: categorySlug})
 	if err != nil {
 		return id, fmt.Errorf("%s: %w", op, errs.ErrFailedToCash)
 	}
@@ -68,6 +82,108 @@ func (s *Service) AllCategories(ctx context.Context) ([]models.Category, error)
 	return categories, nil
 }
 
+func (s *Service) CategoryByIdOrSlug(ctx context.Context, idOrSlug string) (models.Category, error) {
+	const op = "services.category.CategoryByIdOrSlug"
+
+	category, err := s.repository.CategoryByIdOrSlug(ctx, idOrSlug)
+	if err != nil {
+		return models.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return category, nil
+}
+
+// CategoryRedirect returns current slug of category which had oldSlug before
+func (s *Service) CategoryRedirect(ctx context.Context, oldSlug string) (string, error) {
+	const op = "services.category.CategoryRedirect"
+
+	categorySlug, err := s.repository.SlugByOldSlug(ctx, oldSlug)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return categorySlug, nil
+}
+
+func (s *Service) ChangeCategorySlug(ctx context.Context, id string, newSlug string) error {
+	const op = "services.category.ChangeCategorySlug"
+
+	if !slug.IsValid(newSlug) {
+		return fmt.Errorf("%s: %w", op, errs.ErrInvalidSlug)
+	}
+
+	category, err := s.repository.UpdateSlug(ctx, id, newSlug)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.cash.SaveCategory(ctx, category)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, errs.ErrFailedToCash)
+	}
+
+	return nil
+}
+
+// BackfillSlugs gives readable slugs to categories that still use their id as slug
+// and refills cache, since categories cached before slugs were added have none
+func (s *Service) BackfillSlugs(ctx context.Context) error {
+	const op = "services.category.BackfillSlugs"
+
+	categories, err := s.repository.CategoriesWithIdSlug(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(categories) == 0 {
+		return nil
+	}
+
+	for _, category := range categories {
+		categorySlug, err := slug.Unique(ctx, category.Name, "category", s.repository.SlugExists)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = s.repository.UpdateSlug(ctx, category.ID, categorySlug)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = s.cash.DeleteCategories(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, errs.ErrFailedToCash)
+	}
+
+	categories, err = s.repository.AllCategories(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for _, category := range categories {
+		err = s.cash.SaveCategory(ctx, category)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, errs.ErrFailedToCash)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) ChangeCategoryTaxClass(ctx context.Context, id string, taxClass string) error {
+	const op = "services.category.ChangeCategoryTaxClass"
+
+	if !tax.IsValidClass(taxClass) {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongTaxClass)
+	}
+
+	err := s.repository.UpdateTaxClass(ctx, id, taxClass)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func sortCategories(arr []models.Category) {
 	slices.SortFunc(arr, func(a models.Category, b models.Category) int {
 		arr := []string{a.Name, b.Name}
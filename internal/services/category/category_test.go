@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	category_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/category/__mocks__"
 	"github.com/stretchr/testify/mock"
 )
@@ -76,10 +77,16 @@ func TestService_CreateCategory(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			mDb.EXPECT().SlugExists(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"fvewwecvn",
+			).Return(false, nil).Once()
+
 			mDb.EXPECT().SaveCategory(
 				mock.AnythingOfType("context.backgroundCtx"),
 				mock.AnythingOfType("string"),
 				mock.AnythingOfType("string"),
+				"fvewwecvn",
 			).Return(tt.wantDbMockErr)
 
 			mCash.EXPECT().SaveCategory(
@@ -106,3 +113,55 @@ func TestService_CreateCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestService_BackfillSlugs(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []models.Category
+		slugTaken  bool
+		wantSlug   string
+		wantErr    error
+	}{
+		{
+			name:       "good case",
+			categories: []models.Category{{ID: "id1", Name: "Кофе", Slug: "id1"}},
+			wantSlug:   "kofe",
+		},
+		{
+			name:       "slug taken case",
+			categories: []models.Category{{ID: "id1", Name: "Кофе", Slug: "id1"}},
+			slugTaken:  true,
+			wantSlug:   "kofe-2",
+		},
+		{
+			name:       "nothing to backfill case",
+			categories: []models.Category{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mDb := category_service_mocks.NewMockRepository(t)
+			mCash := category_service_mocks.NewMockCash(t)
+			ctx := context.Background()
+
+			mDb.EXPECT().CategoriesWithIdSlug(ctx).Return(tt.categories, nil).Once()
+			if tt.wantSlug != "" {
+				mDb.EXPECT().SlugExists(ctx, "kofe").Return(tt.slugTaken, nil).Once()
+				if tt.slugTaken {
+					mDb.EXPECT().SlugExists(ctx, "kofe-2").Return(false, nil).Once()
+				}
+				updated := models.Category{ID: "id1", Name: "Кофе", Slug: tt.wantSlug}
+				mDb.EXPECT().UpdateSlug(ctx, "id1", tt.wantSlug).Return(updated, nil).Once()
+				mCash.EXPECT().DeleteCategories(ctx).Return(nil).Once()
+				mDb.EXPECT().AllCategories(ctx).Return([]models.Category{updated}, nil).Once()
+				mCash.EXPECT().SaveCategory(ctx, updated).Return(nil).Once()
+			}
+
+			s := New(mDb, mCash)
+			err := s.BackfillSlugs(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.BackfillSlugs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package oidc_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/oidc"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+const (
+	// maxLoginLength is length of users login column
+	maxLoginLength = 50
+	// maxEmailLength is length of users email column
+	maxEmailLength = 50
+)
+
+type Provider interface {
+	AuthURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	Exchange(ctx context.Context, code, verifier, nonce string) (models.Identity, error)
+}
+
+type StateStore interface {
+	SaveState(ctx context.Context, state string, oidcState models.OIDCState) error
+	PopState(ctx context.Context, state string) (models.OIDCState, error)
+}
+
+type Repository interface {
+	UserIdByIdentity(ctx context.Context, provider, subject string) (string, error)
+	SaveIdentity(ctx context.Context, userId string, identity models.Identity) error
+	SaveUserWithIdentity(ctx context.Context, login string, identity models.Identity) (string, error)
+}
+
+type UserService interface {
+	UserByEmail(ctx context.Context, email string) (models.User, error)
+	LoginById(ctx context.Context, userId, guestId string, session models.Session) (models.LoginResult, error)
+}
+
+// Service logs users in with identity providers keyed by name
+type Service struct {
+	providers   map[string]Provider
+	stateStore  StateStore
+	repository  Repository
+	userService UserService
+}
+
+func New(
+	providers map[string]Provider,
+	stateStore StateStore,
+	repository Repository,
+	userService UserService,
+) *Service {
+	return &Service{
+		providers:   providers,
+		stateStore:  stateStore,
+		repository:  repository,
+		userService: userService,
+	}
+}
+
+// AuthURL starts login with provider and returns url of provider login page
+// and state, which must be kept by browser to complete login
+func (s *Service) AuthURL(ctx context.Context, providerName string) (string, string, error) {
+	const op = "services.oidc.AuthURL"
+
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", "", fmt.Errorf("%s: %w", op, errs.ErrProviderNotFound)
+	}
+
+	var tokens [3]string
+	for i := range tokens {
+		token, err := oidc.RandomToken()
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %w", op, err)
+		}
+		tokens[i] = token
+	}
+	state, nonce, verifier := tokens[0], tokens[1], tokens[2]
+
+	err := s.stateStore.SaveState(ctx, state, models.OIDCState{
+		Provider: providerName,
+		Nonce:    nonce,
+		Verifier: verifier,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	authURL, err := provider.AuthURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return authURL, state, nil
+}
+
+// Callback completes login with provider. Unknown identity is linked to user
+// with the same email, or new user is created, only if provider verified the
+// email. Session is created the same way as password login does
+func (s *Service) Callback(
+	ctx context.Context,
+	providerName string,
+	state string,
+	code string,
+	guestId string,
+	session models.Session,
+) (models.LoginResult, error) {
+	const op = "services.oidc.Callback"
+
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, errs.ErrProviderNotFound)
+	}
+
+	oidcState, err := s.stateStore.PopState(ctx, state)
+	if err != nil {
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if oidcState.Provider != providerName {
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, errs.ErrOIDCStateNotFound)
+	}
+
+	identity, err := provider.Exchange(ctx, code, oidcState.Verifier, oidcState.Nonce)
+	if err != nil {
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userId, err := s.repository.UserIdByIdentity(ctx, identity.Provider, identity.Subject)
+	if errors.Is(err, errs.ErrIdentityNotFound) {
+		userId, err = s.linkIdentity(ctx, identity)
+	}
+	if err != nil {
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := s.userService.LoginById(ctx, userId, guestId, session)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// linkIdentity links identity to user with its email or creates new user,
+// user with unverified email is not linked, so whoever registered it
+// can not get access to account of email owner
+func (s *Service) linkIdentity(ctx context.Context, identity models.Identity) (string, error) {
+	identity.Email = strings.ToLower(strings.TrimSpace(identity.Email))
+	if identity.Email == "" || !identity.EmailVerified {
+		return "", errs.ErrEmailNotVerify
+	}
+	if utf8.RuneCountInString(identity.Email) > maxEmailLength {
+		return "", errs.ErrEmailTooLong
+	}
+
+	user, err := s.userService.UserByEmail(ctx, identity.Email)
+	if err == nil {
+		if !user.IsEmailVerified {
+			return "", errs.ErrEmailNotVerify
+		}
+
+		err = s.repository.SaveIdentity(ctx, user.ID, identity)
+		if err != nil {
+			return "", err
+		}
+
+		return user.ID, nil
+	}
+	if !errors.Is(err, errs.ErrUserNotFound) {
+		return "", err
+	}
+
+	return s.repository.SaveUserWithIdentity(ctx, identityLogin(identity), identity)
+}
+
+// identityLogin returns identity name or email local part cut to login length
+func identityLogin(identity models.Identity) string {
+	login := strings.TrimSpace(identity.Name)
+	if login == "" {
+		login, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	runes := []rune(login)
+	if len(runes) > maxLoginLength {
+		runes = runes[:maxLoginLength]
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,271 @@
+package oidc_service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/oidc"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/oidc/oidctest"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	oidc_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/oidc/__mocks__"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_AuthURL(t *testing.T) {
+	s := New(map[string]Provider{}, nil, nil, nil)
+
+	_, _, err := s.AuthURL(context.Background(), "unknown")
+	if !errors.Is(err, errs.ErrProviderNotFound) {
+		t.Errorf("Service.AuthURL() error = %v, wantErr %v", err, errs.ErrProviderNotFound)
+	}
+}
+
+func TestService_Callback(t *testing.T) {
+	type fields struct {
+		repository  func(t *testing.T) Repository
+		userService func(t *testing.T) UserService
+	}
+
+	session := models.Session{IP: "127.0.0.1"}
+	verified := oidctest.User{Subject: "sub", Email: "user@mail.com", EmailVerified: true, Name: "user"}
+
+	identityNotFound := func(m *oidc_service_mocks.MockRepository) {
+		m.EXPECT().UserIdByIdentity(mock.Anything, "test", "sub").Return("", errs.ErrIdentityNotFound)
+	}
+
+	tests := []struct {
+		name     string
+		user     oidctest.User
+		provider string
+		fields   fields
+		want     models.LoginResult
+		wantErr  error
+	}{
+		{
+			name:     "linked identity",
+			user:     verified,
+			provider: "test",
+			fields: fields{
+				repository: func(t *testing.T) Repository {
+					m := oidc_service_mocks.NewMockRepository(t)
+					m.EXPECT().UserIdByIdentity(mock.Anything, "test", "sub").Return("id", nil)
+					return m
+				},
+				userService: func(t *testing.T) UserService {
+					m := oidc_service_mocks.NewMockUserService(t)
+					m.EXPECT().LoginById(mock.Anything, "id", "guest", session).
+						Return(models.LoginResult{SessionId: "session"}, nil)
+					return m
+				},
+			},
+			want: models.LoginResult{SessionId: "session"},
+		},
+		{
+			name:     "link to user with verified email",
+			user:     verified,
+			provider: "test",
+			fields: fields{
+				repository: func(t *testing.T) Repository {
+					m := oidc_service_mocks.NewMockRepository(t)
+					identityNotFound(m)
+					m.EXPECT().SaveIdentity(mock.Anything, "id", models.Identity{
+						Provider:      "test",
+						Subject:       "sub",
+						Email:         "user@mail.com",
+						EmailVerified: true,
+						Name:          "user",
+					}).Return(nil)
+					return m
+				},
+				userService: func(t *testing.T) UserService {
+					m := oidc_service_mocks.NewMockUserService(t)
+					m.EXPECT().UserByEmail(mock.Anything, "user@mail.com").
+						Return(models.User{ID: "id", IsEmailVerified: true}, nil)
+					m.EXPECT().LoginById(mock.Anything, "id", "guest", session).
+						Return(models.LoginResult{TwoFactorToken: "token"}, nil)
+					return m
+				},
+			},
+			want: models.LoginResult{TwoFactorToken: "token"},
+		},
+		{
+			name:     "user with unverified email",
+			user:     verified,
+			provider: "test",
+			fields: fields{
+				repository: func(t *testing.T) Repository {
+					m := oidc_service_mocks.NewMockRepository(t)
+					identityNotFound(m)
+					return m
+				},
+				userService: func(t *testing.T) UserService {
+					m := oidc_service_mocks.NewMockUserService(t)
+					m.EXPECT().UserByEmail(mock.Anything, "user@mail.com").
+						Return(models.User{ID: "id", IsEmailVerified: false}, nil)
+					return m
+				},
+			},
+			wantErr: errs.ErrEmailNotVerify,
+		},
+		{
+			name:     "provider email not verified",
+			user:     oidctest.User{Subject: "sub", Email: "user@mail.com"},
+			provider: "test",
+			fields: fields{
+				repository: func(t *testing.T) Repository {
+					m := oidc_service_mocks.NewMockRepository(t)
+					identityNotFound(m)
+					return m
+				},
+				userService: func(t *testing.T) UserService {
+					return oidc_service_mocks.NewMockUserService(t)
+				},
+			},
+			wantErr: errs.ErrEmailNotVerify,
+		},
+		{
+			name:     "new user",
+			user:     oidctest.User{Subject: "sub", Email: " New.User@Mail.com ", EmailVerified: true},
+			provider: "test",
+			fields: fields{
+				repository: func(t *testing.T) Repository {
+					m := oidc_service_mocks.NewMockRepository(t)
+					identityNotFound(m)
+					m.EXPECT().SaveUserWithIdentity(mock.Anything, "new.user", models.Identity{
+						Provider:      "test",
+						Subject:       "sub",
+						Email:         "new.user@mail.com",
+						EmailVerified: true,
+					}).Return("new-id", nil)
+					return m
+				},
+				userService: func(t *testing.T) UserService {
+					m := oidc_service_mocks.NewMockUserService(t)
+					m.EXPECT().UserByEmail(mock.Anything, "new.user@mail.com").
+						Return(models.User{}, errs.ErrUserNotFound)
+					m.EXPECT().LoginById(mock.Anything, "new-id", "guest", session).
+						Return(models.LoginResult{SessionId: "session"}, nil)
+					return m
+				},
+			},
+			want: models.LoginResult{SessionId: "session"},
+		},
+		{
+			name:     "too long email",
+			user:     oidctest.User{Subject: "sub", Email: strings.Repeat("a", 50) + "@mail.com", EmailVerified: true},
+			provider: "test",
+			fields: fields{
+				repository: func(t *testing.T) Repository {
+					m := oidc_service_mocks.NewMockRepository(t)
+					identityNotFound(m)
+					return m
+				},
+				userService: func(t *testing.T) UserService {
+					return oidc_service_mocks.NewMockUserService(t)
+				},
+			},
+			wantErr: errs.ErrEmailTooLong,
+		},
+		{
+			name:     "state of other provider",
+			user:     verified,
+			provider: "other",
+			fields: fields{
+				repository: func(t *testing.T) Repository {
+					return oidc_service_mocks.NewMockRepository(t)
+				},
+				userService: func(t *testing.T) UserService {
+					return oidc_service_mocks.NewMockUserService(t)
+				},
+			},
+			wantErr: errs.ErrOIDCStateNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := oidctest.NewProvider("client", "secret", tt.user)
+			defer idp.Close()
+
+			provider := oidc.New("test", idp.Client(), idp.Issuer(), "client", "secret", "https://shop/callback", nil)
+
+			states := make(map[string]models.OIDCState)
+			stateStore := oidc_service_mocks.NewMockStateStore(t)
+			stateStore.EXPECT().SaveState(mock.Anything, mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, state string, oidcState models.OIDCState) error {
+					states[state] = oidcState
+					return nil
+				})
+			stateStore.EXPECT().PopState(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, state string) (models.OIDCState, error) {
+					oidcState, ok := states[state]
+					if !ok {
+						return models.OIDCState{}, errs.ErrOIDCStateNotFound
+					}
+					delete(states, state)
+					return oidcState, nil
+				})
+
+			s := New(
+				map[string]Provider{"test": provider, "other": provider},
+				stateStore,
+				tt.fields.repository(t),
+				tt.fields.userService(t),
+			)
+
+			authURL, wantState, err := s.AuthURL(context.Background(), "test")
+			if err != nil {
+				t.Fatalf("Service.AuthURL() error = %v", err)
+			}
+			code, state, err := idp.Authorize(authURL)
+			if err != nil {
+				t.Fatalf("Authorize() error = %v", err)
+			}
+			if state != wantState {
+				t.Fatalf("Authorize() state = %v, want %v", state, wantState)
+			}
+
+			got, err := s.Callback(context.Background(), tt.provider, state, code, "guest", session)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.Callback() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Service.Callback() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentityLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity models.Identity
+		want     string
+	}{
+		{
+			name:     "name",
+			identity: models.Identity{Name: " User Name ", Email: "user@mail.com"},
+			want:     "User Name",
+		},
+		{
+			name:     "email local part",
+			identity: models.Identity{Email: "user@mail.com"},
+			want:     "user",
+		},
+		{
+			name:     "long name",
+			identity: models.Identity{Name: strings.Repeat("я", 60)},
+			want:     strings.Repeat("я", 50),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := identityLogin(tt.identity); got != tt.want {
+				t.Errorf("identityLogin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
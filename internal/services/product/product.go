@@ -3,8 +3,17 @@ package product_service
 import (
 	"context"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/tax"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/slug"
 	"github.com/google/uuid"
 )
 
@@ -12,30 +21,55 @@ type Repository interface {
 	SaveProduct(
 		ctx context.Context,
 		productId string,
+		slug string,
 		name string,
 		description string,
 		price float32,
+		weight int,
 		imageUrl string,
 		categoryIds []string,
+		attributes []models.AttributeValue,
 	) error
-	ProductsByCategoryId(ctx context.Context, categoryId string, page int) ([]models.ProductCard, error)
-	AllProducts(ctx context.Context, page int) ([]models.ProductCard, error)
+	ProductsByCategoryId(
+		ctx context.Context,
+		categoryId string,
+		page int,
+		filters []models.AttributeValue,
+	) ([]models.ProductCard, error)
+	AllProducts(ctx context.Context, page int, filters []models.AttributeValue) ([]models.ProductCard, error)
 	ProductById(ctx context.Context, productId string) (models.Product, error)
+	ProductBySlug(ctx context.Context, slug string) (models.Product, error)
+	SlugExists(ctx context.Context, slug string) (bool, error)
+	SlugByOldSlug(ctx context.Context, oldSlug string) (string, error)
+	UpdateSlug(ctx context.Context, productId string, slug string) error
+	ProductsWithIdSlug(ctx context.Context) ([]models.Product, error)
+	UpdatePrice(ctx context.Context, productId string, price float32, compareAtPrice float32) error
+	UpdateTaxClass(ctx context.Context, productId string, taxClass string) error
+	SavePriceChange(ctx context.Context, change models.PriceChange) (string, error)
+	ApplyPriceChanges(ctx context.Context) error
+	PriceHistory(ctx context.Context, productId string) ([]models.PriceRecord, error)
 }
 
-type S3 interface {
+type ImageStorage interface {
 	SaveImage(ctx context.Context, id string, image []byte) (string, error)
 }
 
+type AttributeProvider interface {
+	AttributesByCategoryIds(ctx context.Context, categoryIds []string) ([]models.Attribute, error)
+	AttributesByIds(ctx context.Context, ids []string) ([]models.Attribute, error)
+}
+
 type Service struct {
-	repository Repository
-	s3         S3
+	repository        Repository
+	imageStorage      ImageStorage
+	attributeProvider AttributeProvider
 }
 
-func New(repository Repository, s3 S3) *Service {
+func New(repository Repository, imageStorage ImageStorage, attributeProvider AttributeProvider) *Service {
 	return &Service{
-		repository: repository,
-		s3:         s3,
+		repository:        repository,
+		imageStorage:      imageStorage,
+		attributeProvider: attributeProvider,
 	}
 }
 
@@ -45,13 +79,30 @@ func (s *Service) CreateProduct(
 	name string,
 	description string,
 	price float32,
+	weight int,
 	image []byte,
+	attributes map[string]string,
 ) (string, error) {
 	const op = "services.product.CreateProduct"
 
+	schema, err := s.attributeProvider.AttributesByCategoryIds(ctx, categoryIds)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	attributeValues, err := validateAttributes(schema, attributes)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	productSlug, err := slug.Unique(ctx, name, "product", s.repository.SlugExists)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
 	productId := uuid.NewString()
 
-	imageUrl, err := s.s3.SaveImage(ctx, productId, image)
+	imageUrl, err := s.imageStorage.SaveImage(ctx, productId, image)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
@@ -59,11 +110,14 @@ func (s *Service) CreateProduct(
 	err = s.repository.SaveProduct(
 		ctx,
 		productId,
+		productSlug,
 		name,
 		description,
 		price,
+		weight,
 		imageUrl,
 		categoryIds,
+		attributeValues,
 	)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
@@ -72,11 +126,21 @@ func (s *Service) CreateProduct(
 	return productId, nil
 }
 
-func (s *Service) ProductsCard(ctx context.Context, categoryId string, page int) ([]models.ProductCard, error) {
-	const op = "services.product.CreateProduct"
+func (s *Service) ProductsCard(
+	ctx context.Context,
+	categoryId string,
+	page int,
+	filters map[string]string,
+) ([]models.ProductCard, error) {
+	const op = "services.product.ProductsCard"
+
+	attributeFilters, err := s.attributeFilters(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
 	if categoryId != "" {
-		products, err := s.repository.ProductsByCategoryId(ctx, categoryId, page)
+		products, err := s.repository.ProductsByCategoryId(ctx, categoryId, page, attributeFilters)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -84,7 +148,7 @@ func (s *Service) ProductsCard(ctx context.Context, categoryId string, page int)
 		return products, nil
 	}
 
-	products, err := s.repository.AllProducts(ctx, page)
+	products, err := s.repository.AllProducts(ctx, page, attributeFilters)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -102,3 +166,263 @@ func (s *Service) ProductById(ctx context.Context, productId string) (models.Pro
 
 	return product, nil
 }
+
+func (s *Service) ProductBySlug(ctx context.Context, productSlug string) (models.Product, error) {
+	const op = "services.product.ProductBySlug"
+
+	product, err := s.repository.ProductBySlug(ctx, productSlug)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return product, nil
+}
+
+// ProductRedirect returns current slug of product which had oldSlug before
+func (s *Service) ProductRedirect(ctx context.Context, oldSlug string) (string, error) {
+	const op = "services.product.ProductRedirect"
+
+	productSlug, err := s.repository.SlugByOldSlug(ctx, oldSlug)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return productSlug, nil
+}
+
+func (s *Service) ChangeProductSlug(ctx context.Context, productId string, newSlug string) error {
+	const op = "services.product.ChangeProductSlug"
+
+	if !slug.IsValid(newSlug) {
+		return fmt.Errorf("%s: %w", op, errs.ErrInvalidSlug)
+	}
+
+	err := s.repository.UpdateSlug(ctx, productId, newSlug)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// BackfillSlugs gives readable slugs to products that still use their id as slug
+func (s *Service) BackfillSlugs(ctx context.Context) error {
+	const op = "services.product.BackfillSlugs"
+
+	products, err := s.repository.ProductsWithIdSlug(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, product := range products {
+		productSlug, err := slug.Unique(ctx, product.Name, "product", s.repository.SlugExists)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = s.repository.UpdateSlug(ctx, product.ID, productSlug)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// UpdatePrice sets product price at once, zero compareAtPrice removes sale
+func (s *Service) UpdatePrice(ctx context.Context, productId string, price float32, compareAtPrice float32) error {
+	const op = "services.product.UpdatePrice"
+
+	if !isValidPrice(price, compareAtPrice) {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongPrice)
+	}
+
+	err := s.repository.UpdatePrice(ctx, productId, price, compareAtPrice)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ChangeProductTaxClass sets product tax class, empty taxClass
+// makes product taxed by class of its categories
+func (s *Service) ChangeProductTaxClass(ctx context.Context, productId string, taxClass string) error {
+	const op = "services.product.ChangeProductTaxClass"
+
+	if taxClass != "" && !tax.IsValidClass(taxClass) {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongTaxClass)
+	}
+
+	err := s.repository.UpdateTaxClass(ctx, productId, taxClass)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SchedulePriceChange saves price which will be set by ApplyPriceChanges at startsAt
+func (s *Service) SchedulePriceChange(
+	ctx context.Context,
+	productId string,
+	price float32,
+	compareAtPrice float32,
+	startsAt time.Time,
+) (string, error) {
+	const op = "services.product.SchedulePriceChange"
+
+	if !isValidPrice(price, compareAtPrice) || !startsAt.After(time.Now()) {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrWrongPrice)
+	}
+
+	id, err := s.repository.SavePriceChange(ctx, models.PriceChange{
+		ProductId:      productId,
+		Price:          price,
+		CompareAtPrice: compareAtPrice,
+		StartsAt:       startsAt,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// ApplyPriceChanges sets scheduled prices which start time has come, it is run by background job
+func (s *Service) ApplyPriceChanges(ctx context.Context) error {
+	const op = "services.product.ApplyPriceChanges"
+
+	err := s.repository.ApplyPriceChanges(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) PriceHistory(ctx context.Context, productId string) ([]models.PriceRecord, error) {
+	const op = "services.product.PriceHistory"
+
+	history, err := s.repository.PriceHistory(ctx, productId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return history, nil
+}
+
+func (s *Service) attributeFilters(ctx context.Context, filters map[string]string) ([]models.AttributeValue, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(filters))
+	for id := range filters {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	attributes, err := s.attributeProvider.AttributesByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	attributeTypes := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		attributeTypes[attribute.ID] = attribute.Type
+	}
+
+	attributeFilters := make([]models.AttributeValue, 0, len(ids))
+	for _, id := range ids {
+		attributeType, ok := attributeTypes[id]
+		if !ok {
+			return nil, errs.ErrAttributeNotFound
+		}
+
+		value, err := normalizeAttributeValue(attributeType, filters[id])
+		if err != nil {
+			return nil, err
+		}
+
+		attributeFilters = append(attributeFilters, models.AttributeValue{
+			AttributeId: id,
+			Type:        attributeType,
+			Value:       value,
+		})
+	}
+
+	return attributeFilters, nil
+}
+
+// validateAttributes checks attribute values against categories attributes schema
+// and returns values in canonical form
+func validateAttributes(schema []models.Attribute, values map[string]string) ([]models.AttributeValue, error) {
+	attributes := make(map[string]models.Attribute, len(schema))
+	for _, attribute := range schema {
+		attributes[attribute.ID] = attribute
+	}
+
+	attributeValues := make([]models.AttributeValue, 0, len(values))
+	for id, value := range values {
+		attribute, ok := attributes[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errs.ErrUnknownAttribute, id)
+		}
+
+		value, err := normalizeAttributeValue(attribute.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, attribute.Name)
+		}
+
+		attributeValues = append(attributeValues, models.AttributeValue{
+			AttributeId: attribute.ID,
+			Name:        attribute.Name,
+			Type:        attribute.Type,
+			Unit:        attribute.Unit,
+			Value:       value,
+		})
+	}
+
+	for _, attribute := range schema {
+		if _, ok := values[attribute.ID]; attribute.IsRequired && !ok {
+			return nil, fmt.Errorf("%w: %s", errs.ErrMissingAttribute, attribute.Name)
+		}
+	}
+
+	slices.SortFunc(attributeValues, func(a, b models.AttributeValue) int {
+		return strings.Compare(a.AttributeId, b.AttributeId)
+	})
+
+	return attributeValues, nil
+}
+
+func normalizeAttributeValue(attributeType string, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errs.ErrInvalidAttribute
+	}
+
+	switch attributeType {
+	case consts.AttributeTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return "", errs.ErrInvalidAttribute
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case consts.AttributeTypeBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", errs.ErrInvalidAttribute
+		}
+		return strconv.FormatBool(b), nil
+	default:
+		return value, nil
+	}
+}
+
+// isValidPrice checks that price is positive and compare-at price
+// is either not set or greater than price
+func isValidPrice(price float32, compareAtPrice float32) bool {
+	return price > 0 && (compareAtPrice == 0 || compareAtPrice > price)
+}
@@ -0,0 +1,350 @@
+package product_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	product_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/product/__mocks__"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_CreateProduct(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		categoryIds []string
+		name        string
+		description string
+		price       float32
+		weight      int
+		image       []byte
+		attributes  map[string]string
+	}
+
+	schema := []models.Attribute{
+		{
+			ID:         "weight",
+			CategoryId: "category",
+			Name:       "weight",
+			Type:       consts.AttributeTypeNumber,
+			Unit:       "kg",
+			IsRequired: true,
+		},
+		{
+			ID:         "wireless",
+			CategoryId: "category",
+			Name:       "wireless",
+			Type:       consts.AttributeTypeBool,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		wantAttributes []models.AttributeValue
+		wantErr        error
+	}{
+		{
+			name: "good case",
+			args: args{
+				ctx:         context.Background(),
+				categoryIds: []string{"category"},
+				name:        "headphones",
+				description: "headphones",
+				price:       100,
+				weight:      250,
+				image:       []byte("image"),
+				attributes: map[string]string{
+					"weight":   " 0.250 ",
+					"wireless": "1",
+				},
+			},
+			wantAttributes: []models.AttributeValue{
+				{AttributeId: "weight", Name: "weight", Type: consts.AttributeTypeNumber, Unit: "kg", Value: "0.25"},
+				{AttributeId: "wireless", Name: "wireless", Type: consts.AttributeTypeBool, Value: "true"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unknown attribute case",
+			args: args{
+				ctx:         context.Background(),
+				categoryIds: []string{"category"},
+				attributes: map[string]string{
+					"weight": "1",
+					"color":  "red",
+				},
+			},
+			wantErr: errs.ErrUnknownAttribute,
+		},
+		{
+			name: "invalid number case",
+			args: args{
+				ctx:         context.Background(),
+				categoryIds: []string{"category"},
+				attributes: map[string]string{
+					"weight": "heavy",
+				},
+			},
+			wantErr: errs.ErrInvalidAttribute,
+		},
+		{
+			name: "not finite number case",
+			args: args{
+				ctx:         context.Background(),
+				categoryIds: []string{"category"},
+				attributes: map[string]string{
+					"weight": "NaN",
+				},
+			},
+			wantErr: errs.ErrInvalidAttribute,
+		},
+		{
+			name: "missing required attribute case",
+			args: args{
+				ctx:         context.Background(),
+				categoryIds: []string{"category"},
+				attributes: map[string]string{
+					"wireless": "false",
+				},
+			},
+			wantErr: errs.ErrMissingAttribute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := product_service_mocks.NewMockRepository(t)
+			mImageStorage := product_service_mocks.NewMockImageStorage(t)
+			mAttributeProvider := product_service_mocks.NewMockAttributeProvider(t)
+
+			mAttributeProvider.EXPECT().AttributesByCategoryIds(
+				mock.AnythingOfType("context.backgroundCtx"),
+				tt.args.categoryIds,
+			).Return(schema, nil)
+
+			if tt.wantErr == nil {
+				mRepository.EXPECT().SlugExists(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"headphones",
+				).Return(true, nil)
+				mRepository.EXPECT().SlugExists(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"headphones-2",
+				).Return(false, nil)
+
+				mImageStorage.EXPECT().SaveImage(
+					mock.AnythingOfType("context.backgroundCtx"),
+					mock.AnythingOfType("string"),
+					tt.args.image,
+				).Return("url", nil)
+
+				mRepository.EXPECT().SaveProduct(
+					mock.AnythingOfType("context.backgroundCtx"),
+					mock.AnythingOfType("string"),
+					"headphones-2",
+					tt.args.name,
+					tt.args.description,
+					tt.args.price,
+					tt.args.weight,
+					"url",
+					tt.args.categoryIds,
+					tt.wantAttributes,
+				).Return(nil)
+			}
+
+			s := &Service{
+				repository:        mRepository,
+				imageStorage:      mImageStorage,
+				attributeProvider: mAttributeProvider,
+			}
+			got, err := s.CreateProduct(
+				tt.args.ctx,
+				tt.args.categoryIds,
+				tt.args.name,
+				tt.args.description,
+				tt.args.price,
+				tt.args.weight,
+				tt.args.image,
+				tt.args.attributes,
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.CreateProduct() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got == "" && tt.wantErr == nil {
+				t.Error("Service.CreateProduct() = id is empty")
+			}
+		})
+	}
+}
+
+func TestService_ChangeProductSlug(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		productId string
+		slug      string
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantMockCall bool
+		wantMockErr  error
+		wantErr      error
+	}{
+		{
+			name: "good case",
+			args: args{
+				ctx:       context.Background(),
+				productId: "id",
+				slug:      "new-headphones",
+			},
+			wantMockCall: true,
+			wantMockErr:  nil,
+			wantErr:      nil,
+		},
+		{
+			name: "invalid slug case",
+			args: args{
+				ctx:       context.Background(),
+				productId: "id",
+				slug:      "Новые наушники",
+			},
+			wantMockCall: false,
+			wantErr:      errs.ErrInvalidSlug,
+		},
+		{
+			name: "slug already exists case",
+			args: args{
+				ctx:       context.Background(),
+				productId: "id",
+				slug:      "headphones",
+			},
+			wantMockCall: true,
+			wantMockErr:  errs.ErrSlugAlreadyExists,
+			wantErr:      errs.ErrSlugAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := product_service_mocks.NewMockRepository(t)
+
+			if tt.wantMockCall {
+				mRepository.EXPECT().UpdateSlug(
+					mock.AnythingOfType("context.backgroundCtx"),
+					tt.args.productId,
+					tt.args.slug,
+				).Return(tt.wantMockErr)
+			}
+
+			s := &Service{
+				repository: mRepository,
+			}
+			if err := s.ChangeProductSlug(tt.args.ctx, tt.args.productId, tt.args.slug); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ChangeProductSlug() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_SchedulePriceChange(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		productId      string
+		price          float32
+		compareAtPrice float32
+		startsAt       time.Time
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantMockCall bool
+		wantMockErr  error
+		wantErr      error
+	}{
+		{
+			name: "good case",
+			args: args{
+				ctx:            context.Background(),
+				productId:      "id",
+				price:          900,
+				compareAtPrice: 1200,
+				startsAt:       time.Now().Add(time.Hour),
+			},
+			wantMockCall: true,
+			wantMockErr:  nil,
+			wantErr:      nil,
+		},
+		{
+			name: "compare at price less than price case",
+			args: args{
+				ctx:            context.Background(),
+				productId:      "id",
+				price:          900,
+				compareAtPrice: 800,
+				startsAt:       time.Now().Add(time.Hour),
+			},
+			wantMockCall: false,
+			wantErr:      errs.ErrWrongPrice,
+		},
+		{
+			name: "start in the past case",
+			args: args{
+				ctx:       context.Background(),
+				productId: "id",
+				price:     900,
+				startsAt:  time.Now().Add(-time.Hour),
+			},
+			wantMockCall: false,
+			wantErr:      errs.ErrWrongPrice,
+		},
+		{
+			name: "product not found case",
+			args: args{
+				ctx:       context.Background(),
+				productId: "id",
+				price:     900,
+				startsAt:  time.Now().Add(time.Hour),
+			},
+			wantMockCall: true,
+			wantMockErr:  errs.ErrProductNotFound,
+			wantErr:      errs.ErrProductNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := product_service_mocks.NewMockRepository(t)
+
+			if tt.wantMockCall {
+				mRepository.EXPECT().SavePriceChange(
+					mock.AnythingOfType("context.backgroundCtx"),
+					models.PriceChange{
+						ProductId:      tt.args.productId,
+						Price:          tt.args.price,
+						CompareAtPrice: tt.args.compareAtPrice,
+						StartsAt:       tt.args.startsAt,
+					},
+				).Return("change", tt.wantMockErr)
+			}
+
+			s := &Service{
+				repository: mRepository,
+			}
+			_, err := s.SchedulePriceChange(
+				tt.args.ctx,
+				tt.args.productId,
+				tt.args.price,
+				tt.args.compareAtPrice,
+				tt.args.startsAt,
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.SchedulePriceChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package promo_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+type Repository interface {
+	SavePromoCode(ctx context.Context, promo models.PromoCode) (string, error)
+	PromoCodes(ctx context.Context, page int) ([]models.PromoCode, error)
+	PromoCodeByCode(ctx context.Context, code string) (models.PromoCode, error)
+	DeletePromoCode(ctx context.Context, id string) error
+	UsageCountByUser(ctx context.Context, promoCodeId, userId string) (int, error)
+	SetCartPromoCode(ctx context.Context, userId, promoCodeId string) error
+	DeleteCartPromoCode(ctx context.Context, userId string) error
+	CartPromoCode(ctx context.Context, userId string) (models.PromoCode, error)
+}
+
+type CartProvider interface {
+	CartByUserId(ctx context.Context, userId string) (models.Cart, error)
+}
+
+type Service struct {
+	repository   Repository
+	cartProvider CartProvider
+}
+
+func New(repository Repository, cartProvider CartProvider) *Service {
+	return &Service{
+		repository:   repository,
+		cartProvider: cartProvider,
+	}
+}
+
+func (s *Service) CreatePromoCode(ctx context.Context, promo models.PromoCode) (string, error) {
+	const op = "services.promo.CreatePromoCode"
+
+	promo.Code = normalizeCode(promo.Code)
+	if !isValidPromoCode(promo) {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrWrongPromoCode)
+	}
+
+	id, err := s.repository.SavePromoCode(ctx, promo)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) PromoCodes(ctx context.Context, page int) ([]models.PromoCode, error) {
+	const op = "services.promo.PromoCodes"
+
+	promoCodes, err := s.repository.PromoCodes(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promoCodes, nil
+}
+
+func (s *Service) DeletePromoCode(ctx context.Context, id string) error {
+	const op = "services.promo.DeletePromoCode"
+
+	err := s.repository.DeletePromoCode(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ApplyPromoCode checks promo code against user cart and attaches it to cart,
+// previously applied promo code is replaced
+func (s *Service) ApplyPromoCode(ctx context.Context, userId, code string) error {
+	const op = "services.promo.ApplyPromoCode"
+
+	promo, err := s.repository.PromoCodeByCode(ctx, normalizeCode(code))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	cart, err := s.cartProvider.CartByUserId(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(cart.Items) == 0 {
+		return fmt.Errorf("%s: %w", op, errs.ErrCartIsEmpty)
+	}
+
+	_, err = s.adjustment(ctx, promo, cart)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.repository.SetCartPromoCode(ctx, userId, promo.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) RemovePromoCode(ctx context.Context, userId string) error {
+	const op = "services.promo.RemovePromoCode"
+
+	err := s.repository.DeleteCartPromoCode(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Adjustments returns discount of promo code applied to cart. Promo code
+// which is no longer applicable to cart gives no discount
+func (s *Service) Adjustments(ctx context.Context, cart models.Cart) ([]models.Adjustment, error) {
+	const op = "services.promo.Adjustments"
+
+	promo, err := s.repository.CartPromoCode(ctx, cart.UserId)
+	if err != nil {
+		if errors.Is(err, errs.ErrPromoCodeNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	adjustment, err := s.adjustment(ctx, promo, cart)
+	if err != nil {
+		if isNotApplicable(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return []models.Adjustment{adjustment}, nil
+}
+
+func (s *Service) adjustment(ctx context.Context, promo models.PromoCode, cart models.Cart) (models.Adjustment, error) {
+	now := time.Now()
+	if !promo.IsActive ||
+		(promo.StartsAt != nil && now.Before(*promo.StartsAt)) ||
+		(promo.EndsAt != nil && now.After(*promo.EndsAt)) {
+		return models.Adjustment{}, errs.ErrPromoCodeExpired
+	}
+
+	if promo.UsageLimit > 0 && promo.UsedCount >= promo.UsageLimit {
+		return models.Adjustment{}, errs.ErrPromoCodeUsedUp
+	}
+
+	if promo.PerUserLimit > 0 {
+		count, err := s.repository.UsageCountByUser(ctx, promo.ID, cart.UserId)
+		if err != nil {
+			return models.Adjustment{}, err
+		}
+		if count >= promo.PerUserLimit {
+			return models.Adjustment{}, errs.ErrPromoCodeUsedUp
+		}
+	}
+
+	amount, err := discount(promo, cart.Items)
+	if err != nil {
+		return models.Adjustment{}, err
+	}
+
+	return models.Adjustment{
+		Source:       consts.AdjustmentPromoCode,
+		SourceId:     promo.ID,
+		Name:         promo.Code,
+		Amount:       amount,
+		FreeShipping: promo.Type == consts.PromoTypeFreeShip,
+	}, nil
+}
+
+// discount counts promo code discount, only products from promo code
+// categories are discounted if categories are set
+func discount(promo models.PromoCode, items []models.CartItem) (float32, error) {
+	var subtotal, eligible float32
+	for _, item := range items {
+		price := item.Product.Price * float32(item.Quantity)
+		subtotal += price
+		if len(promo.CategoryIds) == 0 || slices.ContainsFunc(item.CategoryIds, func(id string) bool {
+			return slices.Contains(promo.CategoryIds, id)
+		}) {
+			eligible += price
+		}
+	}
+
+	if subtotal < promo.MinCartPrice {
+		return 0, errs.ErrPromoCodeMinPrice
+	}
+	if eligible == 0 {
+		return 0, errs.ErrPromoCodeNotApplied
+	}
+
+	switch promo.Type {
+	case consts.PromoTypePercent:
+		return eligible * promo.Value / 100, nil
+	case consts.PromoTypeFixed:
+		return min(promo.Value, eligible), nil
+	default:
+		return 0, nil
+	}
+}
+
+func isValidPromoCode(promo models.PromoCode) bool {
+	if promo.Code == "" || promo.MinCartPrice < 0 || promo.UsageLimit < 0 || promo.PerUserLimit < 0 {
+		return false
+	}
+	if promo.StartsAt != nil && promo.EndsAt != nil && !promo.EndsAt.After(*promo.StartsAt) {
+		return false
+	}
+
+	switch promo.Type {
+	case consts.PromoTypePercent:
+		return promo.Value > 0 && promo.Value <= 100
+	case consts.PromoTypeFixed:
+		return promo.Value > 0
+	case consts.PromoTypeFreeShip:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNotApplicable(err error) bool {
+	return errors.Is(err, errs.ErrPromoCodeExpired) ||
+		errors.Is(err, errs.ErrPromoCodeUsedUp) ||
+		errors.Is(err, errs.ErrPromoCodeMinPrice) ||
+		errors.Is(err, errs.ErrPromoCodeNotApplied)
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
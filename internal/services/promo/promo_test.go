@@ -0,0 +1,248 @@
+package promo_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	promo_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/promo/__mocks__"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ApplyPromoCode(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	cart := models.Cart{
+		UserId: "user",
+		Items: []models.CartItem{
+			{
+				Product:     models.ProductCard{ID: "product", Price: 100},
+				Quantity:    2,
+				CategoryIds: []string{"headphones"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		code      string
+		promo     models.PromoCode
+		userUsage int
+		wantSave  bool
+		wantErr   error
+	}{
+		{
+			name: "good case",
+			code: " sale10 ",
+			promo: models.PromoCode{
+				ID:       "promo",
+				Code:     "SALE10",
+				Type:     consts.PromoTypePercent,
+				Value:    10,
+				IsActive: true,
+			},
+			wantSave: true,
+			wantErr:  nil,
+		},
+		{
+			name: "expired case",
+			code: "SALE10",
+			promo: models.PromoCode{
+				ID:       "promo",
+				Code:     "SALE10",
+				Type:     consts.PromoTypePercent,
+				Value:    10,
+				EndsAt:   &past,
+				IsActive: true,
+			},
+			wantSave: false,
+			wantErr:  errs.ErrPromoCodeExpired,
+		},
+		{
+			name: "min cart price case",
+			code: "SALE10",
+			promo: models.PromoCode{
+				ID:           "promo",
+				Code:         "SALE10",
+				Type:         consts.PromoTypeFixed,
+				Value:        10,
+				MinCartPrice: 500,
+				IsActive:     true,
+			},
+			wantSave: false,
+			wantErr:  errs.ErrPromoCodeMinPrice,
+		},
+		{
+			name: "other category case",
+			code: "SALE10",
+			promo: models.PromoCode{
+				ID:          "promo",
+				Code:        "SALE10",
+				Type:        consts.PromoTypePercent,
+				Value:       10,
+				IsActive:    true,
+				CategoryIds: []string{"speakers"},
+			},
+			wantSave: false,
+			wantErr:  errs.ErrPromoCodeNotApplied,
+		},
+		{
+			name: "user limit case",
+			code: "SALE10",
+			promo: models.PromoCode{
+				ID:           "promo",
+				Code:         "SALE10",
+				Type:         consts.PromoTypePercent,
+				Value:        10,
+				PerUserLimit: 1,
+				IsActive:     true,
+			},
+			userUsage: 1,
+			wantSave:  false,
+			wantErr:   errs.ErrPromoCodeUsedUp,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := promo_service_mocks.NewMockRepository(t)
+			mCartProvider := promo_service_mocks.NewMockCartProvider(t)
+
+			mRepository.EXPECT().PromoCodeByCode(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"SALE10",
+			).Return(tt.promo, nil)
+
+			mCartProvider.EXPECT().CartByUserId(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+			).Return(cart, nil)
+
+			if tt.promo.PerUserLimit > 0 {
+				mRepository.EXPECT().UsageCountByUser(
+					mock.AnythingOfType("context.backgroundCtx"),
+					tt.promo.ID,
+					"user",
+				).Return(tt.userUsage, nil)
+			}
+
+			if tt.wantSave {
+				mRepository.EXPECT().SetCartPromoCode(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					tt.promo.ID,
+				).Return(nil)
+			}
+
+			s := New(mRepository, mCartProvider)
+			err := s.ApplyPromoCode(context.Background(), "user", tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ApplyPromoCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_Adjustments(t *testing.T) {
+	cart := models.Cart{
+		UserId: "user",
+		Items: []models.CartItem{
+			{
+				Product:     models.ProductCard{ID: "headphones", Price: 100},
+				Quantity:    2,
+				CategoryIds: []string{"headphones"},
+			},
+			{
+				Product:     models.ProductCard{ID: "speaker", Price: 300},
+				Quantity:    1,
+				CategoryIds: []string{"speakers"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		promo        models.PromoCode
+		promoErr     error
+		wantAmount   float32
+		wantFreeShip bool
+		wantEmpty    bool
+	}{
+		{
+			name: "percent in category case",
+			promo: models.PromoCode{
+				ID:          "promo",
+				Type:        consts.PromoTypePercent,
+				Value:       10,
+				IsActive:    true,
+				CategoryIds: []string{"headphones"},
+			},
+			wantAmount: 20,
+		},
+		{
+			name: "fixed more than cart case",
+			promo: models.PromoCode{
+				ID:       "promo",
+				Type:     consts.PromoTypeFixed,
+				Value:    1000,
+				IsActive: true,
+			},
+			wantAmount: 500,
+		},
+		{
+			name: "free shipping case",
+			promo: models.PromoCode{
+				ID:       "promo",
+				Type:     consts.PromoTypeFreeShip,
+				IsActive: true,
+			},
+			wantAmount:   0,
+			wantFreeShip: true,
+		},
+		{
+			name: "used up case",
+			promo: models.PromoCode{
+				ID:         "promo",
+				Type:       consts.PromoTypeFixed,
+				Value:      100,
+				UsageLimit: 10,
+				UsedCount:  10,
+				IsActive:   true,
+			},
+			wantEmpty: true,
+		},
+		{
+			name:      "no promo code case",
+			promoErr:  errs.ErrPromoCodeNotFound,
+			wantEmpty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := promo_service_mocks.NewMockRepository(t)
+
+			mRepository.EXPECT().CartPromoCode(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+			).Return(tt.promo, tt.promoErr)
+
+			s := New(mRepository, nil)
+			adjustments, err := s.Adjustments(context.Background(), cart)
+			require.NoError(t, err)
+
+			if tt.wantEmpty {
+				require.Empty(t, adjustments)
+				return
+			}
+
+			require.Len(t, adjustments, 1)
+			require.Equal(t, consts.AdjustmentPromoCode, adjustments[0].Source)
+			require.Equal(t, tt.wantAmount, adjustments[0].Amount)
+			require.Equal(t, tt.wantFreeShip, adjustments[0].FreeShipping)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package promotion_service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+type Repository interface {
+	SavePromotion(ctx context.Context, promotion models.Promotion) (string, error)
+	UpdatePromotion(ctx context.Context, promotion models.Promotion) error
+	DeletePromotion(ctx context.Context, id string) error
+	Promotions(ctx context.Context, page int) ([]models.Promotion, error)
+	ActivePromotions(ctx context.Context) ([]models.Promotion, error)
+}
+
+type Service struct {
+	repository Repository
+}
+
+func New(repository Repository) *Service {
+	return &Service{
+		repository: repository,
+	}
+}
+
+func (s *Service) CreatePromotion(ctx context.Context, promotion models.Promotion) (string, error) {
+	const op = "services.promotion.CreatePromotion"
+
+	if !isValidPromotion(promotion) {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrWrongPromotion)
+	}
+
+	id, err := s.repository.SavePromotion(ctx, promotion)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) UpdatePromotion(ctx context.Context, promotion models.Promotion) error {
+	const op = "services.promotion.UpdatePromotion"
+
+	if !isValidPromotion(promotion) {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongPromotion)
+	}
+
+	err := s.repository.UpdatePromotion(ctx, promotion)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeletePromotion(ctx context.Context, id string) error {
+	const op = "services.promotion.DeletePromotion"
+
+	err := s.repository.DeletePromotion(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) Promotions(ctx context.Context, page int) ([]models.Promotion, error) {
+	const op = "services.promotion.Promotions"
+
+	promotions, err := s.repository.Promotions(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promotions, nil
+}
+
+// Adjustments evaluates active promotions against cart
+func (s *Service) Adjustments(ctx context.Context, cart models.Cart) ([]models.Adjustment, error) {
+	const op = "services.promotion.Adjustments"
+
+	promotions, err := s.repository.ActivePromotions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return evaluate(promotions, cart), nil
+}
+
+// evaluate applies promotions in priority order. Every promotion is evaluated
+// against the whole cart, applied non stackable promotion stops evaluation
+// and its adjustments are exclusive, so promo codes are not applied either
+func evaluate(promotions []models.Promotion, cart models.Cart) []models.Adjustment {
+	promotions = slices.Clone(promotions)
+	slices.SortStableFunc(promotions, func(a, b models.Promotion) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+
+	adjustments := make([]models.Adjustment, 0)
+	for _, promotion := range promotions {
+		var applied []models.Adjustment
+		switch promotion.Type {
+		case consts.PromotionTypeNForM:
+			applied = nForM(promotion, cart.Items)
+		case consts.PromotionTypePercent:
+			applied = orderPercent(promotion, cart.Items)
+		case consts.PromotionTypeGift:
+			applied = gift(promotion, cart.Items)
+		}
+		if len(applied) == 0 {
+			continue
+		}
+
+		if !promotion.Stackable {
+			for i := range applied {
+				applied[i].Exclusive = true
+			}
+			adjustments = append(adjustments, applied...)
+			break
+		}
+		adjustments = append(adjustments, applied...)
+	}
+
+	return adjustments
+}
+
+// nForM makes the cheapest products of every BuyQuantity products
+// from promotion category free, so customer pays for PayQuantity of them
+func nForM(promotion models.Promotion, items []models.CartItem) []models.Adjustment {
+	type unit struct {
+		productId string
+		price     float32
+	}
+
+	units := make([]unit, 0)
+	for _, item := range items {
+		if !slices.Contains(item.CategoryIds, promotion.CategoryId) {
+			continue
+		}
+		for range item.Quantity {
+			units = append(units, unit{productId: item.Product.ID, price: item.Product.Price})
+		}
+	}
+	slices.SortStableFunc(units, func(a, b unit) int {
+		return cmp.Compare(b.price, a.price)
+	})
+
+	amounts := make(map[string]float32)
+	for i := 0; i+promotion.BuyQuantity <= len(units); i += promotion.BuyQuantity {
+		for _, unit := range units[i+promotion.PayQuantity : i+promotion.BuyQuantity] {
+			amounts[unit.productId] += unit.price
+		}
+	}
+
+	adjustments := make([]models.Adjustment, 0, len(amounts))
+	for _, item := range items {
+		if amount, ok := amounts[item.Product.ID]; ok {
+			adjustments = append(adjustments, adjustment(promotion, item.Product.ID, amount))
+		}
+	}
+
+	return adjustments
+}
+
+func orderPercent(promotion models.Promotion, items []models.CartItem) []models.Adjustment {
+	var price float32
+	for _, item := range items {
+		price += item.Product.Price * float32(item.Quantity)
+	}
+	if price == 0 || price < promotion.MinCartPrice {
+		return nil
+	}
+
+	return []models.Adjustment{adjustment(promotion, "", price*promotion.Value/100)}
+}
+
+// gift makes one GiftProductId product free for every ProductId product in cart
+func gift(promotion models.Promotion, items []models.CartItem) []models.Adjustment {
+	var bought int
+	var giftItem *models.CartItem
+	for i, item := range items {
+		if item.Product.ID == promotion.ProductId {
+			bought = item.Quantity
+		}
+		if item.Product.ID == promotion.GiftProductId {
+			giftItem = &items[i]
+		}
+	}
+	if bought == 0 || giftItem == nil {
+		return nil
+	}
+
+	free := min(bought, giftItem.Quantity)
+	if promotion.ProductId == promotion.GiftProductId {
+		free = giftItem.Quantity / 2
+	}
+	if free == 0 {
+		return nil
+	}
+
+	return []models.Adjustment{
+		adjustment(promotion, giftItem.Product.ID, giftItem.Product.Price*float32(free)),
+	}
+}
+
+func adjustment(promotion models.Promotion, productId string, amount float32) models.Adjustment {
+	return models.Adjustment{
+		Source:    consts.AdjustmentPromotion,
+		SourceId:  promotion.ID,
+		Name:      promotion.Name,
+		ProductId: productId,
+		Amount:    amount,
+	}
+}
+
+func isValidPromotion(promotion models.Promotion) bool {
+	if promotion.Name == "" || promotion.MinCartPrice < 0 {
+		return false
+	}
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return false
+	}
+
+	switch promotion.Type {
+	case consts.PromotionTypeNForM:
+		return promotion.CategoryId != "" &&
+			promotion.PayQuantity > 0 &&
+			promotion.BuyQuantity > promotion.PayQuantity
+	case consts.PromotionTypePercent:
+		return promotion.Value > 0 && promotion.Value <= 100
+	case consts.PromotionTypeGift:
+		return promotion.ProductId != "" && promotion.GiftProductId != ""
+	default:
+		return false
+	}
+}
@@ -0,0 +1,148 @@
+package promotion_service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	promotion_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/promotion/__mocks__"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Adjustments(t *testing.T) {
+	cart := models.Cart{
+		UserId: "user",
+		Items: []models.CartItem{
+			{
+				Product:     models.ProductCard{ID: "headphones", Price: 1000},
+				Quantity:    2,
+				CategoryIds: []string{"audio"},
+			},
+			{
+				Product:     models.ProductCard{ID: "cable", Price: 200},
+				Quantity:    2,
+				CategoryIds: []string{"audio", "accessories"},
+			},
+			{
+				Product:     models.ProductCard{ID: "speaker", Price: 3000},
+				Quantity:    1,
+				CategoryIds: []string{"speakers"},
+			},
+		},
+	}
+
+	threeForTwo := models.Promotion{
+		ID:          "three-for-two",
+		Name:        "3 for 2",
+		Type:        consts.PromotionTypeNForM,
+		Priority:    10,
+		Stackable:   true,
+		CategoryId:  "audio",
+		BuyQuantity: 3,
+		PayQuantity: 2,
+	}
+	tenPercent := models.Promotion{
+		ID:           "ten-percent",
+		Name:         "10% over 5000",
+		Type:         consts.PromotionTypePercent,
+		Priority:     5,
+		Stackable:    true,
+		MinCartPrice: 5000,
+		Value:        10,
+	}
+	gift := models.Promotion{
+		ID:            "gift",
+		Name:          "cable for speaker",
+		Type:          consts.PromotionTypeGift,
+		Priority:      1,
+		Stackable:     true,
+		ProductId:     "speaker",
+		GiftProductId: "cable",
+	}
+
+	tests := []struct {
+		name       string
+		promotions []models.Promotion
+		want       map[string]float32
+	}{
+		{
+			name:       "n for m case",
+			promotions: []models.Promotion{threeForTwo},
+			want:       map[string]float32{"three-for-two:cable": 200},
+		},
+		{
+			name:       "order percent case",
+			promotions: []models.Promotion{tenPercent},
+			want:       map[string]float32{"ten-percent:": 540},
+		},
+		{
+			name: "order percent less than minimum case",
+			promotions: []models.Promotion{
+				{
+					ID:           "big",
+					Name:         "10% over 10000",
+					Type:         consts.PromotionTypePercent,
+					Stackable:    true,
+					MinCartPrice: 10000,
+					Value:        10,
+				},
+			},
+			want: map[string]float32{},
+		},
+		{
+			name:       "gift case",
+			promotions: []models.Promotion{gift},
+			want:       map[string]float32{"gift:cable": 200},
+		},
+		{
+			name:       "stacking case",
+			promotions: []models.Promotion{gift, threeForTwo, tenPercent},
+			want: map[string]float32{
+				"three-for-two:cable": 200,
+				"ten-percent:":        540,
+				"gift:cable":          200,
+			},
+		},
+		{
+			name: "not stackable case",
+			promotions: []models.Promotion{
+				gift,
+				tenPercent,
+				{
+					ID:          "exclusive",
+					Name:        "exclusive 3 for 2",
+					Type:        consts.PromotionTypeNForM,
+					Priority:    100,
+					Stackable:   false,
+					CategoryId:  "audio",
+					BuyQuantity: 3,
+					PayQuantity: 2,
+				},
+			},
+			want: map[string]float32{"exclusive:cable": 200},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := promotion_service_mocks.NewMockRepository(t)
+
+			mRepository.EXPECT().ActivePromotions(
+				mock.AnythingOfType("context.backgroundCtx"),
+			).Return(tt.promotions, nil)
+
+			s := New(mRepository)
+			adjustments, err := s.Adjustments(context.Background(), cart)
+			require.NoError(t, err)
+
+			got := make(map[string]float32, len(adjustments))
+			for _, adjustment := range adjustments {
+				require.Equal(t, consts.AdjustmentPromotion, adjustment.Source)
+				require.Equal(t, adjustment.SourceId == "exclusive", adjustment.Exclusive)
+				got[adjustment.SourceId+":"+adjustment.ProductId] += adjustment.Amount
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
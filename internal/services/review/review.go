@@ -0,0 +1,89 @@
+package review_service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+type Repository interface {
+	SaveReview(ctx context.Context, review models.Review) (string, error)
+	IsProductPurchased(ctx context.Context, userId, productId string) (bool, error)
+	ReviewsByProductId(ctx context.Context, productId string, page int) ([]models.Review, error)
+	ReviewsByStatus(ctx context.Context, status string, page int) ([]models.Review, error)
+	UpdateReviewStatus(ctx context.Context, id string, status string) error
+}
+
+type Service struct {
+	repository Repository
+}
+
+func New(repository Repository) *Service {
+	return &Service{
+		repository: repository,
+	}
+}
+
+func (s *Service) CreateReview(ctx context.Context, userId, productId string, rating int, text string) (string, error) {
+	const op = "services.review.CreateReview"
+
+	purchased, err := s.repository.IsProductPurchased(ctx, userId, productId)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if !purchased {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrProductNotPurchased)
+	}
+
+	id, err := s.repository.SaveReview(ctx, models.Review{
+		ProductId: productId,
+		UserId:    userId,
+		Rating:    rating,
+		Text:      text,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) ReviewsByProductId(ctx context.Context, productId string, page int) ([]models.Review, error) {
+	const op = "services.review.ReviewsByProductId"
+
+	reviews, err := s.repository.ReviewsByProductId(ctx, productId, page)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
+
+func (s *Service) PendingReviews(ctx context.Context, page int) ([]models.Review, error) {
+	const op = "services.review.PendingReviews"
+
+	reviews, err := s.repository.ReviewsByStatus(ctx, consts.ReviewStatusPending, page)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
+
+func (s *Service) ModerateReview(ctx context.Context, id string, status string) error {
+	const op = "services.review.ModerateReview"
+
+	if status != consts.ReviewStatusApproved && status != consts.ReviewStatusRejected {
+		return fmt.Errorf("%s: %w", op, errs.ErrWrongReviewStatus)
+	}
+
+	err := s.repository.UpdateReviewStatus(ctx, id, status)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package review_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	review_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/review/__mocks__"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_CreateReview(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		userId    string
+		productId string
+		rating    int
+		text      string
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		purchased   bool
+		wantSave    bool
+		wantSaveErr error
+		wantErr     error
+	}{
+		{
+			name: "good case",
+			args: args{
+				ctx:       context.Background(),
+				userId:    "user",
+				productId: "product",
+				rating:    5,
+				text:      "good headphones",
+			},
+			purchased: true,
+			wantSave:  true,
+			wantErr:   nil,
+		},
+		{
+			name: "not purchased case",
+			args: args{
+				ctx:       context.Background(),
+				userId:    "user",
+				productId: "product",
+				rating:    1,
+			},
+			purchased: false,
+			wantSave:  false,
+			wantErr:   errs.ErrProductNotPurchased,
+		},
+		{
+			name: "already reviewed case",
+			args: args{
+				ctx:       context.Background(),
+				userId:    "user",
+				productId: "product",
+				rating:    4,
+			},
+			purchased:   true,
+			wantSave:    true,
+			wantSaveErr: errs.ErrReviewAlreadyExists,
+			wantErr:     errs.ErrReviewAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := review_service_mocks.NewMockRepository(t)
+
+			mRepository.EXPECT().IsProductPurchased(
+				mock.AnythingOfType("context.backgroundCtx"),
+				tt.args.userId,
+				tt.args.productId,
+			).Return(tt.purchased, nil)
+
+			if tt.wantSave {
+				mRepository.EXPECT().SaveReview(
+					mock.AnythingOfType("context.backgroundCtx"),
+					models.Review{
+						ProductId: tt.args.productId,
+						UserId:    tt.args.userId,
+						Rating:    tt.args.rating,
+						Text:      tt.args.text,
+					},
+				).Return("id", tt.wantSaveErr)
+			}
+
+			s := &Service{
+				repository: mRepository,
+			}
+			got, err := s.CreateReview(tt.args.ctx, tt.args.userId, tt.args.productId, tt.args.rating, tt.args.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.CreateReview() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && got == "" {
+				t.Error("Service.CreateReview() = id is empty")
+			}
+		})
+	}
+}
+
+func TestService_ModerateReview(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		wantCall bool
+		wantErr  error
+	}{
+		{
+			name:     "approve case",
+			status:   consts.ReviewStatusApproved,
+			wantCall: true,
+			wantErr:  nil,
+		},
+		{
+			name:     "reject case",
+			status:   consts.ReviewStatusRejected,
+			wantCall: true,
+			wantErr:  nil,
+		},
+		{
+			name:     "wrong status case",
+			status:   consts.ReviewStatusPending,
+			wantCall: false,
+			wantErr:  errs.ErrWrongReviewStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := review_service_mocks.NewMockRepository(t)
+
+			if tt.wantCall {
+				mRepository.EXPECT().UpdateReviewStatus(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"id",
+					tt.status,
+				).Return(nil)
+			}
+
+			s := &Service{
+				repository: mRepository,
+			}
+			if err := s.ModerateReview(context.Background(), "id", tt.status); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ModerateReview() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
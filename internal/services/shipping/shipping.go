@@ -0,0 +1,209 @@
+package shipping_service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+type Repository interface {
+	SaveMethod(ctx context.Context, method models.ShippingMethod) (string, error)
+	Methods(ctx context.Context) ([]models.ShippingMethod, error)
+	DeleteMethod(ctx context.Context, id string) error
+}
+
+type CartProvider interface {
+	CartByUserId(ctx context.Context, userId string) (models.Cart, error)
+}
+
+type Service struct {
+	repository   Repository
+	cartProvider CartProvider
+}
+
+func New(repository Repository, cartProvider CartProvider) *Service {
+	return &Service{
+		repository:   repository,
+		cartProvider: cartProvider,
+	}
+}
+
+func (s *Service) CreateMethod(ctx context.Context, method models.ShippingMethod) (string, error) {
+	const op = "services.shipping.CreateMethod"
+
+	if !isValidMethod(method) {
+		return "", fmt.Errorf("%s: %w", op, errs.ErrWrongShippingMethod)
+	}
+
+	id, err := s.repository.SaveMethod(ctx, method)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) Methods(ctx context.Context) ([]models.ShippingMethod, error) {
+	const op = "services.shipping.Methods"
+
+	methods, err := s.repository.Methods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return methods, nil
+}
+
+func (s *Service) DeleteMethod(ctx context.Context, id string) error {
+	const op = "services.shipping.DeleteMethod"
+
+	err := s.repository.DeleteMethod(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ShippingOptions quotes every active shipping method which can deliver
+// user cart to region
+func (s *Service) ShippingOptions(ctx context.Context, userId, region string) ([]models.ShippingOption, error) {
+	const op = "services.shipping.ShippingOptions"
+
+	cart, methods, err := s.cartAndMethods(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	options := make([]models.ShippingOption, 0, len(methods))
+	for _, method := range methods {
+		if option, ok := quote(method, cart, region); ok {
+			options = append(options, option)
+		}
+	}
+
+	return options, nil
+}
+
+// ShippingOption quotes shipping method chosen by user
+func (s *Service) ShippingOption(ctx context.Context, userId, methodId, region string) (models.ShippingOption, error) {
+	const op = "services.shipping.ShippingOption"
+
+	cart, methods, err := s.cartAndMethods(ctx, userId)
+	if err != nil {
+		return models.ShippingOption{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, method := range methods {
+		if method.ID != methodId {
+			continue
+		}
+
+		option, ok := quote(method, cart, region)
+		if !ok {
+			return models.ShippingOption{}, fmt.Errorf("%s: %w", op, errs.ErrShippingNotAvailable)
+		}
+
+		return option, nil
+	}
+
+	return models.ShippingOption{}, fmt.Errorf("%s: %w", op, errs.ErrShippingNotFound)
+}
+
+func (s *Service) cartAndMethods(ctx context.Context, userId string) (models.Cart, []models.ShippingMethod, error) {
+	cart, err := s.cartProvider.CartByUserId(ctx, userId)
+	if err != nil {
+		return models.Cart{}, nil, err
+	}
+	if len(cart.Items) == 0 {
+		return models.Cart{}, nil, errs.ErrCartIsEmpty
+	}
+
+	methods, err := s.repository.Methods(ctx)
+	if err != nil {
+		return models.Cart{}, nil, err
+	}
+
+	active := make([]models.ShippingMethod, 0, len(methods))
+	for _, method := range methods {
+		if method.IsActive {
+			active = append(active, method)
+		}
+	}
+
+	return cart, active, nil
+}
+
+// quote picks the most specific rate of method for cart weight and region:
+// rate of the region is preferred over rate for any region, then the rate
+// with the least max weight is taken. Free shipping promo makes price zero
+func quote(method models.ShippingMethod, cart models.Cart, region string) (models.ShippingOption, bool) {
+	var weight int
+	for _, item := range cart.Items {
+		weight += item.Weight * item.Quantity
+	}
+
+	var best *models.ShippingRate
+	for i, rate := range method.Rates {
+		if rate.Region != "" && !strings.EqualFold(strings.TrimSpace(region), rate.Region) {
+			continue
+		}
+		if rate.MaxWeight != 0 && rate.MaxWeight < weight {
+			continue
+		}
+		if best == nil || isMoreSpecific(rate, *best) {
+			best = &method.Rates[i]
+		}
+	}
+	if best == nil {
+		return models.ShippingOption{}, false
+	}
+
+	option := models.ShippingOption{
+		MethodId: method.ID,
+		Name:     method.Name,
+		Type:     method.Type,
+		Price:    best.Price,
+		MinDays:  best.MinDays,
+		MaxDays:  best.MaxDays,
+	}
+	if cart.FreeShipping {
+		option.Price = 0
+	}
+
+	return option, true
+}
+
+func isMoreSpecific(rate, than models.ShippingRate) bool {
+	if (rate.Region != "") != (than.Region != "") {
+		return rate.Region != ""
+	}
+	if (rate.MaxWeight != 0) != (than.MaxWeight != 0) {
+		return rate.MaxWeight != 0
+	}
+
+	return rate.MaxWeight < than.MaxWeight
+}
+
+func isValidMethod(method models.ShippingMethod) bool {
+	if method.Name == "" || len(method.Rates) == 0 {
+		return false
+	}
+	if method.Type != consts.ShippingTypeCourier &&
+		method.Type != consts.ShippingTypePickup &&
+		method.Type != consts.ShippingTypePost {
+		return false
+	}
+
+	for _, rate := range method.Rates {
+		if rate.Price < 0 || rate.MaxWeight < 0 || rate.MinDays < 0 || rate.MaxDays < rate.MinDays {
+			return false
+		}
+	}
+
+	return true
+}
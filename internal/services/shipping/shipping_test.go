@@ -0,0 +1,168 @@
+package shipping_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	shipping_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/shipping/__mocks__"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var methods = []models.ShippingMethod{
+	{
+		ID:       "courier",
+		Name:     "courier",
+		Type:     consts.ShippingTypeCourier,
+		IsActive: true,
+		Rates: []models.ShippingRate{
+			{Region: "Москва", MaxWeight: 1000, Price: 300},
+			{Region: "Москва", MaxWeight: 5000, Price: 500},
+		},
+	},
+	{
+		ID:       "post",
+		Name:     "post",
+		Type:     consts.ShippingTypePost,
+		IsActive: true,
+		Rates: []models.ShippingRate{
+			{Price: 600},
+			{MaxWeight: 1000, Price: 400},
+			{Region: "Москва", Price: 350},
+		},
+	},
+	{
+		ID:       "pickup",
+		Name:     "pickup",
+		Type:     consts.ShippingTypePickup,
+		IsActive: false,
+		Rates: []models.ShippingRate{
+			{Price: 0},
+		},
+	},
+}
+
+func TestService_ShippingOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		cart   models.Cart
+		region string
+		want   map[string]float32
+	}{
+		{
+			name: "light parcel in region case",
+			cart: models.Cart{
+				Items: []models.CartItem{{Quantity: 2, Weight: 400}},
+			},
+			region: "москва",
+			want:   map[string]float32{"courier": 300, "post": 350},
+		},
+		{
+			name: "heavy parcel out of region case",
+			cart: models.Cart{
+				Items: []models.CartItem{{Quantity: 3, Weight: 2000}},
+			},
+			region: "Казань",
+			want:   map[string]float32{"post": 600},
+		},
+		{
+			name: "light parcel out of region case",
+			cart: models.Cart{
+				Items: []models.CartItem{{Quantity: 1, Weight: 500}},
+			},
+			region: "Казань",
+			want:   map[string]float32{"post": 400},
+		},
+		{
+			name: "free shipping case",
+			cart: models.Cart{
+				FreeShipping: true,
+				Items:        []models.CartItem{{Quantity: 1, Weight: 500}},
+			},
+			region: "Москва",
+			want:   map[string]float32{"courier": 0, "post": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := shipping_service_mocks.NewMockRepository(t)
+			mCartProvider := shipping_service_mocks.NewMockCartProvider(t)
+
+			mCartProvider.EXPECT().CartByUserId(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+			).Return(tt.cart, nil)
+
+			mRepository.EXPECT().Methods(
+				mock.AnythingOfType("context.backgroundCtx"),
+			).Return(methods, nil)
+
+			s := New(mRepository, mCartProvider)
+			options, err := s.ShippingOptions(context.Background(), "user", tt.region)
+			require.NoError(t, err)
+
+			got := make(map[string]float32, len(options))
+			for _, option := range options {
+				got[option.MethodId] = option.Price
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ShippingOption(t *testing.T) {
+	cart := models.Cart{
+		Items: []models.CartItem{{Quantity: 1, Weight: 6000}},
+	}
+
+	tests := []struct {
+		name     string
+		methodId string
+		want     float32
+		wantErr  error
+	}{
+		{
+			name:     "good case",
+			methodId: "post",
+			want:     350,
+			wantErr:  nil,
+		},
+		{
+			name:     "too heavy case",
+			methodId: "courier",
+			wantErr:  errs.ErrShippingNotAvailable,
+		},
+		{
+			name:     "inactive method case",
+			methodId: "pickup",
+			wantErr:  errs.ErrShippingNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := shipping_service_mocks.NewMockRepository(t)
+			mCartProvider := shipping_service_mocks.NewMockCartProvider(t)
+
+			mCartProvider.EXPECT().CartByUserId(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+			).Return(cart, nil)
+
+			mRepository.EXPECT().Methods(
+				mock.AnythingOfType("context.backgroundCtx"),
+			).Return(methods, nil)
+
+			s := New(mRepository, mCartProvider)
+			option, err := s.ShippingOption(context.Background(), "user", tt.methodId, "Москва")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ShippingOption() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			require.Equal(t, tt.want, option.Price)
+		})
+	}
+}
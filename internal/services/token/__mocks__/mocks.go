@@ -6,7 +6,9 @@ package token_service_mocks
 
 import (
 	"context"
+	"time"
 
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -37,38 +39,571 @@ func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
 	return &MockStorage_Expecter{mock: &_m.Mock}
 }
 
+// DeleteStaleTokens provides a mock function for the type MockStorage
+func (_mock *MockStorage) DeleteStaleTokens(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStaleTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_DeleteStaleTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStaleTokens'
+type MockStorage_DeleteStaleTokens_Call struct {
+	*mock.Call
+}
+
+// DeleteStaleTokens is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) DeleteStaleTokens(ctx interface{}) *MockStorage_DeleteStaleTokens_Call {
+	return &MockStorage_DeleteStaleTokens_Call{Call: _e.mock.On("DeleteStaleTokens", ctx)}
+}
+
+func (_c *MockStorage_DeleteStaleTokens_Call) Run(run func(ctx context.Context)) *MockStorage_DeleteStaleTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteStaleTokens_Call) Return(err error) *MockStorage_DeleteStaleTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_DeleteStaleTokens_Call) RunAndReturn(run func(ctx context.Context) error) *MockStorage_DeleteStaleTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserTokens provides a mock function for the type MockStorage
+func (_mock *MockStorage) DeleteUserTokens(ctx context.Context, userId string, tokenType string) error {
+	ret := _mock.Called(ctx, userId, tokenType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userId, tokenType)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_DeleteUserTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserTokens'
+type MockStorage_DeleteUserTokens_Call struct {
+	*mock.Call
+}
+
+// DeleteUserTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - tokenType string
+func (_e *MockStorage_Expecter) DeleteUserTokens(ctx interface{}, userId interface{}, tokenType interface{}) *MockStorage_DeleteUserTokens_Call {
+	return &MockStorage_DeleteUserTokens_Call{Call: _e.mock.On("DeleteUserTokens", ctx, userId, tokenType)}
+}
+
+func (_c *MockStorage_DeleteUserTokens_Call) Run(run func(ctx context.Context, userId string, tokenType string)) *MockStorage_DeleteUserTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteUserTokens_Call) Return(err error) *MockStorage_DeleteUserTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_DeleteUserTokens_Call) RunAndReturn(run func(ctx context.Context, userId string, tokenType string) error) *MockStorage_DeleteUserTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function for the type MockStorage
+func (_mock *MockStorage) ResetPassword(ctx context.Context, token string, tokenType string, password string) (string, error) {
+	ret := _mock.Called(ctx, token, tokenType, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return returnFunc(ctx, token, tokenType, password)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = returnFunc(ctx, token, tokenType, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, token, tokenType, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockStorage_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - tokenType string
+//   - password string
+func (_e *MockStorage_Expecter) ResetPassword(ctx interface{}, token interface{}, tokenType interface{}, password interface{}) *MockStorage_ResetPassword_Call {
+	return &MockStorage_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, token, tokenType, password)}
+}
+
+func (_c *MockStorage_ResetPassword_Call) Run(run func(ctx context.Context, token string, tokenType string, password string)) *MockStorage_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ResetPassword_Call) Return(s string, err error) *MockStorage_ResetPassword_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockStorage_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, token string, tokenType string, password string) (string, error)) *MockStorage_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveEmailToken provides a mock function for the type MockStorage
+func (_mock *MockStorage) SaveEmailToken(ctx context.Context, userId string, token string, tokenType string, email string, ttl time.Duration) error {
+	ret := _mock.Called(ctx, userId, token, tokenType, email, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEmailToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, time.Duration) error); ok {
+		r0 = returnFunc(ctx, userId, token, tokenType, email, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_SaveEmailToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveEmailToken'
+type MockStorage_SaveEmailToken_Call struct {
+	*mock.Call
+}
+
+// SaveEmailToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - token string
+//   - tokenType string
+//   - email string
+//   - ttl time.Duration
+func (_e *MockStorage_Expecter) SaveEmailToken(ctx interface{}, userId interface{}, token interface{}, tokenType interface{}, email interface{}, ttl interface{}) *MockStorage_SaveEmailToken_Call {
+	return &MockStorage_SaveEmailToken_Call{Call: _e.mock.On("SaveEmailToken", ctx, userId, token, tokenType, email, ttl)}
+}
+
+func (_c *MockStorage_SaveEmailToken_Call) Run(run func(ctx context.Context, userId string, token string, tokenType string, email string, ttl time.Duration)) *MockStorage_SaveEmailToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 time.Duration
+		if args[5] != nil {
+			arg5 = args[5].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_SaveEmailToken_Call) Return(err error) *MockStorage_SaveEmailToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_SaveEmailToken_Call) RunAndReturn(run func(ctx context.Context, userId string, token string, tokenType string, email string, ttl time.Duration) error) *MockStorage_SaveEmailToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveToken provides a mock function for the type MockStorage
-func (_mock *MockStorage) SaveToken(ctx context.Context, userId string, token string, tokenType string) error {
-	ret := _mock.Called(ctx, userId, token, tokenType)
+func (_mock *MockStorage) SaveToken(ctx context.Context, userId string, token string, tokenType string, ttl time.Duration) error {
+	ret := _mock.Called(ctx, userId, token, tokenType, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) error); ok {
+		r0 = returnFunc(ctx, userId, token, tokenType, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_SaveToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveToken'
+type MockStorage_SaveToken_Call struct {
+	*mock.Call
+}
+
+// SaveToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - token string
+//   - tokenType string
+//   - ttl time.Duration
+func (_e *MockStorage_Expecter) SaveToken(ctx interface{}, userId interface{}, token interface{}, tokenType interface{}, ttl interface{}) *MockStorage_SaveToken_Call {
+	return &MockStorage_SaveToken_Call{Call: _e.mock.On("SaveToken", ctx, userId, token, tokenType, ttl)}
+}
+
+func (_c *MockStorage_SaveToken_Call) Run(run func(ctx context.Context, userId string, token string, tokenType string, ttl time.Duration)) *MockStorage_SaveToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Duration
+		if args[4] != nil {
+			arg4 = args[4].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_SaveToken_Call) Return(err error) *MockStorage_SaveToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_SaveToken_Call) RunAndReturn(run func(ctx context.Context, userId string, token string, tokenType string, ttl time.Duration) error) *MockStorage_SaveToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseEmailToken provides a mock function for the type MockStorage
+func (_mock *MockStorage) UseEmailToken(ctx context.Context, token string, tokenType string) (string, string, error) {
+	ret := _mock.Called(ctx, token, tokenType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseEmailToken")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, string, error)); ok {
+		return returnFunc(ctx, token, tokenType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, token, tokenType)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
+		r1 = returnFunc(ctx, token, tokenType)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = returnFunc(ctx, token, tokenType)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStorage_UseEmailToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseEmailToken'
+type MockStorage_UseEmailToken_Call struct {
+	*mock.Call
+}
+
+// UseEmailToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - tokenType string
+func (_e *MockStorage_Expecter) UseEmailToken(ctx interface{}, token interface{}, tokenType interface{}) *MockStorage_UseEmailToken_Call {
+	return &MockStorage_UseEmailToken_Call{Call: _e.mock.On("UseEmailToken", ctx, token, tokenType)}
+}
+
+func (_c *MockStorage_UseEmailToken_Call) Run(run func(ctx context.Context, token string, tokenType string)) *MockStorage_UseEmailToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_UseEmailToken_Call) Return(s string, s1 string, err error) *MockStorage_UseEmailToken_Call {
+	_c.Call.Return(s, s1, err)
+	return _c
+}
+
+func (_c *MockStorage_UseEmailToken_Call) RunAndReturn(run func(ctx context.Context, token string, tokenType string) (string, string, error)) *MockStorage_UseEmailToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserIdByToken provides a mock function for the type MockStorage
+func (_mock *MockStorage) UserIdByToken(ctx context.Context, token string, tokenType string) (string, error) {
+	ret := _mock.Called(ctx, token, tokenType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserIdByToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, token, tokenType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, token, tokenType)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, token, tokenType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_UserIdByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserIdByToken'
+type MockStorage_UserIdByToken_Call struct {
+	*mock.Call
+}
+
+// UserIdByToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - tokenType string
+func (_e *MockStorage_Expecter) UserIdByToken(ctx interface{}, token interface{}, tokenType interface{}) *MockStorage_UserIdByToken_Call {
+	return &MockStorage_UserIdByToken_Call{Call: _e.mock.On("UserIdByToken", ctx, token, tokenType)}
+}
+
+func (_c *MockStorage_UserIdByToken_Call) Run(run func(ctx context.Context, token string, tokenType string)) *MockStorage_UserIdByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_UserIdByToken_Call) Return(s string, err error) *MockStorage_UserIdByToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockStorage_UserIdByToken_Call) RunAndReturn(run func(ctx context.Context, token string, tokenType string) (string, error)) *MockStorage_UserIdByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserService creates a new instance of MockUserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserService {
+	mock := &MockUserService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUserService is an autogenerated mock type for the UserService type
+type MockUserService struct {
+	mock.Mock
+}
+
+type MockUserService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
+	return &MockUserService_Expecter{mock: &_m.Mock}
+}
+
+// ConfirmEmailChange provides a mock function for the type MockUserService
+func (_mock *MockUserService) ConfirmEmailChange(ctx context.Context, userId string, email string) (string, error) {
+	ret := _mock.Called(ctx, userId, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveToken")
+		panic("no return value specified for ConfirmEmailChange")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(ctx, userId, token, tokenType)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, userId, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, userId, email)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userId, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_SaveToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveToken'
-type MockStorage_SaveToken_Call struct {
+// MockUserService_ConfirmEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmEmailChange'
+type MockUserService_ConfirmEmailChange_Call struct {
 	*mock.Call
 }
 
-// SaveToken is a helper method to define mock.On call
+// ConfirmEmailChange is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userId string
-//   - token string
-//   - tokenType string
-func (_e *MockStorage_Expecter) SaveToken(ctx interface{}, userId interface{}, token interface{}, tokenType interface{}) *MockStorage_SaveToken_Call {
-	return &MockStorage_SaveToken_Call{Call: _e.mock.On("SaveToken", ctx, userId, token, tokenType)}
+//   - email string
+func (_e *MockUserService_Expecter) ConfirmEmailChange(ctx interface{}, userId interface{}, email interface{}) *MockUserService_ConfirmEmailChange_Call {
+	return &MockUserService_ConfirmEmailChange_Call{Call: _e.mock.On("ConfirmEmailChange", ctx, userId, email)}
 }
 
-func (_c *MockStorage_SaveToken_Call) Run(run func(ctx context.Context, userId string, token string, tokenType string)) *MockStorage_SaveToken_Call {
+func (_c *MockUserService_ConfirmEmailChange_Call) Run(run func(ctx context.Context, userId string, email string)) *MockUserService_ConfirmEmailChange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -82,70 +617,126 @@ func (_c *MockStorage_SaveToken_Call) Run(run func(ctx context.Context, userId s
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_SaveToken_Call) Return(err error) *MockStorage_SaveToken_Call {
-	_c.Call.Return(err)
+func (_c *MockUserService_ConfirmEmailChange_Call) Return(s string, err error) *MockUserService_ConfirmEmailChange_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockStorage_SaveToken_Call) RunAndReturn(run func(ctx context.Context, userId string, token string, tokenType string) error) *MockStorage_SaveToken_Call {
+func (_c *MockUserService_ConfirmEmailChange_Call) RunAndReturn(run func(ctx context.Context, userId string, email string) (string, error)) *MockUserService_ConfirmEmailChange_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserIdByToken provides a mock function for the type MockStorage
-func (_mock *MockStorage) UserIdByToken(ctx context.Context, token string, tokenType string) (string, error) {
-	ret := _mock.Called(ctx, token, tokenType)
+// HashPassword provides a mock function for the type MockUserService
+func (_mock *MockUserService) HashPassword(password string) (string, error) {
+	ret := _mock.Called(password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserIdByToken")
+		panic("no return value specified for HashPassword")
 	}
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, token, tokenType)
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(password)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, token, tokenType)
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(password)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, token, tokenType)
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(password)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStorage_UserIdByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserIdByToken'
-type MockStorage_UserIdByToken_Call struct {
+// MockUserService_HashPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HashPassword'
+type MockUserService_HashPassword_Call struct {
 	*mock.Call
 }
 
-// UserIdByToken is a helper method to define mock.On call
+// HashPassword is a helper method to define mock.On call
+//   - password string
+func (_e *MockUserService_Expecter) HashPassword(password interface{}) *MockUserService_HashPassword_Call {
+	return &MockUserService_HashPassword_Call{Call: _e.mock.On("HashPassword", password)}
+}
+
+func (_c *MockUserService_HashPassword_Call) Run(run func(password string)) *MockUserService_HashPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_HashPassword_Call) Return(s string, err error) *MockUserService_HashPassword_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockUserService_HashPassword_Call) RunAndReturn(run func(password string) (string, error)) *MockUserService_HashPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginById provides a mock function for the type MockUserService
+func (_mock *MockUserService) LoginById(ctx context.Context, userId string, guestId string, session models.Session) (models.LoginResult, error) {
+	ret := _mock.Called(ctx, userId, guestId, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginById")
+	}
+
+	var r0 models.LoginResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, models.Session) (models.LoginResult, error)); ok {
+		return returnFunc(ctx, userId, guestId, session)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, models.Session) models.LoginResult); ok {
+		r0 = returnFunc(ctx, userId, guestId, session)
+	} else {
+		r0 = ret.Get(0).(models.LoginResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, models.Session) error); ok {
+		r1 = returnFunc(ctx, userId, guestId, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_LoginById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginById'
+type MockUserService_LoginById_Call struct {
+	*mock.Call
+}
+
+// LoginById is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-//   - tokenType string
-func (_e *MockStorage_Expecter) UserIdByToken(ctx interface{}, token interface{}, tokenType interface{}) *MockStorage_UserIdByToken_Call {
-	return &MockStorage_UserIdByToken_Call{Call: _e.mock.On("UserIdByToken", ctx, token, tokenType)}
+//   - userId string
+//   - guestId string
+//   - session models.Session
+func (_e *MockUserService_Expecter) LoginById(ctx interface{}, userId interface{}, guestId interface{}, session interface{}) *MockUserService_LoginById_Call {
+	return &MockUserService_LoginById_Call{Call: _e.mock.On("LoginById", ctx, userId, guestId, session)}
 }
 
-func (_c *MockStorage_UserIdByToken_Call) Run(run func(ctx context.Context, token string, tokenType string)) *MockStorage_UserIdByToken_Call {
+func (_c *MockUserService_LoginById_Call) Run(run func(ctx context.Context, userId string, guestId string, session models.Session)) *MockUserService_LoginById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -159,50 +750,151 @@ func (_c *MockStorage_UserIdByToken_Call) Run(run func(ctx context.Context, toke
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 models.Session
+		if args[3] != nil {
+			arg3 = args[3].(models.Session)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_UserIdByToken_Call) Return(s string, err error) *MockStorage_UserIdByToken_Call {
-	_c.Call.Return(s, err)
+func (_c *MockUserService_LoginById_Call) Return(loginResult models.LoginResult, err error) *MockUserService_LoginById_Call {
+	_c.Call.Return(loginResult, err)
 	return _c
 }
 
-func (_c *MockStorage_UserIdByToken_Call) RunAndReturn(run func(ctx context.Context, token string, tokenType string) (string, error)) *MockStorage_UserIdByToken_Call {
+func (_c *MockUserService_LoginById_Call) RunAndReturn(run func(ctx context.Context, userId string, guestId string, session models.Session) (models.LoginResult, error)) *MockUserService_LoginById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockUserService creates a new instance of MockUserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockUserService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockUserService {
-	mock := &MockUserService{}
-	mock.Mock.Test(t)
+// LogoutEverywhere provides a mock function for the type MockUserService
+func (_mock *MockUserService) LogoutEverywhere(ctx context.Context, userId string) error {
+	ret := _mock.Called(ctx, userId)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutEverywhere")
+	}
 
-	return mock
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockUserService is an autogenerated mock type for the UserService type
-type MockUserService struct {
-	mock.Mock
+// MockUserService_LogoutEverywhere_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutEverywhere'
+type MockUserService_LogoutEverywhere_Call struct {
+	*mock.Call
 }
 
-type MockUserService_Expecter struct {
-	mock *mock.Mock
+// LogoutEverywhere is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockUserService_Expecter) LogoutEverywhere(ctx interface{}, userId interface{}) *MockUserService_LogoutEverywhere_Call {
+	return &MockUserService_LogoutEverywhere_Call{Call: _e.mock.On("LogoutEverywhere", ctx, userId)}
 }
 
-func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
-	return &MockUserService_Expecter{mock: &_m.Mock}
+func (_c *MockUserService_LogoutEverywhere_Call) Run(run func(ctx context.Context, userId string)) *MockUserService_LogoutEverywhere_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_LogoutEverywhere_Call) Return(err error) *MockUserService_LogoutEverywhere_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_LogoutEverywhere_Call) RunAndReturn(run func(ctx context.Context, userId string) error) *MockUserService_LogoutEverywhere_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserByEmail provides a mock function for the type MockUserService
+func (_mock *MockUserService) UserByEmail(ctx context.Context, email string) (models.User, error) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserByEmail")
+	}
+
+	var r0 models.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.User, error)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.User); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Get(0).(models.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_UserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserByEmail'
+type MockUserService_UserByEmail_Call struct {
+	*mock.Call
+}
+
+// UserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockUserService_Expecter) UserByEmail(ctx interface{}, email interface{}) *MockUserService_UserByEmail_Call {
+	return &MockUserService_UserByEmail_Call{Call: _e.mock.On("UserByEmail", ctx, email)}
+}
+
+func (_c *MockUserService_UserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockUserService_UserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_UserByEmail_Call) Return(user models.User, err error) *MockUserService_UserByEmail_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_UserByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (models.User, error)) *MockUserService_UserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
 // VerifyEmail provides a mock function for the type MockUserService
@@ -261,3 +953,96 @@ func (_c *MockUserService_VerifyEmail_Call) RunAndReturn(run func(ctx context.Co
 	_c.Call.Return(run)
 	return _c
 }
+
+// NewMockCooldown creates a new instance of MockCooldown. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCooldown(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCooldown {
+	mock := &MockCooldown{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCooldown is an autogenerated mock type for the Cooldown type
+type MockCooldown struct {
+	mock.Mock
+}
+
+type MockCooldown_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCooldown) EXPECT() *MockCooldown_Expecter {
+	return &MockCooldown_Expecter{mock: &_m.Mock}
+}
+
+// StartCooldown provides a mock function for the type MockCooldown
+func (_mock *MockCooldown) StartCooldown(ctx context.Context, key string) (bool, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartCooldown")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCooldown_StartCooldown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartCooldown'
+type MockCooldown_StartCooldown_Call struct {
+	*mock.Call
+}
+
+// StartCooldown is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockCooldown_Expecter) StartCooldown(ctx interface{}, key interface{}) *MockCooldown_StartCooldown_Call {
+	return &MockCooldown_StartCooldown_Call{Call: _e.mock.On("StartCooldown", ctx, key)}
+}
+
+func (_c *MockCooldown_StartCooldown_Call) Run(run func(ctx context.Context, key string)) *MockCooldown_StartCooldown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCooldown_StartCooldown_Call) Return(b bool, err error) *MockCooldown_StartCooldown_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockCooldown_StartCooldown_Call) RunAndReturn(run func(ctx context.Context, key string) (bool, error)) *MockCooldown_StartCooldown_Call {
+	_c.Call.Return(run)
+	return _c
+}
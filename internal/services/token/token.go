@@ -3,45 +3,66 @@ package token_service
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	"github.com/google/uuid"
 )
 
 type Storage interface {
-	SaveToken(ctx context.Context, userId, token, tokenType string) error
+	SaveToken(ctx context.Context, userId, token, tokenType string, ttl time.Duration) error
+	SaveEmailToken(ctx context.Context, userId, token, tokenType, email string, ttl time.Duration) error
 	UserIdByToken(ctx context.Context, token, tokenType string) (string, error)
+	UseEmailToken(ctx context.Context, token, tokenType string) (string, string, error)
+	ResetPassword(ctx context.Context, token, tokenType, password string) (string, error)
+	DeleteStaleTokens(ctx context.Context) error
+	DeleteUserTokens(ctx context.Context, userId, tokenType string) error
 }
 
 type UserService interface {
+	UserByEmail(ctx context.Context, email string) (models.User, error)
 	VerifyEmail(ctx context.Context, id string) error
+	HashPassword(password string) (string, error)
+	LogoutEverywhere(ctx context.Context, userId string) error
+	ConfirmEmailChange(ctx context.Context, userId, email string) (string, error)
+	LoginById(ctx context.Context, userId, guestId string, session models.Session) (models.LoginResult, error)
 }
 
+type Cooldown interface {
+	StartCooldown(ctx context.Context, key string) (bool, error)
+}
+
+// Service creates tokens of types from ttls, each token
+// expires after ttl of its type
 type Service struct {
 	storage     Storage
 	userService UserService
+	cooldown    Cooldown
+	ttls        map[string]time.Duration
 }
 
-func New(storage Storage, userService UserService) *Service {
+func New(storage Storage, userService UserService, cooldown Cooldown, ttls map[string]time.Duration) *Service {
 	return &Service{
 		storage:     storage,
 		userService: userService,
+		cooldown:    cooldown,
+		ttls:        ttls,
 	}
 }
 
 func (s *Service) CreateToken(ctx context.Context, userId, tokenType string) (string, error) {
 	const op = "services.token.CreateToken"
 
-	var token string
-	switch tokenType {
-	case consts.TokenTypeEmailVerify:
-		token = uuid.NewString()
-	default:
+	ttl, ok := s.ttls[tokenType]
+	if !ok {
 		return "", fmt.Errorf("%s: %w", op, errs.ErrWrongTokenType)
 	}
 
-	err := s.storage.SaveToken(ctx, userId, token, tokenType)
+	token := uuid.NewString()
+	err := s.storage.SaveToken(ctx, userId, token, tokenType, ttl)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
@@ -64,3 +85,206 @@ func (s *Service) VerifyEmail(ctx context.Context, token string) error {
 
 	return nil
 }
+
+// CreateEmailChangeToken invalidates unused email change tokens of user
+// and creates new one bound to email it is sent to
+func (s *Service) CreateEmailChangeToken(ctx context.Context, userId, email string) (string, error) {
+	const op = "services.token.CreateEmailChangeToken"
+
+	err := s.storage.DeleteUserTokens(ctx, userId, consts.TokenTypeEmailChange)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	token := uuid.NewString()
+	err = s.storage.SaveEmailToken(ctx, userId, token, consts.TokenTypeEmailChange, email, s.ttls[consts.TokenTypeEmailChange])
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+// ConfirmEmail switches user email to the one email change token was sent to,
+// token sent to email which is not pending anymore gives ErrEmailChangeNotFound.
+// Returns replaced and new emails
+func (s *Service) ConfirmEmail(ctx context.Context, token string) (string, string, error) {
+	const op = "services.token.ConfirmEmail"
+
+	userId, email, err := s.storage.UseEmailToken(ctx, token, consts.TokenTypeEmailChange)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	oldEmail, err := s.userService.ConfirmEmailChange(ctx, userId, email)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return oldEmail, email, nil
+}
+
+// ResendVerification invalidates unused email verification tokens of user with
+// email and creates new one. It can be called once per cooldown for each email
+func (s *Service) ResendVerification(ctx context.Context, email string) (models.User, string, error) {
+	const op = "services.token.ResendVerification"
+
+	ok, err := s.cooldown.StartCooldown(ctx, "verification:"+cooldownEmail(email))
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if !ok {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, errs.ErrTooManyRequests)
+	}
+
+	user, err := s.userService.UserByEmail(ctx, email)
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if user.IsEmailVerified {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, errs.ErrEmailAlreadyVerified)
+	}
+
+	err = s.storage.DeleteUserTokens(ctx, user.ID, consts.TokenTypeEmailVerify)
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err := s.CreateToken(ctx, user.ID, consts.TokenTypeEmailVerify)
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, token, nil
+}
+
+// CreateMagicLink invalidates unused magic links of user with email and creates
+// new one, users with unverified email get ErrEmailNotVerify. It can be called
+// once per cooldown for each email
+func (s *Service) CreateMagicLink(ctx context.Context, email string) (models.User, string, error) {
+	const op = "services.token.CreateMagicLink"
+
+	ok, err := s.cooldown.StartCooldown(ctx, "magic-link:"+cooldownEmail(email))
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if !ok {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, errs.ErrTooManyRequests)
+	}
+
+	user, err := s.userService.UserByEmail(ctx, email)
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !user.IsEmailVerified {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, errs.ErrEmailNotVerify)
+	}
+
+	err = s.storage.DeleteUserTokens(ctx, user.ID, consts.TokenTypeMagicLink)
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err := s.CreateToken(ctx, user.ID, consts.TokenTypeMagicLink)
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, token, nil
+}
+
+// LoginByMagicLink logs in magic link token owner the same way as password
+// login does, token can not be used again
+func (s *Service) LoginByMagicLink(
+	ctx context.Context,
+	token string,
+	guestId string,
+	session models.Session,
+) (models.LoginResult, error) {
+	const op = "services.token.LoginByMagicLink"
+
+	userId, err := s.storage.UserIdByToken(ctx, token, consts.TokenTypeMagicLink)
+	if err != nil {
+		return models.LoginResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := s.userService.LoginById(ctx, userId, guestId, session)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// CreatePasswordResetToken invalidates unused password reset tokens of user
+// with email and creates new one. It can be called once per cooldown for each email
+func (s *Service) CreatePasswordResetToken(ctx context.Context, email string) (models.User, string, error) {
+	const op = "services.token.CreatePasswordResetToken"
+
+	ok, err := s.cooldown.StartCooldown(ctx, "password-reset:"+cooldownEmail(email))
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if !ok {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, errs.ErrTooManyRequests)
+	}
+
+	user, err := s.userService.UserByEmail(ctx, email)
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.storage.DeleteUserTokens(ctx, user.ID, consts.TokenTypePasswordReset)
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err := s.CreateToken(ctx, user.ID, consts.TokenTypePasswordReset)
+	if err != nil {
+		return models.User{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, token, nil
+}
+
+// ResetPassword sets new password of password reset token owner and revokes
+// user sessions, token is used only if password is changed
+func (s *Service) ResetPassword(ctx context.Context, token, password string) error {
+	const op = "services.token.ResetPassword"
+
+	hash, err := s.userService.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	userId, err := s.storage.ResetPassword(ctx, token, consts.TokenTypePasswordReset, hash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.userService.LogoutEverywhere(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CleanupTokens deletes used and expired tokens
+func (s *Service) CleanupTokens(ctx context.Context) error {
+	const op = "services.token.CleanupTokens"
+
+	err := s.storage.DeleteStaleTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// cooldownEmail makes differently written forms of one email share cooldown
+func cooldownEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
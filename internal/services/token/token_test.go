@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 	token_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/token/__mocks__"
 	"github.com/stretchr/testify/mock"
 )
@@ -27,7 +29,8 @@ func TestService_CreateToken(t *testing.T) {
 		mock.AnythingOfType("context.backgroundCtx"),
 		mock.AnythingOfType("string"),
 		mock.AnythingOfType("string"),
-		mock.AnythingOfType("string"),
+		consts.TokenTypeEmailVerify,
+		time.Hour,
 	).Return(nil)
 
 	tests := []struct {
@@ -65,6 +68,9 @@ func TestService_CreateToken(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &Service{
 				storage: tt.fields.storage,
+				ttls: map[string]time.Duration{
+					consts.TokenTypeEmailVerify: time.Hour,
+				},
 			}
 			got, err := s.CreateToken(tt.args.ctx, tt.args.userId, tt.args.tokenType)
 			if err != nil {
@@ -79,3 +85,440 @@ func TestService_CreateToken(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		tokenErr    error
+		wantChanged bool
+		wantErr     error
+	}{
+		{
+			name:        "good case",
+			token:       "token",
+			tokenErr:    nil,
+			wantChanged: true,
+			wantErr:     nil,
+		},
+		{
+			name:        "token not found case",
+			token:       "unknown",
+			tokenErr:    errs.ErrTokenNotFound,
+			wantChanged: false,
+			wantErr:     errs.ErrTokenNotFound,
+		},
+		{
+			name:        "token used case",
+			token:       "used",
+			tokenErr:    errs.ErrTokenUsed,
+			wantChanged: false,
+			wantErr:     errs.ErrTokenUsed,
+		},
+		{
+			name:        "token expired case",
+			token:       "expired",
+			tokenErr:    errs.ErrTokenExpired,
+			wantChanged: false,
+			wantErr:     errs.ErrTokenExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mStorage := token_service_mocks.NewMockStorage(t)
+			mUserService := token_service_mocks.NewMockUserService(t)
+
+			mUserService.EXPECT().HashPassword("new-password").Return("hash", nil).Once()
+			mStorage.EXPECT().ResetPassword(
+				mock.AnythingOfType("context.backgroundCtx"),
+				tt.token,
+				consts.TokenTypePasswordReset,
+				"hash",
+			).Return("user", tt.tokenErr).Once()
+
+			if tt.wantChanged {
+				mUserService.EXPECT().LogoutEverywhere(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+				).Return(nil).Once()
+			}
+
+			s := New(mStorage, mUserService, nil, nil)
+			err := s.ResetPassword(context.Background(), tt.token, "new-password")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ResetPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_CreatePasswordResetToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		inCooldown  bool
+		userErr     error
+		wantCreated bool
+		wantErr     error
+	}{
+		{
+			name:        "good case",
+			wantCreated: true,
+			wantErr:     nil,
+		},
+		{
+			name:       "cooldown case",
+			inCooldown: true,
+			wantErr:    errs.ErrTooManyRequests,
+		},
+		{
+			name:    "user not found case",
+			userErr: errs.ErrUserNotFound,
+			wantErr: errs.ErrUserNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mStorage := token_service_mocks.NewMockStorage(t)
+			mUserService := token_service_mocks.NewMockUserService(t)
+			mCooldown := token_service_mocks.NewMockCooldown(t)
+
+			mCooldown.EXPECT().StartCooldown(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"password-reset:user@mail.com",
+			).Return(!tt.inCooldown, nil).Once()
+
+			if !tt.inCooldown {
+				mUserService.EXPECT().UserByEmail(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user@mail.com",
+				).Return(models.User{ID: "user", Email: "user@mail.com"}, tt.userErr).Once()
+			}
+
+			if tt.wantCreated {
+				mStorage.EXPECT().DeleteUserTokens(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					consts.TokenTypePasswordReset,
+				).Return(nil).Once()
+				mStorage.EXPECT().SaveToken(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					mock.AnythingOfType("string"),
+					consts.TokenTypePasswordReset,
+					time.Hour,
+				).Return(nil).Once()
+			}
+
+			s := New(mStorage, mUserService, mCooldown, map[string]time.Duration{
+				consts.TokenTypePasswordReset: time.Hour,
+			})
+			_, token, err := s.CreatePasswordResetToken(context.Background(), "user@mail.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.CreatePasswordResetToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantCreated && token == "" {
+				t.Error("Service.CreatePasswordResetToken() = token is empty")
+			}
+		})
+	}
+}
+
+func TestService_ResendVerification(t *testing.T) {
+	tests := []struct {
+		name        string
+		inCooldown  bool
+		user        models.User
+		wantCreated bool
+		wantErr     error
+	}{
+		{
+			name:        "good case",
+			user:        models.User{ID: "user", Email: "user@mail.com"},
+			wantCreated: true,
+			wantErr:     nil,
+		},
+		{
+			name:       "cooldown case",
+			inCooldown: true,
+			wantErr:    errs.ErrTooManyRequests,
+		},
+		{
+			name:    "already verified case",
+			user:    models.User{ID: "user", Email: "user@mail.com", IsEmailVerified: true},
+			wantErr: errs.ErrEmailAlreadyVerified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mStorage := token_service_mocks.NewMockStorage(t)
+			mUserService := token_service_mocks.NewMockUserService(t)
+			mCooldown := token_service_mocks.NewMockCooldown(t)
+
+			mCooldown.EXPECT().StartCooldown(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"verification:user@mail.com",
+			).Return(!tt.inCooldown, nil).Once()
+
+			if !tt.inCooldown {
+				mUserService.EXPECT().UserByEmail(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user@mail.com",
+				).Return(tt.user, nil).Once()
+			}
+
+			if tt.wantCreated {
+				mStorage.EXPECT().DeleteUserTokens(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					consts.TokenTypeEmailVerify,
+				).Return(nil).Once()
+				mStorage.EXPECT().SaveToken(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					mock.AnythingOfType("string"),
+					consts.TokenTypeEmailVerify,
+					time.Hour,
+				).Return(nil).Once()
+			}
+
+			s := New(mStorage, mUserService, mCooldown, map[string]time.Duration{
+				consts.TokenTypeEmailVerify: time.Hour,
+			})
+			_, token, err := s.ResendVerification(context.Background(), "user@mail.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ResendVerification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantCreated && token == "" {
+				t.Error("Service.ResendVerification() = token is empty")
+			}
+		})
+	}
+}
+
+func TestService_CreateMagicLink(t *testing.T) {
+	tests := []struct {
+		name        string
+		inCooldown  bool
+		user        models.User
+		wantCreated bool
+		wantErr     error
+	}{
+		{
+			name:        "good case",
+			user:        models.User{ID: "user", Email: "user@mail.com", IsEmailVerified: true},
+			wantCreated: true,
+			wantErr:     nil,
+		},
+		{
+			name:       "cooldown case",
+			inCooldown: true,
+			wantErr:    errs.ErrTooManyRequests,
+		},
+		{
+			name:    "not verified case",
+			user:    models.User{ID: "user", Email: "user@mail.com"},
+			wantErr: errs.ErrEmailNotVerify,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mStorage := token_service_mocks.NewMockStorage(t)
+			mUserService := token_service_mocks.NewMockUserService(t)
+			mCooldown := token_service_mocks.NewMockCooldown(t)
+
+			mCooldown.EXPECT().StartCooldown(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"magic-link:user@mail.com",
+			).Return(!tt.inCooldown, nil).Once()
+
+			if !tt.inCooldown {
+				mUserService.EXPECT().UserByEmail(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user@mail.com",
+				).Return(tt.user, nil).Once()
+			}
+
+			if tt.wantCreated {
+				mStorage.EXPECT().DeleteUserTokens(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					consts.TokenTypeMagicLink,
+				).Return(nil).Once()
+				mStorage.EXPECT().SaveToken(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					mock.AnythingOfType("string"),
+					consts.TokenTypeMagicLink,
+					15*time.Minute,
+				).Return(nil).Once()
+			}
+
+			s := New(mStorage, mUserService, mCooldown, map[string]time.Duration{
+				consts.TokenTypeMagicLink: 15 * time.Minute,
+			})
+			_, token, err := s.CreateMagicLink(context.Background(), "user@mail.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.CreateMagicLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantCreated && token == "" {
+				t.Error("Service.CreateMagicLink() = token is empty")
+			}
+		})
+	}
+}
+
+func TestService_CooldownEmail(t *testing.T) {
+	mCooldown := token_service_mocks.NewMockCooldown(t)
+
+	for _, key := range []string{"verification", "magic-link", "password-reset"} {
+		mCooldown.EXPECT().StartCooldown(
+			mock.AnythingOfType("context.backgroundCtx"),
+			key+":user@mail.com",
+		).Return(false, nil).Once()
+	}
+
+	s := New(nil, nil, mCooldown, nil)
+	email := "  User@Mail.COM "
+	if _, _, err := s.ResendVerification(context.Background(), email); !errors.Is(err, errs.ErrTooManyRequests) {
+		t.Errorf("Service.ResendVerification() error = %v, wantErr %v", err, errs.ErrTooManyRequests)
+	}
+	if _, _, err := s.CreateMagicLink(context.Background(), email); !errors.Is(err, errs.ErrTooManyRequests) {
+		t.Errorf("Service.CreateMagicLink() error = %v, wantErr %v", err, errs.ErrTooManyRequests)
+	}
+	if _, _, err := s.CreatePasswordResetToken(context.Background(), email); !errors.Is(err, errs.ErrTooManyRequests) {
+		t.Errorf("Service.CreatePasswordResetToken() error = %v, wantErr %v", err, errs.ErrTooManyRequests)
+	}
+}
+
+func TestService_LoginByMagicLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		tokenErr     error
+		wantLoggedIn bool
+		wantErr      error
+	}{
+		{
+			name:         "good case",
+			wantLoggedIn: true,
+			wantErr:      nil,
+		},
+		{
+			name:     "token used case",
+			tokenErr: errs.ErrTokenUsed,
+			wantErr:  errs.ErrTokenUsed,
+		},
+		{
+			name:     "token expired case",
+			tokenErr: errs.ErrTokenExpired,
+			wantErr:  errs.ErrTokenExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mStorage := token_service_mocks.NewMockStorage(t)
+			mUserService := token_service_mocks.NewMockUserService(t)
+
+			session := models.Session{IP: "127.0.0.1"}
+			mStorage.EXPECT().UserIdByToken(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"token",
+				consts.TokenTypeMagicLink,
+			).Return("user", tt.tokenErr).Once()
+
+			if tt.wantLoggedIn {
+				mUserService.EXPECT().LoginById(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					"guest",
+					session,
+				).Return(models.LoginResult{SessionId: "session"}, nil).Once()
+			}
+
+			s := New(mStorage, mUserService, nil, nil)
+			got, err := s.LoginByMagicLink(context.Background(), "token", "guest", session)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.LoginByMagicLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantLoggedIn && got.SessionId != "session" {
+				t.Errorf("Service.LoginByMagicLink() = %v, want session", got)
+			}
+		})
+	}
+}
+
+func TestService_CreateEmailChangeToken(t *testing.T) {
+	mStorage := token_service_mocks.NewMockStorage(t)
+
+	mStorage.EXPECT().DeleteUserTokens(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"user",
+		consts.TokenTypeEmailChange,
+	).Return(nil).Once()
+	mStorage.EXPECT().SaveEmailToken(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"user",
+		mock.AnythingOfType("string"),
+		consts.TokenTypeEmailChange,
+		"new@mail.com",
+		time.Hour,
+	).Return(nil).Once()
+
+	s := New(mStorage, nil, nil, map[string]time.Duration{consts.TokenTypeEmailChange: time.Hour})
+	got, err := s.CreateEmailChangeToken(context.Background(), "user", "new@mail.com")
+	if err != nil || got == "" {
+		t.Errorf("Service.CreateEmailChangeToken() = %v, %v", got, err)
+	}
+}
+
+func TestService_ConfirmEmail(t *testing.T) {
+	tests := []struct {
+		name       string
+		tokenErr   error
+		confirmErr error
+		wantErr    error
+	}{
+		{
+			name:    "good case",
+			wantErr: nil,
+		},
+		{
+			name:     "token used case",
+			tokenErr: errs.ErrTokenUsed,
+			wantErr:  errs.ErrTokenUsed,
+		},
+		{
+			name:       "email is not pending case",
+			confirmErr: errs.ErrEmailChangeNotFound,
+			wantErr:    errs.ErrEmailChangeNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mStorage := token_service_mocks.NewMockStorage(t)
+			mUserService := token_service_mocks.NewMockUserService(t)
+
+			mStorage.EXPECT().UseEmailToken(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"token",
+				consts.TokenTypeEmailChange,
+			).Return("user", "new@mail.com", tt.tokenErr).Once()
+
+			if tt.tokenErr == nil {
+				mUserService.EXPECT().ConfirmEmailChange(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					"new@mail.com",
+				).Return("old@mail.com", tt.confirmErr).Once()
+			}
+
+			s := New(mStorage, mUserService, nil, nil)
+			oldEmail, newEmail, err := s.ConfirmEmail(context.Background(), "token")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ConfirmEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (oldEmail != "old@mail.com" || newEmail != "new@mail.com") {
+				t.Errorf("Service.ConfirmEmail() = %v, %v, want old@mail.com, new@mail.com", oldEmail, newEmail)
+			}
+		})
+	}
+}
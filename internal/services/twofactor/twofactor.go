@@ -0,0 +1,251 @@
+package twofactor_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/totp"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+type Repository interface {
+	UserById(ctx context.Context, id string) (models.User, error)
+	SetTwoFactorSecret(ctx context.Context, id, secret string) error
+	EnableTwoFactor(ctx context.Context, id string, codeHashes []string) error
+	ReplaceRecoveryCodes(ctx context.Context, id string, codeHashes []string) error
+	DisableTwoFactor(ctx context.Context, id string) error
+	UseTwoFactorStep(ctx context.Context, id string, step int64) error
+}
+
+type SessionStore interface {
+	DeleteUserSessions(ctx context.Context, userId string) error
+}
+
+type CodeLimiter interface {
+	AddFailure(ctx context.Context, key string) (int, error)
+	ResetFailures(ctx context.Context, key string) error
+	Lock(ctx context.Context, key string, expire time.Duration) error
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+}
+
+// CodePolicy Attempts wrong codes are allowed, each next one locks code
+// check for Lockout doubled per failure up to MaxLockout
+type CodePolicy struct {
+	Attempts   int
+	Lockout    time.Duration
+	MaxLockout time.Duration
+}
+
+// Service issuer is shown in authenticator app next to account,
+// recoveryCodes is number of recovery codes generated at once
+type Service struct {
+	repository    Repository
+	sessionStore  SessionStore
+	codeLimiter   CodeLimiter
+	codePolicy    CodePolicy
+	issuer        string
+	recoveryCodes int
+}
+
+func New(
+	repository Repository,
+	sessionStore SessionStore,
+	codeLimiter CodeLimiter,
+	codePolicy CodePolicy,
+	issuer string,
+	recoveryCodes int,
+) *Service {
+	return &Service{
+		repository:    repository,
+		sessionStore:  sessionStore,
+		codeLimiter:   codeLimiter,
+		codePolicy:    codePolicy,
+		issuer:        issuer,
+		recoveryCodes: recoveryCodes,
+	}
+}
+
+// Enrol generates new totp secret which is used after Confirm,
+// returns secret and provisioning uri for qr code
+func (s *Service) Enrol(ctx context.Context, userId string) (string, string, error) {
+	const op = "services.twofactor.Enrol"
+
+	user, err := s.repository.UserById(ctx, userId)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+	if user.TwoFactorEnabled {
+		return "", "", fmt.Errorf("%s: %w", op, errs.ErrTwoFactorEnabled)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.repository.SetTwoFactorSecret(ctx, userId, secret)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secret, totp.URI(s.issuer, user.Email, secret), nil
+}
+
+// Confirm enables two factor authentication if code matches enrolled secret
+// and returns recovery codes. All user sessions are revoked, so next login
+// requires two factor code
+func (s *Service) Confirm(ctx context.Context, userId, code string) ([]string, error) {
+	const op = "services.twofactor.Confirm"
+
+	user, err := s.repository.UserById(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.TwoFactorEnabled {
+		return nil, fmt.Errorf("%s: %w", op, errs.ErrTwoFactorEnabled)
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, fmt.Errorf("%s: %w", op, errs.ErrTwoFactorNotEnrolled)
+	}
+	step, ok := totp.ValidateStep(user.TwoFactorSecret, code, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, errs.ErrWrongTwoFactorCode)
+	}
+
+	err = s.repository.UseTwoFactorStep(ctx, userId, step)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	codes, hashes, err := totp.RecoveryCodes(s.recoveryCodes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.repository.EnableTwoFactor(ctx, userId, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.sessionStore.DeleteUserSessions(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes replaces all user recovery codes with new ones
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userId, code string) ([]string, error) {
+	const op = "services.twofactor.RegenerateRecoveryCodes"
+
+	_, err := s.checkCode(ctx, userId, code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	codes, hashes, err := totp.RecoveryCodes(s.recoveryCodes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.repository.ReplaceRecoveryCodes(ctx, userId, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return codes, nil
+}
+
+// Disable turns two factor authentication off,
+// admins can not disable it and get ErrTwoFactorRequired
+func (s *Service) Disable(ctx context.Context, userId, code string) error {
+	const op = "services.twofactor.Disable"
+
+	user, err := s.checkCode(ctx, userId, code)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if user.Role == consts.RoleAdmin {
+		return fmt.Errorf("%s: %w", op, errs.ErrTwoFactorRequired)
+	}
+
+	err = s.repository.DisableTwoFactor(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// checkCode returns user with enabled two factor authentication if totp code
+// is right, every code is accepted once. Too many wrong codes lock code check
+// together with two factor login, locked check gives ErrTooManyRequests
+func (s *Service) checkCode(ctx context.Context, userId, code string) (models.User, error) {
+	user, err := s.repository.UserById(ctx, userId)
+	if err != nil {
+		return models.User{}, err
+	}
+	if !user.TwoFactorEnabled {
+		return models.User{}, errs.ErrTwoFactorNotEnabled
+	}
+
+	key := codeKey(userId)
+	lockedFor, err := s.codeLimiter.LockedFor(ctx, key)
+	if err != nil {
+		return models.User{}, err
+	}
+	if lockedFor > 0 {
+		return models.User{}, errs.ErrTooManyRequests
+	}
+
+	err = errs.ErrWrongTwoFactorCode
+	if step, ok := totp.ValidateStep(user.TwoFactorSecret, code, time.Now()); ok {
+		err = s.repository.UseTwoFactorStep(ctx, userId, step)
+	}
+	if err != nil && !errors.Is(err, errs.ErrWrongTwoFactorCode) {
+		return models.User{}, err
+	}
+	if err != nil {
+		failures, err := s.codeLimiter.AddFailure(ctx, key)
+		if err != nil {
+			return models.User{}, err
+		}
+		if failures >= s.codePolicy.Attempts {
+			err = s.codeLimiter.Lock(ctx, key, s.lockout(failures-s.codePolicy.Attempts+1))
+			if err != nil {
+				return models.User{}, err
+			}
+			return models.User{}, errs.ErrTooManyRequests
+		}
+		return models.User{}, errs.ErrWrongTwoFactorCode
+	}
+
+	err = s.codeLimiter.ResetFailures(ctx, key)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
+// codeKey is the same key two factor login is limited by,
+// so wrong codes are counted together
+func codeKey(userId string) string {
+	return "2fa:" + userId
+}
+
+// lockout returns lock time after n failures over limit,
+// it doubles with each failure and is capped by MaxLockout
+func (s *Service) lockout(n int) time.Duration {
+	lockout := s.codePolicy.Lockout
+	for i := 1; i < n && lockout < s.codePolicy.MaxLockout; i++ {
+		lockout *= 2
+	}
+
+	return min(lockout, s.codePolicy.MaxLockout)
+}
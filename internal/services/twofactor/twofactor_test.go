@@ -0,0 +1,237 @@
+package twofactor_service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/totp"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	twofactor_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/twofactor/__mocks__"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Enrol(t *testing.T) {
+	mRepository := twofactor_service_mocks.NewMockRepository(t)
+
+	mRepository.EXPECT().UserById(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"user",
+	).Return(models.User{ID: "user", Email: "sas@gmail.com"}, nil).Once()
+	mRepository.EXPECT().SetTwoFactorSecret(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"user",
+		mock.AnythingOfType("string"),
+	).Return(nil).Once()
+
+	s := New(mRepository, nil, nil, CodePolicy{}, "Shop", 10)
+	secret, uri, err := s.Enrol(context.Background(), "user")
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Shop:sas@gmail.com?"))
+	require.Contains(t, uri, "secret="+secret)
+}
+
+func TestService_Confirm(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.Code(secret, time.Now())
+
+	tests := []struct {
+		name    string
+		user    models.User
+		code    string
+		wantErr error
+	}{
+		{
+			name:    "good case",
+			user:    models.User{ID: "user", TwoFactorSecret: secret},
+			code:    code,
+			wantErr: nil,
+		},
+		{
+			name:    "wrong code case",
+			user:    models.User{ID: "user", TwoFactorSecret: secret},
+			code:    "12345a",
+			wantErr: errs.ErrWrongTwoFactorCode,
+		},
+		{
+			name:    "not enrolled case",
+			user:    models.User{ID: "user"},
+			code:    code,
+			wantErr: errs.ErrTwoFactorNotEnrolled,
+		},
+		{
+			name:    "already enabled case",
+			user:    models.User{ID: "user", TwoFactorSecret: secret, TwoFactorEnabled: true},
+			code:    code,
+			wantErr: errs.ErrTwoFactorEnabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := twofactor_service_mocks.NewMockRepository(t)
+			mSessionStore := twofactor_service_mocks.NewMockSessionStore(t)
+
+			mRepository.EXPECT().UserById(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+			).Return(tt.user, nil).Once()
+
+			if tt.wantErr == nil {
+				mRepository.EXPECT().UseTwoFactorStep(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					mock.AnythingOfType("int64"),
+				).Return(nil).Once()
+				mRepository.EXPECT().EnableTwoFactor(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					mock.MatchedBy(func(hashes []string) bool {
+						return len(hashes) == 10
+					}),
+				).Return(nil).Once()
+				mSessionStore.EXPECT().DeleteUserSessions(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+				).Return(nil).Once()
+			}
+
+			s := New(mRepository, mSessionStore, nil, CodePolicy{}, "Shop", 10)
+			codes, err := s.Confirm(context.Background(), "user", tt.code)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				require.Len(t, codes, 10)
+			}
+		})
+	}
+}
+
+func TestService_Disable(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.Code(secret, time.Now())
+
+	tests := []struct {
+		name     string
+		role     string
+		code     string
+		stepErr  error
+		failures int
+		locked   bool
+		wantErr  error
+	}{
+		{
+			name:    "good case",
+			role:    consts.RoleUser,
+			code:    code,
+			wantErr: nil,
+		},
+		{
+			name:    "admin case",
+			role:    consts.RoleAdmin,
+			code:    code,
+			wantErr: errs.ErrTwoFactorRequired,
+		},
+		{
+			name:     "wrong code case",
+			role:     consts.RoleUser,
+			code:     "12345a",
+			failures: 1,
+			wantErr:  errs.ErrWrongTwoFactorCode,
+		},
+		{
+			name:     "replayed code case",
+			role:     consts.RoleUser,
+			code:     code,
+			stepErr:  errs.ErrWrongTwoFactorCode,
+			failures: 1,
+			wantErr:  errs.ErrWrongTwoFactorCode,
+		},
+		{
+			name:     "too many wrong codes case",
+			role:     consts.RoleUser,
+			code:     "12345a",
+			failures: 5,
+			wantErr:  errs.ErrTooManyRequests,
+		},
+		{
+			name:    "locked case",
+			role:    consts.RoleUser,
+			code:    code,
+			locked:  true,
+			wantErr: errs.ErrTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := twofactor_service_mocks.NewMockRepository(t)
+			mCodeLimiter := twofactor_service_mocks.NewMockCodeLimiter(t)
+
+			mRepository.EXPECT().UserById(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+			).Return(models.User{
+				ID:               "user",
+				Role:             tt.role,
+				TwoFactorSecret:  secret,
+				TwoFactorEnabled: true,
+			}, nil).Once()
+
+			var lockedFor time.Duration
+			if tt.locked {
+				lockedFor = time.Minute
+			}
+			mCodeLimiter.EXPECT().LockedFor(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"2fa:user",
+			).Return(lockedFor, nil).Once()
+
+			if !tt.locked && tt.code == code {
+				mRepository.EXPECT().UseTwoFactorStep(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					mock.AnythingOfType("int64"),
+				).Return(tt.stepErr).Once()
+			}
+
+			if tt.failures > 0 {
+				mCodeLimiter.EXPECT().AddFailure(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"2fa:user",
+				).Return(tt.failures, nil).Once()
+			}
+			if tt.failures >= 5 {
+				mCodeLimiter.EXPECT().Lock(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"2fa:user",
+					time.Minute,
+				).Return(nil).Once()
+			}
+
+			if !tt.locked && tt.failures == 0 {
+				mCodeLimiter.EXPECT().ResetFailures(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"2fa:user",
+				).Return(nil).Once()
+			}
+
+			if tt.wantErr == nil {
+				mRepository.EXPECT().DisableTwoFactor(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+				).Return(nil).Once()
+			}
+
+			s := New(mRepository, nil, mCodeLimiter, CodePolicy{
+				Attempts:   5,
+				Lockout:    time.Minute,
+				MaxLockout: time.Hour,
+			}, "Shop", 10)
+			err := s.Disable(context.Background(), "user", tt.code)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
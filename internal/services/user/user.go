@@ -1,32 +1,62 @@
 package user_service
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/AlexMickh/coledzh-shop-backend/internal/consts"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/lib/phone"
 	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
 )
 
+type Repository interface {
+	UserById(ctx context.Context, id string) (models.User, error)
+	UpdateProfile(ctx context.Context, id string, update models.ProfileUpdate) error
+}
+
 type SessionRepository interface {
-	SessionById(ctx context.Context, sessionId string) (models.User, error)
+	SessionById(ctx context.Context, sessionId string) (models.User, models.Session, error)
+	TouchSession(ctx context.Context, sessionId string, lastSeenAt time.Time, expire time.Duration) error
+	SessionsByUserId(ctx context.Context, userId string) ([]models.Session, error)
+	DeleteSession(ctx context.Context, sessionId string) error
 }
 
+// Service prolongs session for idleTimeout on every request,
+// session can not live longer than maxAge since login
 type Service struct {
+	repository        Repository
 	sessionRepository SessionRepository
+	idleTimeout       time.Duration
+	maxAge            time.Duration
 }
 
-func New(sessionRepository SessionRepository) *Service {
+func New(
+	repository Repository,
+	sessionRepository SessionRepository,
+	idleTimeout time.Duration,
+	maxAge time.Duration,
+) *Service {
 	return &Service{
+		repository:        repository,
 		sessionRepository: sessionRepository,
+		idleTimeout:       idleTimeout,
+		maxAge:            maxAge,
 	}
 }
 
+// ValidateAdminSession admins without two factor
+// authentication get ErrTwoFactorRequired
 func (s *Service) ValidateAdminSession(ctx context.Context, sessionId string) error {
 	const op = "services.user.ValidateAdminSession"
 
-	user, err := s.sessionRepository.SessionById(ctx, sessionId)
+	user, err := s.session(ctx, sessionId)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -34,6 +64,9 @@ func (s *Service) ValidateAdminSession(ctx context.Context, sessionId string) er
 	if user.Role != consts.RoleAdmin {
 		return fmt.Errorf("%s: %w", op, errs.ErrNotAdmin)
 	}
+	if !user.TwoFactorEnabled {
+		return fmt.Errorf("%s: %w", op, errs.ErrTwoFactorRequired)
+	}
 
 	return nil
 }
@@ -41,10 +74,131 @@ func (s *Service) ValidateAdminSession(ctx context.Context, sessionId string) er
 func (s *Service) ValidateUserSession(ctx context.Context, sessionId string) (string, error) {
 	const op = "services.user.ValidateUserSession"
 
-	user, err := s.sessionRepository.SessionById(ctx, sessionId)
+	user, err := s.session(ctx, sessionId)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
 	return user.ID, nil
 }
+
+func (s *Service) Profile(ctx context.Context, userId string) (models.User, error) {
+	const op = "services.user.Profile"
+
+	user, err := s.repository.UserById(ctx, userId)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// UpdateProfile updates non nil fields of update, phone is brought
+// to +7XXXXXXXXXX form, empty phone removes it
+func (s *Service) UpdateProfile(ctx context.Context, userId string, update models.ProfileUpdate) error {
+	const op = "services.user.UpdateProfile"
+
+	update.Login = trim(update.Login)
+	update.FirstName = trim(update.FirstName)
+	update.LastName = trim(update.LastName)
+	update.Phone = trim(update.Phone)
+
+	if update.Phone != nil && *update.Phone != "" {
+		phone, ok := phone.Normalize(*update.Phone)
+		if !ok {
+			return fmt.Errorf("%s: %w", op, errs.ErrInvalidPhone)
+		}
+		update.Phone = &phone
+	}
+
+	err := s.repository.UpdateProfile(ctx, userId, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Sessions returns active user sessions with public ids, recently used first
+func (s *Service) Sessions(ctx context.Context, userId, currentSessionId string) ([]models.Session, error) {
+	const op = "services.user.Sessions"
+
+	sessions, err := s.sessionRepository.SessionsByUserId(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionId
+		sessions[i].ID = publicSessionId(sessions[i].ID)
+	}
+	slices.SortFunc(sessions, func(a, b models.Session) int {
+		return cmp.Compare(b.LastSeenAt.Unix(), a.LastSeenAt.Unix())
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession deletes user session by its public id
+func (s *Service) RevokeSession(ctx context.Context, userId, publicId string) error {
+	const op = "services.user.RevokeSession"
+
+	sessions, err := s.sessionRepository.SessionsByUserId(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, session := range sessions {
+		if publicSessionId(session.ID) != publicId {
+			continue
+		}
+
+		err = s.sessionRepository.DeleteSession(ctx, session.ID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", op, errs.ErrSessionNotFound)
+}
+
+// session returns session owner and prolongs session,
+// session older than maxAge is deleted
+func (s *Service) session(ctx context.Context, sessionId string) (models.User, error) {
+	user, session, err := s.sessionRepository.SessionById(ctx, sessionId)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	now := time.Now()
+	expire := min(s.idleTimeout, session.CreatedAt.Add(s.maxAge).Sub(now))
+	if expire <= 0 {
+		err = s.sessionRepository.DeleteSession(ctx, sessionId)
+		if err != nil {
+			return models.User{}, err
+		}
+		return models.User{}, errs.ErrSessionExpired
+	}
+
+	err = s.sessionRepository.TouchSession(ctx, sessionId, now, expire)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
+func trim(field *string) *string {
+	if field == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*field)
+	return &trimmed
+}
+
+func publicSessionId(sessionId string) string {
+	hash := sha256.Sum256([]byte(sessionId))
+	return hex.EncodeToString(hash[:16])
+}
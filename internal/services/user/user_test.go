@@ -0,0 +1,198 @@
+package user_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+	user_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/user/__mocks__"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var sessions = []models.Session{
+	{ID: "old", UserId: "user", LastSeenAt: time.Unix(100, 0)},
+	{ID: "current", UserId: "user", LastSeenAt: time.Unix(300, 0)},
+	{ID: "other", UserId: "user", LastSeenAt: time.Unix(200, 0)},
+}
+
+func TestService_ValidateUserSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		createdAt  time.Time
+		wantExpire time.Duration
+		wantErr    error
+	}{
+		{
+			name:       "good case",
+			createdAt:  time.Now().Add(-time.Hour),
+			wantExpire: time.Hour,
+			wantErr:    nil,
+		},
+		{
+			name:       "close to max age case",
+			createdAt:  time.Now().Add(-24*time.Hour + 10*time.Minute),
+			wantExpire: 10 * time.Minute,
+			wantErr:    nil,
+		},
+		{
+			name:      "max age exceeded case",
+			createdAt: time.Now().Add(-25 * time.Hour),
+			wantErr:   errs.ErrSessionExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := user_service_mocks.NewMockSessionRepository(t)
+
+			mRepository.EXPECT().SessionById(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"current",
+			).Return(models.User{ID: "user"}, models.Session{ID: "current", CreatedAt: tt.createdAt}, nil)
+
+			if tt.wantErr == nil {
+				mRepository.EXPECT().TouchSession(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"current",
+					mock.AnythingOfType("time.Time"),
+					mock.MatchedBy(func(expire time.Duration) bool {
+						return expire <= tt.wantExpire && expire > tt.wantExpire-time.Minute
+					}),
+				).Return(nil).Once()
+			} else {
+				mRepository.EXPECT().DeleteSession(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"current",
+				).Return(nil).Once()
+			}
+
+			s := New(nil, mRepository, time.Hour, 24*time.Hour)
+			userId, err := s.ValidateUserSession(context.Background(), "current")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateUserSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				require.Equal(t, "user", userId)
+			}
+		})
+	}
+}
+
+func TestService_Sessions(t *testing.T) {
+	mRepository := user_service_mocks.NewMockSessionRepository(t)
+
+	mRepository.EXPECT().SessionsByUserId(
+		mock.AnythingOfType("context.backgroundCtx"),
+		"user",
+	).Return(append([]models.Session(nil), sessions...), nil)
+
+	s := New(nil, mRepository, time.Hour, 24*time.Hour)
+	got, err := s.Sessions(context.Background(), "user", "current")
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.Equal(t, publicSessionId("current"), got[0].ID)
+	require.True(t, got[0].Current)
+	require.Equal(t, publicSessionId("other"), got[1].ID)
+	require.False(t, got[1].Current)
+	require.Equal(t, publicSessionId("old"), got[2].ID)
+}
+
+func TestService_RevokeSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		publicId    string
+		wantDeleted string
+		wantErr     error
+	}{
+		{
+			name:        "good case",
+			publicId:    publicSessionId("other"),
+			wantDeleted: "other",
+			wantErr:     nil,
+		},
+		{
+			name:     "session of another user case",
+			publicId: publicSessionId("foreign"),
+			wantErr:  errs.ErrSessionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := user_service_mocks.NewMockSessionRepository(t)
+
+			mRepository.EXPECT().SessionsByUserId(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+			).Return(append([]models.Session(nil), sessions...), nil)
+
+			if tt.wantDeleted != "" {
+				mRepository.EXPECT().DeleteSession(
+					mock.AnythingOfType("context.backgroundCtx"),
+					tt.wantDeleted,
+				).Return(nil).Once()
+			}
+
+			s := New(nil, mRepository, time.Hour, 24*time.Hour)
+			err := s.RevokeSession(context.Background(), "user", tt.publicId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RevokeSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_UpdateProfile(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name      string
+		update    models.ProfileUpdate
+		wantPhone *string
+		wantErr   error
+	}{
+		{
+			name:      "good case",
+			update:    models.ProfileUpdate{FirstName: ptr(" Ivan "), Phone: ptr("8 (912) 345-67-89")},
+			wantPhone: ptr("+79123456789"),
+			wantErr:   nil,
+		},
+		{
+			name:      "remove phone case",
+			update:    models.ProfileUpdate{Phone: ptr("")},
+			wantPhone: ptr(""),
+			wantErr:   nil,
+		},
+		{
+			name:    "invalid phone case",
+			update:  models.ProfileUpdate{Phone: ptr("12345")},
+			wantErr: errs.ErrInvalidPhone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := user_service_mocks.NewMockRepository(t)
+
+			if tt.wantErr == nil {
+				mRepository.EXPECT().UpdateProfile(
+					mock.AnythingOfType("context.backgroundCtx"),
+					"user",
+					mock.MatchedBy(func(update models.ProfileUpdate) bool {
+						if update.FirstName != nil && *update.FirstName != "Ivan" {
+							return false
+						}
+						return *update.Phone == *tt.wantPhone
+					}),
+				).Return(nil).Once()
+			}
+
+			s := New(mRepository, nil, time.Hour, 24*time.Hour)
+			err := s.UpdateProfile(context.Background(), "user", tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
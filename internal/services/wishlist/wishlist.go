@@ -0,0 +1,92 @@
+package wishlist_service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/models"
+)
+
+type Repository interface {
+	AddProduct(ctx context.Context, userId, productId string) error
+	DeleteProduct(ctx context.Context, userId, productId string) error
+	WishlistByUserId(ctx context.Context, userId string) ([]models.ProductCard, error)
+	FavoriteProductIds(ctx context.Context, userId string, productIds []string) ([]string, error)
+	MoveToCart(ctx context.Context, userId, productId string, maxQuantity int) (string, error)
+}
+
+// Service maxQuantity is limit of product quantity in cart
+type Service struct {
+	repository  Repository
+	maxQuantity int
+}
+
+func New(repository Repository, maxQuantity int) *Service {
+	return &Service{
+		repository:  repository,
+		maxQuantity: maxQuantity,
+	}
+}
+
+func (s *Service) AddProduct(ctx context.Context, userId, productId string) error {
+	const op = "services.wishlist.AddProduct"
+
+	err := s.repository.AddProduct(ctx, userId, productId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteProduct(ctx context.Context, userId, productId string) error {
+	const op = "services.wishlist.DeleteProduct"
+
+	err := s.repository.DeleteProduct(ctx, userId, productId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) WishlistByUserId(ctx context.Context, userId string) ([]models.ProductCard, error) {
+	const op = "services.wishlist.WishlistByUserId"
+
+	products, err := s.repository.WishlistByUserId(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return products, nil
+}
+
+// MoveToCart adds product from wishlist to cart and removes it from wishlist,
+// both happen or none of them
+func (s *Service) MoveToCart(ctx context.Context, userId, productId string) (string, error) {
+	const op = "services.wishlist.MoveToCart"
+
+	cartId, err := s.repository.MoveToCart(ctx, userId, productId, s.maxQuantity)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cartId, nil
+}
+
+// FavoriteProducts returns set of productIds which are in user wishlist
+func (s *Service) FavoriteProducts(ctx context.Context, userId string, productIds []string) (map[string]bool, error) {
+	const op = "services.wishlist.FavoriteProducts"
+
+	ids, err := s.repository.FavoriteProductIds(ctx, userId, productIds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	favorites := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		favorites[id] = true
+	}
+
+	return favorites, nil
+}
@@ -0,0 +1,51 @@
+package wishlist_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlexMickh/coledzh-shop-backend/internal/errs"
+	wishlist_service_mocks "github.com/AlexMickh/coledzh-shop-backend/internal/services/wishlist/__mocks__"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_MoveToCart(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{
+			name:    "good case",
+			wantErr: nil,
+		},
+		{
+			name:    "not in wishlist case",
+			repoErr: errs.ErrProductNotInWishlist,
+			wantErr: errs.ErrProductNotInWishlist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mRepository := wishlist_service_mocks.NewMockRepository(t)
+
+			mRepository.EXPECT().MoveToCart(
+				mock.AnythingOfType("context.backgroundCtx"),
+				"user",
+				"product",
+				99,
+			).Return("cart", tt.repoErr).Once()
+
+			s := New(mRepository, 99)
+			got, err := s.MoveToCart(context.Background(), "user", "product")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.MoveToCart() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && got == "" {
+				t.Error("Service.MoveToCart() = cart id is empty")
+			}
+		})
+	}
+}
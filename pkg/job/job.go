@@ -0,0 +1,65 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/AlexMickh/coledzh-shop-backend/pkg/logger"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Fn       func(ctx context.Context) error
+}
+
+// Run starts every job in its own goroutine, job is called each interval
+// until ctx is done or returned stop is called. Job errors are logged and
+// do not stop the job, job with not positive interval is not started.
+// Stop waits for running jobs, so resources they use can be closed after it
+func Run(ctx context.Context, jobs ...Job) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		if job.Interval <= 0 {
+			logger.FromCtx(ctx).Error(
+				"job interval must be positive",
+				slog.String("job", job.Name),
+				slog.Duration("interval", job.Interval),
+			)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(ctx, job)
+		}()
+	}
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func run(ctx context.Context, job Job) {
+	log := logger.FromCtx(ctx).With(slog.String("job", job.Name))
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.Fn(ctx); err != nil {
+				log.Error("job failed", logger.Err(err))
+			}
+		}
+	}
+}
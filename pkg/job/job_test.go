@@ -0,0 +1,63 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	_ = Run(ctx, Job{
+		Name:     "test",
+		Interval: 10 * time.Millisecond,
+		Fn: func(ctx context.Context) error {
+			calls.Add(1)
+			return errors.New("failed")
+		},
+	})
+
+	time.Sleep(55 * time.Millisecond)
+	cancel()
+
+	if got := calls.Load(); got < 2 {
+		t.Errorf("Run() calls = %d, want at least 2 despite errors", got)
+	}
+
+	stopped := calls.Load()
+	time.Sleep(30 * time.Millisecond)
+	if got := calls.Load(); got > stopped+1 {
+		t.Errorf("Run() calls after cancel = %d, want job stopped", got-stopped)
+	}
+}
+
+func TestRun_Stop(t *testing.T) {
+	var finished atomic.Bool
+	stop := Run(context.Background(), Job{
+		Name:     "test",
+		Interval: time.Millisecond,
+		Fn: func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			finished.Store(true)
+			return nil
+		},
+	}, Job{
+		Name: "zero interval",
+		Fn: func(ctx context.Context) error {
+			return nil
+		},
+	})
+
+	time.Sleep(5 * time.Millisecond)
+	stop()
+
+	if !finished.Load() {
+		t.Error("Run() stop returned before running job finished")
+	}
+}
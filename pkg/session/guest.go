@@ -0,0 +1,72 @@
+package session
+
+import (
+	"net/http"
+	"time"
+)
+
+type Signer interface {
+	Sign(value string) string
+	Verify(signed string) (string, error)
+}
+
+// Guest keeps anonymous visitor id in signed cookie
+type Guest struct {
+	name   string
+	secure bool
+	maxAge int
+	signer Signer
+}
+
+func NewGuest(
+	name string,
+	secure bool,
+	maxAge int,
+	signer Signer,
+) *Guest {
+	return &Guest{
+		name:   name,
+		secure: secure,
+		maxAge: maxAge,
+		signer: signer,
+	}
+}
+
+func (g *Guest) Create(w http.ResponseWriter, guestId string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     g.name,
+		Value:    g.signer.Sign(guestId),
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   g.secure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   g.maxAge,
+	})
+}
+
+// GuestId returns guest id when request has cookie with valid signature
+func (g *Guest) GuestId(r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(g.name)
+	if err != nil {
+		return "", false
+	}
+
+	guestId, err := g.signer.Verify(cookie.Value)
+	if err != nil {
+		return "", false
+	}
+
+	return guestId, true
+}
+
+func (g *Guest) Delete(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     g.name,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   g.secure,
+		MaxAge:   -1,
+		Expires:  time.Unix(0, 0),
+	})
+}
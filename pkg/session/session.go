@@ -1,11 +1,16 @@
 package session
 
-import "net/http"
+import (
+	"net/http"
+	"strings"
+	"time"
+)
 
 type Session struct {
 	name     string
 	httpOnly bool
 	secure   bool
+	sameSite http.SameSite
 	maxAge   int
 }
 
@@ -13,16 +18,31 @@ func New(
 	name string,
 	httpOnly bool,
 	secure bool,
+	sameSite http.SameSite,
 	maxAge int,
 ) *Session {
 	return &Session{
 		name:     name,
 		httpOnly: httpOnly,
 		secure:   secure,
+		sameSite: sameSite,
 		maxAge:   maxAge,
 	}
 }
 
+// ParseSameSite converts lax, strict or none into http.SameSite,
+// unknown value gives strict mode
+func ParseSameSite(sameSite string) http.SameSite {
+	switch strings.ToLower(sameSite) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
 func (s *Session) Create(w http.ResponseWriter, sessionId string) {
 	cookie := &http.Cookie{
 		Name:     s.name,
@@ -30,9 +50,31 @@ func (s *Session) Create(w http.ResponseWriter, sessionId string) {
 		Path:     "/",
 		HttpOnly: s.httpOnly,
 		Secure:   s.secure,
-		SameSite: http.SameSiteStrictMode,
+		SameSite: s.sameSite,
 		MaxAge:   s.maxAge,
 	}
 	http.SetCookie(w, cookie)
 	w.WriteHeader(http.StatusCreated)
 }
+
+func (s *Session) SessionId(r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(s.name)
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+
+	return cookie.Value, true
+}
+
+func (s *Session) Delete(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.name,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: s.httpOnly,
+		Secure:   s.secure,
+		SameSite: s.sameSite,
+		MaxAge:   -1,
+		Expires:  time.Unix(0, 0),
+	})
+}
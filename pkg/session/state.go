@@ -0,0 +1,76 @@
+package session
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+)
+
+// State keeps login state in short-lived signed cookie, so login
+// can be completed only in browser which started it
+type State struct {
+	name     string
+	path     string
+	secure   bool
+	sameSite http.SameSite
+	maxAge   int
+	signer   Signer
+}
+
+func NewState(
+	name string,
+	path string,
+	secure bool,
+	sameSite http.SameSite,
+	maxAge int,
+	signer Signer,
+) *State {
+	return &State{
+		name:     name,
+		path:     path,
+		secure:   secure,
+		sameSite: sameSite,
+		maxAge:   maxAge,
+		signer:   signer,
+	}
+}
+
+func (s *State) Create(w http.ResponseWriter, state string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.name,
+		Value:    s.signer.Sign(state),
+		Path:     s.path,
+		HttpOnly: true,
+		Secure:   s.secure,
+		SameSite: s.sameSite,
+		MaxAge:   s.maxAge,
+	})
+}
+
+// Verify reports whether request has cookie with valid signature and state
+func (s *State) Verify(r *http.Request, state string) bool {
+	cookie, err := r.Cookie(s.name)
+	if err != nil {
+		return false
+	}
+
+	value, err := s.signer.Verify(cookie.Value)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(value), []byte(state)) == 1
+}
+
+func (s *State) Delete(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.name,
+		Value:    "",
+		Path:     s.path,
+		HttpOnly: true,
+		Secure:   s.secure,
+		SameSite: s.sameSite,
+		MaxAge:   -1,
+		Expires:  time.Unix(0, 0),
+	})
+}
@@ -0,0 +1,46 @@
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+type Signer struct {
+	secret []byte
+}
+
+func New(secret string) *Signer {
+	return &Signer{
+		secret: []byte(secret),
+	}
+}
+
+// Sign returns value with appended HMAC-SHA256 signature
+func (s *Signer) Sign(value string) string {
+	return value + "." + s.signature(value)
+}
+
+// Verify checks signature and returns original value
+func (s *Signer) Verify(signed string) (string, error) {
+	value, signature, ok := strings.Cut(signed, ".")
+	if !ok || value == "" {
+		return "", ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(value))) {
+		return "", ErrInvalidSignature
+	}
+
+	return value, nil
+}
+
+func (s *Signer) signature(value string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,51 @@
+package signer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSigner_Verify(t *testing.T) {
+	s := New("secret")
+
+	tests := []struct {
+		name    string
+		signed  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "good case",
+			signed:  s.Sign("guest-id"),
+			want:    "guest-id",
+			wantErr: nil,
+		},
+		{
+			name:    "tampered value case",
+			signed:  "other-id." + s.signature("guest-id"),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "other secret case",
+			signed:  New("other").Sign("guest-id"),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "no signature case",
+			signed:  "guest-id",
+			wantErr: ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Verify(tt.signed)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Signer.Verify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Signer.Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package slug
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxLength   = 100
+	maxAttempts = 50
+)
+
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+}
+
+// Make transliterates cyrillic to latin and returns lower case slug
+// of latin letters, digits and single dashes
+func Make(s string) string {
+	builder := new(strings.Builder)
+	dash := false
+
+	for _, r := range strings.ToLower(s) {
+		if latin, ok := translit[r]; ok {
+			builder.WriteString(latin)
+			dash = false
+			continue
+		}
+
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			builder.WriteRune(r)
+			dash = false
+			continue
+		}
+
+		if !dash && builder.Len() > 0 {
+			builder.WriteByte('-')
+			dash = true
+		}
+	}
+
+	slug := builder.String()
+	if len(slug) > maxLength {
+		slug = slug[:maxLength]
+	}
+
+	return strings.Trim(slug, "-")
+}
+
+// IsValid reports whether s is already a slug
+func IsValid(s string) bool {
+	return s != "" && Make(s) == s
+}
+
+// Unique makes slug from s and adds numeric suffix until exists reports it is free
+func Unique(ctx context.Context, s string, fallback string, exists func(ctx context.Context, slug string) (bool, error)) (string, error) {
+	const op = "slug.Unique"
+
+	base := Make(s)
+	if base == "" {
+		base = fallback
+	}
+
+	slug := base
+	for i := 2; i <= maxAttempts+1; i++ {
+		taken, err := exists(ctx, slug)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		if !taken {
+			return slug, nil
+		}
+
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	return fmt.Sprintf("%s-%s", base, uuid.NewString()[:8]), nil
+}
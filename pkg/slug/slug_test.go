@@ -0,0 +1,91 @@
+package slug
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "latin",
+			s:    "iPhone 15 Pro",
+			want: "iphone-15-pro",
+		},
+		{
+			name: "cyrillic",
+			s:    "Щётка для обуви",
+			want: "shchetka-dlya-obuvi",
+		},
+		{
+			name: "soft and hard signs",
+			s:    "Объёмный коньяк",
+			want: "obemnyy-konyak",
+		},
+		{
+			name: "punctuation",
+			s:    "  Чай, кофе & какао!!! ",
+			want: "chay-kofe-kakao",
+		},
+		{
+			name: "nothing to keep",
+			s:    "***",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Make(tt.s); got != tt.want {
+				t.Errorf("Make() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnique(t *testing.T) {
+	taken := map[string]bool{
+		"chay":   true,
+		"chay-2": true,
+	}
+	exists := func(ctx context.Context, slug string) (bool, error) {
+		return taken[slug], nil
+	}
+
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "free slug",
+			s:    "Кофе",
+			want: "kofe",
+		},
+		{
+			name: "taken slug",
+			s:    "Чай",
+			want: "chay-3",
+		},
+		{
+			name: "fallback",
+			s:    "!!!",
+			want: "product",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Unique(context.Background(), tt.s, "product", exists)
+			if err != nil {
+				t.Errorf("Unique() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Unique() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}